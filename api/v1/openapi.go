@@ -0,0 +1,8 @@
+package v1
+
+import _ "embed"
+
+// OpenAPI is the OpenAPI 3 description of the agent's HTTP/JSON gateway.
+//
+//go:embed synapse.openapi.yaml
+var OpenAPI []byte
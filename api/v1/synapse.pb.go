@@ -9,6 +9,7 @@ package v1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,18 +24,29 @@ const (
 
 // Kpak represents a knowledge packet - the atomic unit of knowledge
 type Kpak struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`                       // Who/what this is about
-	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`                   // The relationship/property
-	Object        string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`                         // The value (JSON-encoded for flexibility)
-	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                         // Origin of this knowledge
-	Confidence    float32                `protobuf:"fixed32,5,opt,name=confidence,proto3" json:"confidence,omitempty"`               // Trust level (0.0-1.0)
-	Timestamp     int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                  // Unix timestamp when created
-	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`                                 // Content hash for uniqueness
-	Spid          string                 `protobuf:"bytes,8,opt,name=spid,proto3" json:"spid,omitempty"`                             // Subject+Predicate hash for indexing
-	ExpiresAt     int64                  `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp when this k-pak expires (0 = never expires)
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Subject             string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`                                                                             // Who/what this is about
+	Predicate           string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`                                                                         // The relationship/property
+	Object              string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`                                                                               // The value as plain text (kept for clients that do not read `value`)
+	Source              string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                                                                               // Origin of this knowledge
+	Confidence          float32                `protobuf:"fixed32,5,opt,name=confidence,proto3" json:"confidence,omitempty"`                                                                     // Trust level (0.0-1.0)
+	Timestamp           int64                  `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                                                                        // Unix timestamp when created
+	Id                  string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"`                                                                                       // Content hash for uniqueness
+	Spid                string                 `protobuf:"bytes,8,opt,name=spid,proto3" json:"spid,omitempty"`                                                                                   // Subject+Predicate hash for indexing
+	ExpiresAt           int64                  `protobuf:"varint,9,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`                                                       // Unix timestamp when this k-pak expires (0 = never expires)
+	FusedConfidence     float32                `protobuf:"fixed32,10,opt,name=fused_confidence,json=fusedConfidence,proto3" json:"fused_confidence,omitempty"`                                   // Combined confidence of all claims backing this value (query results only)
+	SupportingSources   []string               `protobuf:"bytes,11,rep,name=supporting_sources,json=supportingSources,proto3" json:"supporting_sources,omitempty"`                               // Sources whose claims back this value (query results only)
+	EffectiveConfidence float32                `protobuf:"fixed32,12,opt,name=effective_confidence,json=effectiveConfidence,proto3" json:"effective_confidence,omitempty"`                       // Confidence after age-based decay (query results only)
+	Value               *Value                 `protobuf:"bytes,13,opt,name=value,proto3" json:"value,omitempty"`                                                                                // Typed value; takes precedence over `object` when set
+	Version             map[string]uint64      `protobuf:"bytes,14,rep,name=version,proto3" json:"version,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Version vector of the fact when this claim was accepted (ignored on ingest)
+	Pending             bool                   `protobuf:"varint,15,opt,name=pending,proto3" json:"pending,omitempty"`                                                                           // Outranks the truth but is waiting out its predicate's damping rule (query results only)
+	PendingSince        int64                  `protobuf:"varint,16,opt,name=pending_since,json=pendingSince,proto3" json:"pending_since,omitempty"`                                             // Unix timestamp when the pending value started winning (query results only)
+	Confirmations       int32                  `protobuf:"varint,17,opt,name=confirmations,proto3" json:"confirmations,omitempty"`                                                               // Claims confirming the pending value so far (query results only)
+	Pinned              bool                   `protobuf:"varint,18,opt,name=pinned,proto3" json:"pinned,omitempty"`                                                                             // Set by an operator override rather than reconciliation (query results only)
+	PinnedBy            string                 `protobuf:"bytes,19,opt,name=pinned_by,json=pinnedBy,proto3" json:"pinned_by,omitempty"`                                                          // Operator who pinned the value (query results only)
+	PinReason           string                 `protobuf:"bytes,20,opt,name=pin_reason,json=pinReason,proto3" json:"pin_reason,omitempty"`                                                       // Why the value was pinned (query results only)
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *Kpak) Reset() {
@@ -130,19 +142,276 @@ func (x *Kpak) GetExpiresAt() int64 {
 	return 0
 }
 
+func (x *Kpak) GetFusedConfidence() float32 {
+	if x != nil {
+		return x.FusedConfidence
+	}
+	return 0
+}
+
+func (x *Kpak) GetSupportingSources() []string {
+	if x != nil {
+		return x.SupportingSources
+	}
+	return nil
+}
+
+func (x *Kpak) GetEffectiveConfidence() float32 {
+	if x != nil {
+		return x.EffectiveConfidence
+	}
+	return 0
+}
+
+func (x *Kpak) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *Kpak) GetVersion() map[string]uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return nil
+}
+
+func (x *Kpak) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *Kpak) GetPendingSince() int64 {
+	if x != nil {
+		return x.PendingSince
+	}
+	return 0
+}
+
+func (x *Kpak) GetConfirmations() int32 {
+	if x != nil {
+		return x.Confirmations
+	}
+	return 0
+}
+
+func (x *Kpak) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *Kpak) GetPinnedBy() string {
+	if x != nil {
+		return x.PinnedBy
+	}
+	return ""
+}
+
+func (x *Kpak) GetPinReason() string {
+	if x != nil {
+		return x.PinReason
+	}
+	return ""
+}
+
+// Value is a typed k-pak object
+type Value struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Kind:
+	//
+	//	*Value_StringValue
+	//	*Value_IntValue
+	//	*Value_FloatValue
+	//	*Value_BoolValue
+	//	*Value_BytesValue
+	//	*Value_TimestampValue
+	//	*Value_JsonValue
+	//	*Value_RefValue
+	Kind          isValue_Kind `protobuf_oneof:"kind"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Value) Reset() {
+	*x = Value{}
+	mi := &file_api_v1_synapse_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Value) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Value) ProtoMessage() {}
+
+func (x *Value) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Value.ProtoReflect.Descriptor instead.
+func (*Value) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Value) GetKind() isValue_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return nil
+}
+
+func (x *Value) GetStringValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_StringValue); ok {
+			return x.StringValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetIntValue() int64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_IntValue); ok {
+			return x.IntValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetFloatValue() float64 {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_FloatValue); ok {
+			return x.FloatValue
+		}
+	}
+	return 0
+}
+
+func (x *Value) GetBoolValue() bool {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BoolValue); ok {
+			return x.BoolValue
+		}
+	}
+	return false
+}
+
+func (x *Value) GetBytesValue() []byte {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_BytesValue); ok {
+			return x.BytesValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetTimestampValue() *timestamppb.Timestamp {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_TimestampValue); ok {
+			return x.TimestampValue
+		}
+	}
+	return nil
+}
+
+func (x *Value) GetJsonValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_JsonValue); ok {
+			return x.JsonValue
+		}
+	}
+	return ""
+}
+
+func (x *Value) GetRefValue() string {
+	if x != nil {
+		if x, ok := x.Kind.(*Value_RefValue); ok {
+			return x.RefValue
+		}
+	}
+	return ""
+}
+
+type isValue_Kind interface {
+	isValue_Kind()
+}
+
+type Value_StringValue struct {
+	StringValue string `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3,oneof"`
+}
+
+type Value_IntValue struct {
+	IntValue int64 `protobuf:"varint,2,opt,name=int_value,json=intValue,proto3,oneof"`
+}
+
+type Value_FloatValue struct {
+	FloatValue float64 `protobuf:"fixed64,3,opt,name=float_value,json=floatValue,proto3,oneof"`
+}
+
+type Value_BoolValue struct {
+	BoolValue bool `protobuf:"varint,4,opt,name=bool_value,json=boolValue,proto3,oneof"`
+}
+
+type Value_BytesValue struct {
+	BytesValue []byte `protobuf:"bytes,5,opt,name=bytes_value,json=bytesValue,proto3,oneof"`
+}
+
+type Value_TimestampValue struct {
+	TimestampValue *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=timestamp_value,json=timestampValue,proto3,oneof"`
+}
+
+type Value_JsonValue struct {
+	JsonValue string `protobuf:"bytes,7,opt,name=json_value,json=jsonValue,proto3,oneof"` // A JSON document
+}
+
+type Value_RefValue struct {
+	RefValue string `protobuf:"bytes,8,opt,name=ref_value,json=refValue,proto3,oneof"` // Subject of another fact in the mesh
+}
+
+func (*Value_StringValue) isValue_Kind() {}
+
+func (*Value_IntValue) isValue_Kind() {}
+
+func (*Value_FloatValue) isValue_Kind() {}
+
+func (*Value_BoolValue) isValue_Kind() {}
+
+func (*Value_BytesValue) isValue_Kind() {}
+
+func (*Value_TimestampValue) isValue_Kind() {}
+
+func (*Value_JsonValue) isValue_Kind() {}
+
+func (*Value_RefValue) isValue_Kind() {}
+
 // IngestResponse confirms receipt of knowledge packets
 type IngestResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Accepted      int32                  `protobuf:"varint,1,opt,name=accepted,proto3" json:"accepted,omitempty"` // Number of k-paks accepted
 	Rejected      int32                  `protobuf:"varint,2,opt,name=rejected,proto3" json:"rejected,omitempty"` // Number of k-paks rejected
 	Errors        []string               `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`      // Error messages if any
+	Held          int32                  `protobuf:"varint,4,opt,name=held,proto3" json:"held,omitempty"`         // Number of k-paks held for review (not counted as accepted or rejected)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *IngestResponse) Reset() {
 	*x = IngestResponse{}
-	mi := &file_api_v1_synapse_proto_msgTypes[1]
+	mi := &file_api_v1_synapse_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -154,7 +423,75 @@ func (x *IngestResponse) String() string {
 func (*IngestResponse) ProtoMessage() {}
 
 func (x *IngestResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[1]
+	mi := &file_api_v1_synapse_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IngestResponse.ProtoReflect.Descriptor instead.
+func (*IngestResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *IngestResponse) GetAccepted() int32 {
+	if x != nil {
+		return x.Accepted
+	}
+	return 0
+}
+
+func (x *IngestResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
+func (x *IngestResponse) GetErrors() []string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *IngestResponse) GetHeld() int32 {
+	if x != nil {
+		return x.Held
+	}
+	return 0
+}
+
+// QueryRequest specifies what knowledge to retrieve
+type QueryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`                                          // Query by subject
+	Predicate     *string                `protobuf:"bytes,2,opt,name=predicate,proto3,oneof" json:"predicate,omitempty"`                                // Optional: filter by predicate
+	MinConfidence *float32               `protobuf:"fixed32,3,opt,name=min_confidence,json=minConfidence,proto3,oneof" json:"min_confidence,omitempty"` // Optional: drop results whose effective (or fused) confidence is lower
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryRequest) Reset() {
+	*x = QueryRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryRequest) ProtoMessage() {}
+
+func (x *QueryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -162,59 +499,1752 @@ func (x *IngestResponse) ProtoReflect() protoreflect.Message {
 		}
 		return ms
 	}
-	return mi.MessageOf(x)
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
+func (*QueryRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueryRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetPredicate() string {
+	if x != nil && x.Predicate != nil {
+		return *x.Predicate
+	}
+	return ""
+}
+
+func (x *QueryRequest) GetMinConfidence() float32 {
+	if x != nil && x.MinConfidence != nil {
+		return *x.MinConfidence
+	}
+	return 0
+}
+
+// Health check messages
+type HealthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthRequest) Reset() {
+	*x = HealthRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthRequest) ProtoMessage() {}
+
+func (x *HealthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{4}
+}
+
+type HealthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                     // "healthy", "degraded" or "unhealthy": the worst of the checks
+	KpakCount     int32                  `protobuf:"varint,2,opt,name=kpak_count,json=kpakCount,proto3" json:"kpak_count,omitempty"`             // Total knowledge packets stored
+	UptimeSeconds int64                  `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"` // How long this agent has been running
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`                                   // The checks that are not healthy, or that all are
+	Checks        []*HealthCheck         `protobuf:"bytes,5,rep,name=checks,proto3" json:"checks,omitempty"`                                     // Every check, by name
+	State         string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`                                       // Lifecycle: "starting", "replaying", "syncing", "ready" or "draining"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthResponse) Reset() {
+	*x = HealthResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthResponse) ProtoMessage() {}
+
+func (x *HealthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
+func (*HealthResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *HealthResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetKpakCount() int32 {
+	if x != nil {
+		return x.KpakCount
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *HealthResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *HealthResponse) GetChecks() []*HealthCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+func (x *HealthResponse) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+// HealthCheck is the outcome of one health check.
+type HealthCheck struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // "wal", "gossip", "disk", ...
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`   // "healthy", "degraded" or "unhealthy"
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"` // What was measured
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HealthCheck) Reset() {
+	*x = HealthCheck{}
+	mi := &file_api_v1_synapse_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HealthCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HealthCheck) ProtoMessage() {}
+
+func (x *HealthCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HealthCheck.ProtoReflect.Descriptor instead.
+func (*HealthCheck) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HealthCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *HealthCheck) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Peer information messages
+type PeersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeersRequest) Reset() {
+	*x = PeersRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersRequest) ProtoMessage() {}
+
+func (x *PeersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersRequest.ProtoReflect.Descriptor instead.
+func (*PeersRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{7}
+}
+
+type PeersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peers         []*PeerInfo            `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeersResponse) Reset() {
+	*x = PeersResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeersResponse) ProtoMessage() {}
+
+func (x *PeersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeersResponse.ProtoReflect.Descriptor instead.
+func (*PeersResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PeersResponse) GetPeers() []*PeerInfo {
+	if x != nil {
+		return x.Peers
+	}
+	return nil
+}
+
+type PeerInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`                            // IP:port of the peer
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                                  // Peer name/ID
+	State         int32                  `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`                               // Peer state (0=alive, 1=suspect, 2=dead)
+	LastSeen      int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`         // Unix timestamp of last contact
+	GrpcAddress   string                 `protobuf:"bytes,5,opt,name=grpc_address,json=grpcAddress,proto3" json:"grpc_address,omitempty"` // IP:port of the peer's API, if it advertises one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PeerInfo) Reset() {
+	*x = PeerInfo{}
+	mi := &file_api_v1_synapse_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PeerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PeerInfo) ProtoMessage() {}
+
+func (x *PeerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PeerInfo.ProtoReflect.Descriptor instead.
+func (*PeerInfo) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PeerInfo) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *PeerInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PeerInfo) GetState() int32 {
+	if x != nil {
+		return x.State
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PeerInfo) GetGrpcAddress() string {
+	if x != nil {
+		return x.GrpcAddress
+	}
+	return ""
+}
+
+// Metrics messages
+type MetricsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MetricsRequest) Reset() {
+	*x = MetricsRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsRequest) ProtoMessage() {}
+
+func (x *MetricsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsRequest.ProtoReflect.Descriptor instead.
+func (*MetricsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{10}
+}
+
+type MetricsResponse struct {
+	state                    protoimpl.MessageState `protogen:"open.v1"`
+	TotalKpaks               int32                  `protobuf:"varint,1,opt,name=total_kpaks,json=totalKpaks,proto3" json:"total_kpaks,omitempty"`                                                                                                                          // Total k-paks in memory
+	TotalSubjects            int32                  `protobuf:"varint,2,opt,name=total_subjects,json=totalSubjects,proto3" json:"total_subjects,omitempty"`                                                                                                                 // Total unique subjects
+	IngestRatePerMin         int64                  `protobuf:"varint,3,opt,name=ingest_rate_per_min,json=ingestRatePerMin,proto3" json:"ingest_rate_per_min,omitempty"`                                                                                                    // K-paks ingested per minute
+	QueryRatePerMin          int64                  `protobuf:"varint,4,opt,name=query_rate_per_min,json=queryRatePerMin,proto3" json:"query_rate_per_min,omitempty"`                                                                                                       // Queries per minute
+	UptimeSeconds            int64                  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`                                                                                                                 // Agent uptime
+	MemoryUsageBytes         int64                  `protobuf:"varint,6,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`                                                                                                      // Resident memory (heap obtained from the OS where RSS is unknown)
+	CpuUsagePercent          float32                `protobuf:"fixed32,7,opt,name=cpu_usage_percent,json=cpuUsagePercent,proto3" json:"cpu_usage_percent,omitempty"`                                                                                                        // CPU usage over the last minute; 100 is one full core
+	Version                  string                 `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`                                                                                                                                                   // Agent version
+	ActiveSources            []string               `protobuf:"bytes,9,rep,name=active_sources,json=activeSources,proto3" json:"active_sources,omitempty"`                                                                                                                  // List of active data sources
+	PolicyViolations         int64                  `protobuf:"varint,10,opt,name=policy_violations,json=policyViolations,proto3" json:"policy_violations,omitempty"`                                                                                                       // Claims refused because their source may not assert them
+	ConfidenceCapped         int64                  `protobuf:"varint,11,opt,name=confidence_capped,json=confidenceCapped,proto3" json:"confidence_capped,omitempty"`                                                                                                       // Claims lowered to their source's confidence cap
+	PolicyViolationsBySource map[string]int64       `protobuf:"bytes,12,rep,name=policy_violations_by_source,json=policyViolationsBySource,proto3" json:"policy_violations_by_source,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Refused claims per source
+	Throttled                int64                  `protobuf:"varint,13,opt,name=throttled,proto3" json:"throttled,omitempty"`                                                                                                                                             // Claims that hit a source or client rate limit
+	ThrottledBySource        map[string]int64       `protobuf:"bytes,14,rep,name=throttled_by_source,json=throttledBySource,proto3" json:"throttled_by_source,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`                        // Rate-limited claims per source
+	QuotaExceeded            int64                  `protobuf:"varint,15,opt,name=quota_exceeded,json=quotaExceeded,proto3" json:"quota_exceeded,omitempty"`                                                                                                                // Claims refused because their source used up its daily quota
+	HeapAllocBytes           int64                  `protobuf:"varint,16,opt,name=heap_alloc_bytes,json=heapAllocBytes,proto3" json:"heap_alloc_bytes,omitempty"`                                                                                                           // Bytes of allocated heap objects
+	Goroutines               int32                  `protobuf:"varint,17,opt,name=goroutines,proto3" json:"goroutines,omitempty"`                                                                                                                                           // Live goroutines
+	OpenFds                  int32                  `protobuf:"varint,18,opt,name=open_fds,json=openFds,proto3" json:"open_fds,omitempty"`                                                                                                                                  // Open file descriptors (-1 = unknown on this platform)
+	GcRuns                   uint32                 `protobuf:"varint,19,opt,name=gc_runs,json=gcRuns,proto3" json:"gc_runs,omitempty"`                                                                                                                                     // Completed Go GC cycles
+	GcPauseTotalNs           int64                  `protobuf:"varint,20,opt,name=gc_pause_total_ns,json=gcPauseTotalNs,proto3" json:"gc_pause_total_ns,omitempty"`                                                                                                         // Go GC pause time since the agent started
+	GcPauseLastNs            int64                  `protobuf:"varint,21,opt,name=gc_pause_last_ns,json=gcPauseLastNs,proto3" json:"gc_pause_last_ns,omitempty"`                                                                                                            // Pause of the most recent Go GC cycle
+	GcPauseMaxNs             int64                  `protobuf:"varint,22,opt,name=gc_pause_max_ns,json=gcPauseMaxNs,proto3" json:"gc_pause_max_ns,omitempty"`                                                                                                               // Longest of the last 256 Go GC pauses
+	IngestRatePerMinAvg5     float64                `protobuf:"fixed64,23,opt,name=ingest_rate_per_min_avg5,json=ingestRatePerMinAvg5,proto3" json:"ingest_rate_per_min_avg5,omitempty"`                                                                                    // K-paks ingested per minute, averaged over 5 minutes
+	IngestRatePerMinAvg15    float64                `protobuf:"fixed64,24,opt,name=ingest_rate_per_min_avg15,json=ingestRatePerMinAvg15,proto3" json:"ingest_rate_per_min_avg15,omitempty"`                                                                                 // K-paks ingested per minute, averaged over 15 minutes
+	QueryRatePerMinAvg5      float64                `protobuf:"fixed64,25,opt,name=query_rate_per_min_avg5,json=queryRatePerMinAvg5,proto3" json:"query_rate_per_min_avg5,omitempty"`                                                                                       // Queries per minute, averaged over 5 minutes
+	QueryRatePerMinAvg15     float64                `protobuf:"fixed64,26,opt,name=query_rate_per_min_avg15,json=queryRatePerMinAvg15,proto3" json:"query_rate_per_min_avg15,omitempty"`                                                                                    // Queries per minute, averaged over 15 minutes
+	QuotaExceededBySource    map[string]int64       `protobuf:"bytes,27,rep,name=quota_exceeded_by_source,json=quotaExceededBySource,proto3" json:"quota_exceeded_by_source,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`          // Claims refused by a daily quota, per source
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *MetricsResponse) Reset() {
+	*x = MetricsResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsResponse) ProtoMessage() {}
+
+func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
+func (*MetricsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *MetricsResponse) GetTotalKpaks() int32 {
+	if x != nil {
+		return x.TotalKpaks
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetTotalSubjects() int32 {
+	if x != nil {
+		return x.TotalSubjects
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetIngestRatePerMin() int64 {
+	if x != nil {
+		return x.IngestRatePerMin
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetQueryRatePerMin() int64 {
+	if x != nil {
+		return x.QueryRatePerMin
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetUptimeSeconds() int64 {
+	if x != nil {
+		return x.UptimeSeconds
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetMemoryUsageBytes() int64 {
+	if x != nil {
+		return x.MemoryUsageBytes
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetCpuUsagePercent() float32 {
+	if x != nil {
+		return x.CpuUsagePercent
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MetricsResponse) GetActiveSources() []string {
+	if x != nil {
+		return x.ActiveSources
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetPolicyViolations() int64 {
+	if x != nil {
+		return x.PolicyViolations
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetConfidenceCapped() int64 {
+	if x != nil {
+		return x.ConfidenceCapped
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetPolicyViolationsBySource() map[string]int64 {
+	if x != nil {
+		return x.PolicyViolationsBySource
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetThrottled() int64 {
+	if x != nil {
+		return x.Throttled
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetThrottledBySource() map[string]int64 {
+	if x != nil {
+		return x.ThrottledBySource
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetQuotaExceeded() int64 {
+	if x != nil {
+		return x.QuotaExceeded
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetHeapAllocBytes() int64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetGoroutines() int32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetOpenFds() int32 {
+	if x != nil {
+		return x.OpenFds
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetGcRuns() uint32 {
+	if x != nil {
+		return x.GcRuns
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetGcPauseTotalNs() int64 {
+	if x != nil {
+		return x.GcPauseTotalNs
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetGcPauseLastNs() int64 {
+	if x != nil {
+		return x.GcPauseLastNs
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetGcPauseMaxNs() int64 {
+	if x != nil {
+		return x.GcPauseMaxNs
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetIngestRatePerMinAvg5() float64 {
+	if x != nil {
+		return x.IngestRatePerMinAvg5
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetIngestRatePerMinAvg15() float64 {
+	if x != nil {
+		return x.IngestRatePerMinAvg15
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetQueryRatePerMinAvg5() float64 {
+	if x != nil {
+		return x.QueryRatePerMinAvg5
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetQueryRatePerMinAvg15() float64 {
+	if x != nil {
+		return x.QueryRatePerMinAvg15
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetQuotaExceededBySource() map[string]int64 {
+	if x != nil {
+		return x.QuotaExceededBySource
+	}
+	return nil
+}
+
+// Retraction messages
+type RetractRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`     // Subject of the withdrawn claim
+	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"` // Predicate of the withdrawn claim
+	Source        string                 `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`       // Source whose claims are withdrawn
+	Object        string                 `protobuf:"bytes,4,opt,name=object,proto3" json:"object,omitempty"`       // Only withdraw claims of this value (e.g. one member of a set)
+	Value         *Value                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`         // Typed form of object, preferred when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractRequest) Reset() {
+	*x = RetractRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractRequest) ProtoMessage() {}
+
+func (x *RetractRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractRequest.ProtoReflect.Descriptor instead.
+func (*RetractRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RetractRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *RetractRequest) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *RetractRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RetractRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *RetractRequest) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type RetractResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Retracted     int32                  `protobuf:"varint,1,opt,name=retracted,proto3" json:"retracted,omitempty"` // Number of claims withdrawn on this agent
+	Current       *Kpak                  `protobuf:"bytes,2,opt,name=current,proto3" json:"current,omitempty"`      // Truth after the retraction (unset if the fact is gone)
+	Members       []*Kpak                `protobuf:"bytes,3,rep,name=members,proto3" json:"members,omitempty"`      // Live members after the retraction, for multi-valued predicates
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractResponse) Reset() {
+	*x = RetractResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractResponse) ProtoMessage() {}
+
+func (x *RetractResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractResponse.ProtoReflect.Descriptor instead.
+func (*RetractResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RetractResponse) GetRetracted() int32 {
+	if x != nil {
+		return x.Retracted
+	}
+	return 0
+}
+
+func (x *RetractResponse) GetCurrent() *Kpak {
+	if x != nil {
+		return x.Current
+	}
+	return nil
+}
+
+func (x *RetractResponse) GetMembers() []*Kpak {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+// Schema messages
+type PredicateSchema struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Predicate         string                 `protobuf:"bytes,1,opt,name=predicate,proto3" json:"predicate,omitempty"`                                                                       // Predicate name or pattern ("health_*")
+	Type              string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`                                                                                 // Required object type (empty = any)
+	Enum              []string               `protobuf:"bytes,3,rep,name=enum,proto3" json:"enum,omitempty"`                                                                                 // Allowed values, matched case-insensitively
+	Aliases           map[string]string      `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Alternative spellings mapped to an enum value
+	Min               *float64               `protobuf:"fixed64,5,opt,name=min,proto3,oneof" json:"min,omitempty"`                                                                           // Lowest allowed numeric value
+	Max               *float64               `protobuf:"fixed64,6,opt,name=max,proto3,oneof" json:"max,omitempty"`                                                                           // Highest allowed numeric value
+	Cardinality       string                 `protobuf:"bytes,7,opt,name=cardinality,proto3" json:"cardinality,omitempty"`                                                                   // "single" (default) or "multi"
+	DefaultTtlSeconds int64                  `protobuf:"varint,8,opt,name=default_ttl_seconds,json=defaultTtlSeconds,proto3" json:"default_ttl_seconds,omitempty"`                           // TTL for claims that carry no expiry
+	Resolver          string                 `protobuf:"bytes,9,opt,name=resolver,proto3" json:"resolver,omitempty"`                                                                         // Reconciliation mode (empty = agent default)
+	Version           int64                  `protobuf:"varint,10,opt,name=version,proto3" json:"version,omitempty"`                                                                         // Schema version (0 = next version after the current one)
+	Crdt              string                 `protobuf:"bytes,11,opt,name=crdt,proto3" json:"crdt,omitempty"`                                                                                // g_counter, pn_counter, or_set, lww_register or lww_map (empty = not a CRDT)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *PredicateSchema) Reset() {
+	*x = PredicateSchema{}
+	mi := &file_api_v1_synapse_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PredicateSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PredicateSchema) ProtoMessage() {}
+
+func (x *PredicateSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PredicateSchema.ProtoReflect.Descriptor instead.
+func (*PredicateSchema) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PredicateSchema) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *PredicateSchema) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *PredicateSchema) GetEnum() []string {
+	if x != nil {
+		return x.Enum
+	}
+	return nil
+}
+
+func (x *PredicateSchema) GetAliases() map[string]string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *PredicateSchema) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *PredicateSchema) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *PredicateSchema) GetCardinality() string {
+	if x != nil {
+		return x.Cardinality
+	}
+	return ""
+}
+
+func (x *PredicateSchema) GetDefaultTtlSeconds() int64 {
+	if x != nil {
+		return x.DefaultTtlSeconds
+	}
+	return 0
+}
+
+func (x *PredicateSchema) GetResolver() string {
+	if x != nil {
+		return x.Resolver
+	}
+	return ""
+}
+
+func (x *PredicateSchema) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *PredicateSchema) GetCrdt() string {
+	if x != nil {
+		return x.Crdt
+	}
+	return ""
+}
+
+type DefineSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *PredicateSchema       `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"` // The schema as stored, with its version
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DefineSchemaResponse) Reset() {
+	*x = DefineSchemaResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DefineSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DefineSchemaResponse) ProtoMessage() {}
+
+func (x *DefineSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DefineSchemaResponse.ProtoReflect.Descriptor instead.
+func (*DefineSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DefineSchemaResponse) GetSchema() *PredicateSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type ListSchemasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchemasRequest) Reset() {
+	*x = ListSchemasRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchemasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemasRequest) ProtoMessage() {}
+
+func (x *ListSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ListSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{16}
+}
+
+type ListSchemasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schemas       []*PredicateSchema     `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchemasResponse) Reset() {
+	*x = ListSchemasResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchemasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemasResponse) ProtoMessage() {}
+
+func (x *ListSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ListSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSchemasResponse) GetSchemas() []*PredicateSchema {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+// Conflict messages
+type ConflictsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`     // Only conflicts about this subject (empty = all)
+	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"` // Only conflicts about this predicate (empty = all)
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`        // Maximum number of conflicts returned, newest first (0 = all kept)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConflictsRequest) Reset() {
+	*x = ConflictsRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConflictsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictsRequest) ProtoMessage() {}
+
+func (x *ConflictsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictsRequest.ProtoReflect.Descriptor instead.
+func (*ConflictsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConflictsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *ConflictsRequest) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *ConflictsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Conflict struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Local         *Kpak                  `protobuf:"bytes,3,opt,name=local,proto3" json:"local,omitempty"`                                                                                                              // Truth held by this agent when the concurrent claim arrived
+	Remote        *Kpak                  `protobuf:"bytes,4,opt,name=remote,proto3" json:"remote,omitempty"`                                                                                                            // The concurrent claim, with the version vector it was made at
+	Winner        *Kpak                  `protobuf:"bytes,5,opt,name=winner,proto3" json:"winner,omitempty"`                                                                                                            // Truth after deterministic resolution
+	LocalVersion  map[string]uint64      `protobuf:"bytes,6,rep,name=local_version,json=localVersion,proto3" json:"local_version,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // Version of the fact on this agent when the claim arrived
+	DetectedAt    int64                  `protobuf:"varint,7,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`                                                                                 // Unix timestamp when the conflict was detected
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Conflict) Reset() {
+	*x = Conflict{}
+	mi := &file_api_v1_synapse_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Conflict) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Conflict) ProtoMessage() {}
+
+func (x *Conflict) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Conflict.ProtoReflect.Descriptor instead.
+func (*Conflict) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Conflict) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Conflict) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *Conflict) GetLocal() *Kpak {
+	if x != nil {
+		return x.Local
+	}
+	return nil
+}
+
+func (x *Conflict) GetRemote() *Kpak {
+	if x != nil {
+		return x.Remote
+	}
+	return nil
+}
+
+func (x *Conflict) GetWinner() *Kpak {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *Conflict) GetLocalVersion() map[string]uint64 {
+	if x != nil {
+		return x.LocalVersion
+	}
+	return nil
+}
+
+func (x *Conflict) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+type ConflictsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conflicts     []*Conflict            `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConflictsResponse) Reset() {
+	*x = ConflictsResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConflictsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConflictsResponse) ProtoMessage() {}
+
+func (x *ConflictsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConflictsResponse.ProtoReflect.Descriptor instead.
+func (*ConflictsResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ConflictsResponse) GetConflicts() []*Conflict {
+	if x != nil {
+		return x.Conflicts
+	}
+	return nil
+}
+
+// Anomaly messages
+type AnomaliesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"` // Only anomalies about this subject (empty = all)
+	Kind          string                 `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"`       // "flapping" or "contradiction" (empty = all)
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`    // Maximum number of anomalies returned, newest first (0 = all)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnomaliesRequest) Reset() {
+	*x = AnomaliesRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnomaliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnomaliesRequest) ProtoMessage() {}
+
+func (x *AnomaliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnomaliesRequest.ProtoReflect.Descriptor instead.
+func (*AnomaliesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *AnomaliesRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *AnomaliesRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *AnomaliesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type Anomaly struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          string                 `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"` // "flapping" or "contradiction"
+	Subject       string                 `protobuf:"bytes,2,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate     string                 `protobuf:"bytes,3,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Changes       int32                  `protobuf:"varint,4,opt,name=changes,proto3" json:"changes,omitempty"`                                  // Flapping: winner changes within the window
+	WindowSeconds int64                  `protobuf:"varint,5,opt,name=window_seconds,json=windowSeconds,proto3" json:"window_seconds,omitempty"` // Flapping: length of the window
+	Values        []string               `protobuf:"bytes,6,rep,name=values,proto3" json:"values,omitempty"`                                     // Values involved, most recent winner first
+	Winner        *Kpak                  `protobuf:"bytes,7,opt,name=winner,proto3" json:"winner,omitempty"`                                     // Truth when the anomaly was detected
+	Rival         *Kpak                  `protobuf:"bytes,8,opt,name=rival,proto3" json:"rival,omitempty"`                                       // Contradiction: the nearly as trusted losing claim
+	DetectedAt    int64                  `protobuf:"varint,9,opt,name=detected_at,json=detectedAt,proto3" json:"detected_at,omitempty"`          // Unix timestamp when the anomaly was detected
+	ReportedBy    string                 `protobuf:"bytes,10,opt,name=reported_by,json=reportedBy,proto3" json:"reported_by,omitempty"`          // Source of the finding (the analyzer's agent)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Anomaly) Reset() {
+	*x = Anomaly{}
+	mi := &file_api_v1_synapse_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Anomaly) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Anomaly) ProtoMessage() {}
+
+func (x *Anomaly) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Anomaly.ProtoReflect.Descriptor instead.
+func (*Anomaly) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Anomaly) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *Anomaly) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *Anomaly) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *Anomaly) GetChanges() int32 {
+	if x != nil {
+		return x.Changes
+	}
+	return 0
+}
+
+func (x *Anomaly) GetWindowSeconds() int64 {
+	if x != nil {
+		return x.WindowSeconds
+	}
+	return 0
+}
+
+func (x *Anomaly) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Anomaly) GetWinner() *Kpak {
+	if x != nil {
+		return x.Winner
+	}
+	return nil
+}
+
+func (x *Anomaly) GetRival() *Kpak {
+	if x != nil {
+		return x.Rival
+	}
+	return nil
+}
+
+func (x *Anomaly) GetDetectedAt() int64 {
+	if x != nil {
+		return x.DetectedAt
+	}
+	return 0
+}
+
+func (x *Anomaly) GetReportedBy() string {
+	if x != nil {
+		return x.ReportedBy
+	}
+	return ""
+}
+
+type AnomaliesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Anomalies     []*Anomaly             `protobuf:"bytes,1,rep,name=anomalies,proto3" json:"anomalies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnomaliesResponse) Reset() {
+	*x = AnomaliesResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnomaliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnomaliesResponse) ProtoMessage() {}
+
+func (x *AnomaliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnomaliesResponse.ProtoReflect.Descriptor instead.
+func (*AnomaliesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AnomaliesResponse) GetAnomalies() []*Anomaly {
+	if x != nil {
+		return x.Anomalies
+	}
+	return nil
+}
+
+// Override messages
+type OverrideRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate     string                 `protobuf:"bytes,2,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Object        string                 `protobuf:"bytes,3,opt,name=object,proto3" json:"object,omitempty"`     // Pinned value as plain text
+	Value         *Value                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`       // Typed form of object, preferred when set
+	Operator      string                 `protobuf:"bytes,5,opt,name=operator,proto3" json:"operator,omitempty"` // Who is pinning the value
+	Reason        string                 `protobuf:"bytes,6,opt,name=reason,proto3" json:"reason,omitempty"`     // Why the value is pinned (required unless removing)
+	Until         int64                  `protobuf:"varint,7,opt,name=until,proto3" json:"until,omitempty"`      // Unix timestamp when the pin lapses (0 = until lifted)
+	Remove        bool                   `protobuf:"varint,8,opt,name=remove,proto3" json:"remove,omitempty"`    // Lift the existing pin instead of setting one
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverrideRequest) Reset() {
+	*x = OverrideRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverrideRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideRequest) ProtoMessage() {}
+
+func (x *OverrideRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideRequest.ProtoReflect.Descriptor instead.
+func (*OverrideRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *OverrideRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+func (x *OverrideRequest) GetPredicate() string {
+	if x != nil {
+		return x.Predicate
+	}
+	return ""
+}
+
+func (x *OverrideRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *OverrideRequest) GetValue() *Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *OverrideRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *OverrideRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OverrideRequest) GetUntil() int64 {
+	if x != nil {
+		return x.Until
+	}
+	return 0
+}
+
+func (x *OverrideRequest) GetRemove() bool {
+	if x != nil {
+		return x.Remove
+	}
+	return false
+}
+
+type OverrideResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pinned        *Kpak                  `protobuf:"bytes,1,opt,name=pinned,proto3" json:"pinned,omitempty"`    // The pinned value (or the value that was unpinned)
+	Removed       bool                   `protobuf:"varint,2,opt,name=removed,proto3" json:"removed,omitempty"` // The pin was lifted
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OverrideResponse) Reset() {
+	*x = OverrideResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OverrideResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OverrideResponse) ProtoMessage() {}
+
+func (x *OverrideResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OverrideResponse.ProtoReflect.Descriptor instead.
+func (*OverrideResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *OverrideResponse) GetPinned() *Kpak {
+	if x != nil {
+		return x.Pinned
+	}
+	return nil
+}
+
+func (x *OverrideResponse) GetRemoved() bool {
+	if x != nil {
+		return x.Removed
+	}
+	return false
+}
+
+// Review messages
+type ReviewItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // ID of the held k-pak
+	Claim         *Kpak                  `protobuf:"bytes,2,opt,name=claim,proto3" json:"claim,omitempty"`
+	Reasons       []string               `protobuf:"bytes,3,rep,name=reasons,proto3" json:"reasons,omitempty"`                       // Why the claim was held
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`                         // "pending", "approved" or "rejected"
+	HeldAt        int64                  `protobuf:"varint,5,opt,name=held_at,json=heldAt,proto3" json:"held_at,omitempty"`          // Unix timestamp when the claim was held
+	HeldBy        string                 `protobuf:"bytes,6,opt,name=held_by,json=heldBy,proto3" json:"held_by,omitempty"`           // Agent that held the claim
+	Reviewer      string                 `protobuf:"bytes,7,opt,name=reviewer,proto3" json:"reviewer,omitempty"`                     // Who decided
+	Note          string                 `protobuf:"bytes,8,opt,name=note,proto3" json:"note,omitempty"`                             // Reviewer's note
+	DecidedAt     int64                  `protobuf:"varint,9,opt,name=decided_at,json=decidedAt,proto3" json:"decided_at,omitempty"` // Unix timestamp of the decision (0 = pending)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewItem) Reset() {
+	*x = ReviewItem{}
+	mi := &file_api_v1_synapse_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewItem) ProtoMessage() {}
+
+func (x *ReviewItem) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewItem.ProtoReflect.Descriptor instead.
+func (*ReviewItem) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ReviewItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetClaim() *Kpak {
+	if x != nil {
+		return x.Claim
+	}
+	return nil
+}
+
+func (x *ReviewItem) GetReasons() []string {
+	if x != nil {
+		return x.Reasons
+	}
+	return nil
+}
+
+func (x *ReviewItem) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReviewItem) GetHeldAt() int64 {
+	if x != nil {
+		return x.HeldAt
+	}
+	return 0
 }
 
-// Deprecated: Use IngestResponse.ProtoReflect.Descriptor instead.
-func (*IngestResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{1}
+func (x *ReviewItem) GetHeldBy() string {
+	if x != nil {
+		return x.HeldBy
+	}
+	return ""
 }
 
-func (x *IngestResponse) GetAccepted() int32 {
+func (x *ReviewItem) GetReviewer() string {
 	if x != nil {
-		return x.Accepted
+		return x.Reviewer
 	}
-	return 0
+	return ""
 }
 
-func (x *IngestResponse) GetRejected() int32 {
+func (x *ReviewItem) GetNote() string {
 	if x != nil {
-		return x.Rejected
+		return x.Note
 	}
-	return 0
+	return ""
 }
 
-func (x *IngestResponse) GetErrors() []string {
+func (x *ReviewItem) GetDecidedAt() int64 {
 	if x != nil {
-		return x.Errors
+		return x.DecidedAt
 	}
-	return nil
+	return 0
 }
 
-// QueryRequest specifies what knowledge to retrieve
-type QueryRequest struct {
+type ListReviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`           // Query by subject
-	Predicate     *string                `protobuf:"bytes,2,opt,name=predicate,proto3,oneof" json:"predicate,omitempty"` // Optional: filter by predicate
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // "pending" (default), "approved", "rejected" or "all"
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // Maximum number of items returned, oldest first (0 = all)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *QueryRequest) Reset() {
-	*x = QueryRequest{}
-	mi := &file_api_v1_synapse_proto_msgTypes[2]
+func (x *ListReviewRequest) Reset() {
+	*x = ListReviewRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *QueryRequest) String() string {
+func (x *ListReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*QueryRequest) ProtoMessage() {}
+func (*ListReviewRequest) ProtoMessage() {}
 
-func (x *QueryRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[2]
+func (x *ListReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -225,47 +2255,47 @@ func (x *QueryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use QueryRequest.ProtoReflect.Descriptor instead.
-func (*QueryRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListReviewRequest.ProtoReflect.Descriptor instead.
+func (*ListReviewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *QueryRequest) GetSubject() string {
+func (x *ListReviewRequest) GetStatus() string {
 	if x != nil {
-		return x.Subject
+		return x.Status
 	}
 	return ""
 }
 
-func (x *QueryRequest) GetPredicate() string {
-	if x != nil && x.Predicate != nil {
-		return *x.Predicate
+func (x *ListReviewRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
-	return ""
+	return 0
 }
 
-// Health check messages
-type HealthRequest struct {
+type ListReviewResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ReviewItem          `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthRequest) Reset() {
-	*x = HealthRequest{}
-	mi := &file_api_v1_synapse_proto_msgTypes[3]
+func (x *ListReviewResponse) Reset() {
+	*x = ListReviewResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthRequest) String() string {
+func (x *ListReviewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthRequest) ProtoMessage() {}
+func (*ListReviewResponse) ProtoMessage() {}
 
-func (x *HealthRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[3]
+func (x *ListReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -276,35 +2306,43 @@ func (x *HealthRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthRequest.ProtoReflect.Descriptor instead.
-func (*HealthRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListReviewResponse.ProtoReflect.Descriptor instead.
+func (*ListReviewResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{28}
 }
 
-type HealthResponse struct {
+func (x *ListReviewResponse) GetItems() []*ReviewItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type DecideReviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`                                     // "healthy", "degraded", etc.
-	KpakCount     int32                  `protobuf:"varint,2,opt,name=kpak_count,json=kpakCount,proto3" json:"kpak_count,omitempty"`             // Total knowledge packets stored
-	UptimeSeconds int64                  `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"` // How long this agent has been running
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`             // Claim ID or a unique prefix of it
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`  // Approve (true) or reject (false) the claim
+	Reviewer      string                 `protobuf:"bytes,3,opt,name=reviewer,proto3" json:"reviewer,omitempty"` // Who is deciding
+	Note          string                 `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`         // Optional note kept with the decision
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HealthResponse) Reset() {
-	*x = HealthResponse{}
-	mi := &file_api_v1_synapse_proto_msgTypes[4]
+func (x *DecideReviewRequest) Reset() {
+	*x = DecideReviewRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HealthResponse) String() string {
+func (x *DecideReviewRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HealthResponse) ProtoMessage() {}
+func (*DecideReviewRequest) ProtoMessage() {}
 
-func (x *HealthResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[4]
+func (x *DecideReviewRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -315,54 +2353,62 @@ func (x *HealthResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HealthResponse.ProtoReflect.Descriptor instead.
-func (*HealthResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DecideReviewRequest.ProtoReflect.Descriptor instead.
+func (*DecideReviewRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{29}
 }
 
-func (x *HealthResponse) GetStatus() string {
+func (x *DecideReviewRequest) GetId() string {
 	if x != nil {
-		return x.Status
+		return x.Id
 	}
 	return ""
 }
 
-func (x *HealthResponse) GetKpakCount() int32 {
+func (x *DecideReviewRequest) GetApprove() bool {
 	if x != nil {
-		return x.KpakCount
+		return x.Approve
 	}
-	return 0
+	return false
 }
 
-func (x *HealthResponse) GetUptimeSeconds() int64 {
+func (x *DecideReviewRequest) GetReviewer() string {
 	if x != nil {
-		return x.UptimeSeconds
+		return x.Reviewer
 	}
-	return 0
+	return ""
 }
 
-// Peer information messages
-type PeersRequest struct {
+func (x *DecideReviewRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type DecideReviewResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *ReviewItem            `protobuf:"bytes,1,opt,name=item,proto3" json:"item,omitempty"`          // The decided item
+	Accepted      bool                   `protobuf:"varint,2,opt,name=accepted,proto3" json:"accepted,omitempty"` // The approved claim became the truth
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PeersRequest) Reset() {
-	*x = PeersRequest{}
-	mi := &file_api_v1_synapse_proto_msgTypes[5]
+func (x *DecideReviewResponse) Reset() {
+	*x = DecideReviewResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeersRequest) String() string {
+func (x *DecideReviewResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeersRequest) ProtoMessage() {}
+func (*DecideReviewResponse) ProtoMessage() {}
 
-func (x *PeersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[5]
+func (x *DecideReviewResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -373,33 +2419,47 @@ func (x *PeersRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeersRequest.ProtoReflect.Descriptor instead.
-func (*PeersRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DecideReviewResponse.ProtoReflect.Descriptor instead.
+func (*DecideReviewResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{30}
 }
 
-type PeersResponse struct {
+func (x *DecideReviewResponse) GetItem() *ReviewItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *DecideReviewResponse) GetAccepted() bool {
+	if x != nil {
+		return x.Accepted
+	}
+	return false
+}
+
+type SetLogLevelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Peers         []*PeerInfo            `protobuf:"bytes,1,rep,name=peers,proto3" json:"peers,omitempty"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // "debug", "info", "warn" or "error" (empty = report the current level)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PeersResponse) Reset() {
-	*x = PeersResponse{}
-	mi := &file_api_v1_synapse_proto_msgTypes[6]
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeersResponse) String() string {
+func (x *SetLogLevelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeersResponse) ProtoMessage() {}
+func (*SetLogLevelRequest) ProtoMessage() {}
 
-func (x *PeersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[6]
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,43 +2470,41 @@ func (x *PeersResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeersResponse.ProtoReflect.Descriptor instead.
-func (*PeersResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{31}
 }
 
-func (x *PeersResponse) GetPeers() []*PeerInfo {
+func (x *SetLogLevelRequest) GetLevel() string {
 	if x != nil {
-		return x.Peers
+		return x.Level
 	}
-	return nil
+	return ""
 }
 
-type PeerInfo struct {
+type SetLogLevelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Address       string                 `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`                    // IP:port of the peer
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`                          // Peer name/ID
-	State         int32                  `protobuf:"varint,3,opt,name=state,proto3" json:"state,omitempty"`                       // Peer state (0=alive, 1=suspect, 2=dead)
-	LastSeen      int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"` // Unix timestamp of last contact
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`       // The level now in effect
+	Previous      string                 `protobuf:"bytes,2,opt,name=previous,proto3" json:"previous,omitempty"` // The level before the request
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PeerInfo) Reset() {
-	*x = PeerInfo{}
-	mi := &file_api_v1_synapse_proto_msgTypes[7]
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_api_v1_synapse_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PeerInfo) String() string {
+func (x *SetLogLevelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PeerInfo) ProtoMessage() {}
+func (*SetLogLevelResponse) ProtoMessage() {}
 
-func (x *PeerInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[7]
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -457,61 +2515,49 @@ func (x *PeerInfo) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PeerInfo.ProtoReflect.Descriptor instead.
-func (*PeerInfo) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{32}
 }
 
-func (x *PeerInfo) GetAddress() string {
+func (x *SetLogLevelResponse) GetLevel() string {
 	if x != nil {
-		return x.Address
+		return x.Level
 	}
 	return ""
 }
 
-func (x *PeerInfo) GetName() string {
+func (x *SetLogLevelResponse) GetPrevious() string {
 	if x != nil {
-		return x.Name
+		return x.Previous
 	}
 	return ""
 }
 
-func (x *PeerInfo) GetState() int32 {
-	if x != nil {
-		return x.State
-	}
-	return 0
-}
-
-func (x *PeerInfo) GetLastSeen() int64 {
-	if x != nil {
-		return x.LastSeen
-	}
-	return 0
-}
-
-// Metrics messages
-type MetricsRequest struct {
+// WatchRequest filters the changes a watcher receives
+type WatchRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Subject       string                 `protobuf:"bytes,1,opt,name=subject,proto3" json:"subject,omitempty"`           // Only changes about this subject (empty = every subject)
+	Predicate     *string                `protobuf:"bytes,2,opt,name=predicate,proto3,oneof" json:"predicate,omitempty"` // Optional: only changes to this predicate
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MetricsRequest) Reset() {
-	*x = MetricsRequest{}
-	mi := &file_api_v1_synapse_proto_msgTypes[8]
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_api_v1_synapse_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MetricsRequest) String() string {
+func (x *WatchRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MetricsRequest) ProtoMessage() {}
+func (*WatchRequest) ProtoMessage() {}
 
-func (x *MetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[8]
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -522,41 +2568,54 @@ func (x *MetricsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MetricsRequest.ProtoReflect.Descriptor instead.
-func (*MetricsRequest) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{33}
 }
 
-type MetricsResponse struct {
-	state            protoimpl.MessageState `protogen:"open.v1"`
-	TotalKpaks       int32                  `protobuf:"varint,1,opt,name=total_kpaks,json=totalKpaks,proto3" json:"total_kpaks,omitempty"`                       // Total k-paks in memory
-	TotalSubjects    int32                  `protobuf:"varint,2,opt,name=total_subjects,json=totalSubjects,proto3" json:"total_subjects,omitempty"`              // Total unique subjects
-	IngestRatePerMin int64                  `protobuf:"varint,3,opt,name=ingest_rate_per_min,json=ingestRatePerMin,proto3" json:"ingest_rate_per_min,omitempty"` // K-paks ingested per minute
-	QueryRatePerMin  int64                  `protobuf:"varint,4,opt,name=query_rate_per_min,json=queryRatePerMin,proto3" json:"query_rate_per_min,omitempty"`    // Queries per minute
-	UptimeSeconds    int64                  `protobuf:"varint,5,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`              // Agent uptime
-	MemoryUsageBytes int64                  `protobuf:"varint,6,opt,name=memory_usage_bytes,json=memoryUsageBytes,proto3" json:"memory_usage_bytes,omitempty"`   // Memory usage
-	CpuUsagePercent  float32                `protobuf:"fixed32,7,opt,name=cpu_usage_percent,json=cpuUsagePercent,proto3" json:"cpu_usage_percent,omitempty"`     // CPU usage percentage
-	Version          string                 `protobuf:"bytes,8,opt,name=version,proto3" json:"version,omitempty"`                                                // Agent version
-	ActiveSources    []string               `protobuf:"bytes,9,rep,name=active_sources,json=activeSources,proto3" json:"active_sources,omitempty"`               // List of active data sources
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+func (x *WatchRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
 }
 
-func (x *MetricsResponse) Reset() {
-	*x = MetricsResponse{}
-	mi := &file_api_v1_synapse_proto_msgTypes[9]
+func (x *WatchRequest) GetPredicate() string {
+	if x != nil && x.Predicate != nil {
+		return *x.Predicate
+	}
+	return ""
+}
+
+// TruthChange is one change to the accepted truth for a subject+predicate
+type TruthChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Type          string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"` // "accepted", "promoted", "expired", "retracted", "pinned" or "unpinned"
+	Spid          string                 `protobuf:"bytes,2,opt,name=spid,proto3" json:"spid,omitempty"` // Subject+predicate ID of the fact
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	Predicate     string                 `protobuf:"bytes,4,opt,name=predicate,proto3" json:"predicate,omitempty"`
+	Previous      *Kpak                  `protobuf:"bytes,5,opt,name=previous,proto3" json:"previous,omitempty"`    // The truth before the change, if there was one
+	Current       *Kpak                  `protobuf:"bytes,6,opt,name=current,proto3" json:"current,omitempty"`      // The truth after the change, if there is one
+	Timestamp     int64                  `protobuf:"varint,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp when the agent saw the change
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TruthChange) Reset() {
+	*x = TruthChange{}
+	mi := &file_api_v1_synapse_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MetricsResponse) String() string {
+func (x *TruthChange) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MetricsResponse) ProtoMessage() {}
+func (*TruthChange) ProtoMessage() {}
 
-func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_synapse_proto_msgTypes[9]
+func (x *TruthChange) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_synapse_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,80 +2626,66 @@ func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
-func (*MetricsResponse) Descriptor() ([]byte, []int) {
-	return file_api_v1_synapse_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use TruthChange.ProtoReflect.Descriptor instead.
+func (*TruthChange) Descriptor() ([]byte, []int) {
+	return file_api_v1_synapse_proto_rawDescGZIP(), []int{34}
 }
 
-func (x *MetricsResponse) GetTotalKpaks() int32 {
+func (x *TruthChange) GetType() string {
 	if x != nil {
-		return x.TotalKpaks
+		return x.Type
 	}
-	return 0
+	return ""
 }
 
-func (x *MetricsResponse) GetTotalSubjects() int32 {
+func (x *TruthChange) GetSpid() string {
 	if x != nil {
-		return x.TotalSubjects
+		return x.Spid
 	}
-	return 0
+	return ""
 }
 
-func (x *MetricsResponse) GetIngestRatePerMin() int64 {
+func (x *TruthChange) GetSubject() string {
 	if x != nil {
-		return x.IngestRatePerMin
+		return x.Subject
 	}
-	return 0
+	return ""
 }
 
-func (x *MetricsResponse) GetQueryRatePerMin() int64 {
+func (x *TruthChange) GetPredicate() string {
 	if x != nil {
-		return x.QueryRatePerMin
+		return x.Predicate
 	}
-	return 0
+	return ""
 }
 
-func (x *MetricsResponse) GetUptimeSeconds() int64 {
+func (x *TruthChange) GetPrevious() *Kpak {
 	if x != nil {
-		return x.UptimeSeconds
+		return x.Previous
 	}
-	return 0
+	return nil
 }
 
-func (x *MetricsResponse) GetMemoryUsageBytes() int64 {
+func (x *TruthChange) GetCurrent() *Kpak {
 	if x != nil {
-		return x.MemoryUsageBytes
+		return x.Current
 	}
-	return 0
+	return nil
 }
 
-func (x *MetricsResponse) GetCpuUsagePercent() float32 {
+func (x *TruthChange) GetTimestamp() int64 {
 	if x != nil {
-		return x.CpuUsagePercent
+		return x.Timestamp
 	}
 	return 0
 }
 
-func (x *MetricsResponse) GetVersion() string {
-	if x != nil {
-		return x.Version
-	}
-	return ""
-}
-
-func (x *MetricsResponse) GetActiveSources() []string {
-	if x != nil {
-		return x.ActiveSources
-	}
-	return nil
-}
-
 var File_api_v1_synapse_proto protoreflect.FileDescriptor
 
 const file_api_v1_synapse_proto_rawDesc = "" +
 	"\n" +
 	"\x14api/v1/synapse.proto\x12\n" +
-	"synapse.v1\"\xef\x01\n" +
+	"synapse.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xd3\x05\n" +
 	"\x04Kpak\x12\x18\n" +
 	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
 	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12\x16\n" +
@@ -653,31 +2698,72 @@ const file_api_v1_synapse_proto_rawDesc = "" +
 	"\x02id\x18\a \x01(\tR\x02id\x12\x12\n" +
 	"\x04spid\x18\b \x01(\tR\x04spid\x12\x1d\n" +
 	"\n" +
-	"expires_at\x18\t \x01(\x03R\texpiresAt\"`\n" +
+	"expires_at\x18\t \x01(\x03R\texpiresAt\x12)\n" +
+	"\x10fused_confidence\x18\n" +
+	" \x01(\x02R\x0ffusedConfidence\x12-\n" +
+	"\x12supporting_sources\x18\v \x03(\tR\x11supportingSources\x121\n" +
+	"\x14effective_confidence\x18\f \x01(\x02R\x13effectiveConfidence\x12'\n" +
+	"\x05value\x18\r \x01(\v2\x11.synapse.v1.ValueR\x05value\x127\n" +
+	"\aversion\x18\x0e \x03(\v2\x1d.synapse.v1.Kpak.VersionEntryR\aversion\x12\x18\n" +
+	"\apending\x18\x0f \x01(\bR\apending\x12#\n" +
+	"\rpending_since\x18\x10 \x01(\x03R\fpendingSince\x12$\n" +
+	"\rconfirmations\x18\x11 \x01(\x05R\rconfirmations\x12\x16\n" +
+	"\x06pinned\x18\x12 \x01(\bR\x06pinned\x12\x1b\n" +
+	"\tpinned_by\x18\x13 \x01(\tR\bpinnedBy\x12\x1d\n" +
+	"\n" +
+	"pin_reason\x18\x14 \x01(\tR\tpinReason\x1a:\n" +
+	"\fVersionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"\xc1\x02\n" +
+	"\x05Value\x12#\n" +
+	"\fstring_value\x18\x01 \x01(\tH\x00R\vstringValue\x12\x1d\n" +
+	"\tint_value\x18\x02 \x01(\x03H\x00R\bintValue\x12!\n" +
+	"\vfloat_value\x18\x03 \x01(\x01H\x00R\n" +
+	"floatValue\x12\x1f\n" +
+	"\n" +
+	"bool_value\x18\x04 \x01(\bH\x00R\tboolValue\x12!\n" +
+	"\vbytes_value\x18\x05 \x01(\fH\x00R\n" +
+	"bytesValue\x12E\n" +
+	"\x0ftimestamp_value\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampH\x00R\x0etimestampValue\x12\x1f\n" +
+	"\n" +
+	"json_value\x18\a \x01(\tH\x00R\tjsonValue\x12\x1d\n" +
+	"\tref_value\x18\b \x01(\tH\x00R\brefValueB\x06\n" +
+	"\x04kind\"t\n" +
 	"\x0eIngestResponse\x12\x1a\n" +
 	"\baccepted\x18\x01 \x01(\x05R\baccepted\x12\x1a\n" +
 	"\brejected\x18\x02 \x01(\x05R\brejected\x12\x16\n" +
-	"\x06errors\x18\x03 \x03(\tR\x06errors\"Y\n" +
+	"\x06errors\x18\x03 \x03(\tR\x06errors\x12\x12\n" +
+	"\x04held\x18\x04 \x01(\x05R\x04held\"\x98\x01\n" +
 	"\fQueryRequest\x12\x18\n" +
 	"\asubject\x18\x01 \x01(\tR\asubject\x12!\n" +
-	"\tpredicate\x18\x02 \x01(\tH\x00R\tpredicate\x88\x01\x01B\f\n" +
+	"\tpredicate\x18\x02 \x01(\tH\x00R\tpredicate\x88\x01\x01\x12*\n" +
+	"\x0emin_confidence\x18\x03 \x01(\x02H\x01R\rminConfidence\x88\x01\x01B\f\n" +
 	"\n" +
-	"_predicate\"\x0f\n" +
-	"\rHealthRequest\"n\n" +
+	"_predicateB\x11\n" +
+	"\x0f_min_confidence\"\x0f\n" +
+	"\rHealthRequest\"\xcf\x01\n" +
 	"\x0eHealthResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1d\n" +
 	"\n" +
 	"kpak_count\x18\x02 \x01(\x05R\tkpakCount\x12%\n" +
-	"\x0euptime_seconds\x18\x03 \x01(\x03R\ruptimeSeconds\"\x0e\n" +
+	"\x0euptime_seconds\x18\x03 \x01(\x03R\ruptimeSeconds\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\x12/\n" +
+	"\x06checks\x18\x05 \x03(\v2\x17.synapse.v1.HealthCheckR\x06checks\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\"S\n" +
+	"\vHealthCheck\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x0e\n" +
 	"\fPeersRequest\";\n" +
 	"\rPeersResponse\x12*\n" +
-	"\x05peers\x18\x01 \x03(\v2\x14.synapse.v1.PeerInfoR\x05peers\"k\n" +
+	"\x05peers\x18\x01 \x03(\v2\x14.synapse.v1.PeerInfoR\x05peers\"\x8e\x01\n" +
 	"\bPeerInfo\x12\x18\n" +
 	"\aaddress\x18\x01 \x01(\tR\aaddress\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
 	"\x05state\x18\x03 \x01(\x05R\x05state\x12\x1b\n" +
-	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\"\x10\n" +
-	"\x0eMetricsRequest\"\xf7\x02\n" +
+	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\x12!\n" +
+	"\fgrpc_address\x18\x05 \x01(\tR\vgrpcAddress\"\x10\n" +
+	"\x0eMetricsRequest\"\x9b\f\n" +
 	"\x0fMetricsResponse\x12\x1f\n" +
 	"\vtotal_kpaks\x18\x01 \x01(\x05R\n" +
 	"totalKpaks\x12%\n" +
@@ -688,14 +2774,181 @@ const file_api_v1_synapse_proto_rawDesc = "" +
 	"\x12memory_usage_bytes\x18\x06 \x01(\x03R\x10memoryUsageBytes\x12*\n" +
 	"\x11cpu_usage_percent\x18\a \x01(\x02R\x0fcpuUsagePercent\x12\x18\n" +
 	"\aversion\x18\b \x01(\tR\aversion\x12%\n" +
-	"\x0eactive_sources\x18\t \x03(\tR\ractiveSources2\xca\x02\n" +
+	"\x0eactive_sources\x18\t \x03(\tR\ractiveSources\x12+\n" +
+	"\x11policy_violations\x18\n" +
+	" \x01(\x03R\x10policyViolations\x12+\n" +
+	"\x11confidence_capped\x18\v \x01(\x03R\x10confidenceCapped\x12x\n" +
+	"\x1bpolicy_violations_by_source\x18\f \x03(\v29.synapse.v1.MetricsResponse.PolicyViolationsBySourceEntryR\x18policyViolationsBySource\x12\x1c\n" +
+	"\tthrottled\x18\r \x01(\x03R\tthrottled\x12b\n" +
+	"\x13throttled_by_source\x18\x0e \x03(\v22.synapse.v1.MetricsResponse.ThrottledBySourceEntryR\x11throttledBySource\x12%\n" +
+	"\x0equota_exceeded\x18\x0f \x01(\x03R\rquotaExceeded\x12(\n" +
+	"\x10heap_alloc_bytes\x18\x10 \x01(\x03R\x0eheapAllocBytes\x12\x1e\n" +
+	"\n" +
+	"goroutines\x18\x11 \x01(\x05R\n" +
+	"goroutines\x12\x19\n" +
+	"\bopen_fds\x18\x12 \x01(\x05R\aopenFds\x12\x17\n" +
+	"\agc_runs\x18\x13 \x01(\rR\x06gcRuns\x12)\n" +
+	"\x11gc_pause_total_ns\x18\x14 \x01(\x03R\x0egcPauseTotalNs\x12'\n" +
+	"\x10gc_pause_last_ns\x18\x15 \x01(\x03R\rgcPauseLastNs\x12%\n" +
+	"\x0fgc_pause_max_ns\x18\x16 \x01(\x03R\fgcPauseMaxNs\x126\n" +
+	"\x18ingest_rate_per_min_avg5\x18\x17 \x01(\x01R\x14ingestRatePerMinAvg5\x128\n" +
+	"\x19ingest_rate_per_min_avg15\x18\x18 \x01(\x01R\x15ingestRatePerMinAvg15\x124\n" +
+	"\x17query_rate_per_min_avg5\x18\x19 \x01(\x01R\x13queryRatePerMinAvg5\x126\n" +
+	"\x18query_rate_per_min_avg15\x18\x1a \x01(\x01R\x14queryRatePerMinAvg15\x12o\n" +
+	"\x18quota_exceeded_by_source\x18\x1b \x03(\v26.synapse.v1.MetricsResponse.QuotaExceededBySourceEntryR\x15quotaExceededBySource\x1aK\n" +
+	"\x1dPolicyViolationsBySourceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aD\n" +
+	"\x16ThrottledBySourceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aH\n" +
+	"\x1aQuotaExceededBySourceEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\xa1\x01\n" +
+	"\x0eRetractRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x16\n" +
+	"\x06object\x18\x04 \x01(\tR\x06object\x12'\n" +
+	"\x05value\x18\x05 \x01(\v2\x11.synapse.v1.ValueR\x05value\"\x87\x01\n" +
+	"\x0fRetractResponse\x12\x1c\n" +
+	"\tretracted\x18\x01 \x01(\x05R\tretracted\x12*\n" +
+	"\acurrent\x18\x02 \x01(\v2\x10.synapse.v1.KpakR\acurrent\x12*\n" +
+	"\amembers\x18\x03 \x03(\v2\x10.synapse.v1.KpakR\amembers\"\xb1\x03\n" +
+	"\x0fPredicateSchema\x12\x1c\n" +
+	"\tpredicate\x18\x01 \x01(\tR\tpredicate\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x12\n" +
+	"\x04enum\x18\x03 \x03(\tR\x04enum\x12B\n" +
+	"\aaliases\x18\x04 \x03(\v2(.synapse.v1.PredicateSchema.AliasesEntryR\aaliases\x12\x15\n" +
+	"\x03min\x18\x05 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x06 \x01(\x01H\x01R\x03max\x88\x01\x01\x12 \n" +
+	"\vcardinality\x18\a \x01(\tR\vcardinality\x12.\n" +
+	"\x13default_ttl_seconds\x18\b \x01(\x03R\x11defaultTtlSeconds\x12\x1a\n" +
+	"\bresolver\x18\t \x01(\tR\bresolver\x12\x18\n" +
+	"\aversion\x18\n" +
+	" \x01(\x03R\aversion\x12\x12\n" +
+	"\x04crdt\x18\v \x01(\tR\x04crdt\x1a:\n" +
+	"\fAliasesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"K\n" +
+	"\x14DefineSchemaResponse\x123\n" +
+	"\x06schema\x18\x01 \x01(\v2\x1b.synapse.v1.PredicateSchemaR\x06schema\"\x14\n" +
+	"\x12ListSchemasRequest\"L\n" +
+	"\x13ListSchemasResponse\x125\n" +
+	"\aschemas\x18\x01 \x03(\v2\x1b.synapse.v1.PredicateSchemaR\aschemas\"`\n" +
+	"\x10ConflictsRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xed\x02\n" +
+	"\bConflict\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12&\n" +
+	"\x05local\x18\x03 \x01(\v2\x10.synapse.v1.KpakR\x05local\x12(\n" +
+	"\x06remote\x18\x04 \x01(\v2\x10.synapse.v1.KpakR\x06remote\x12(\n" +
+	"\x06winner\x18\x05 \x01(\v2\x10.synapse.v1.KpakR\x06winner\x12K\n" +
+	"\rlocal_version\x18\x06 \x03(\v2&.synapse.v1.Conflict.LocalVersionEntryR\flocalVersion\x12\x1f\n" +
+	"\vdetected_at\x18\a \x01(\x03R\n" +
+	"detectedAt\x1a?\n" +
+	"\x11LocalVersionEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x04R\x05value:\x028\x01\"G\n" +
+	"\x11ConflictsResponse\x122\n" +
+	"\tconflicts\x18\x01 \x03(\v2\x14.synapse.v1.ConflictR\tconflicts\"V\n" +
+	"\x10AnomaliesRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x12\n" +
+	"\x04kind\x18\x02 \x01(\tR\x04kind\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"\xc2\x02\n" +
+	"\aAnomaly\x12\x12\n" +
+	"\x04kind\x18\x01 \x01(\tR\x04kind\x12\x18\n" +
+	"\asubject\x18\x02 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x03 \x01(\tR\tpredicate\x12\x18\n" +
+	"\achanges\x18\x04 \x01(\x05R\achanges\x12%\n" +
+	"\x0ewindow_seconds\x18\x05 \x01(\x03R\rwindowSeconds\x12\x16\n" +
+	"\x06values\x18\x06 \x03(\tR\x06values\x12(\n" +
+	"\x06winner\x18\a \x01(\v2\x10.synapse.v1.KpakR\x06winner\x12&\n" +
+	"\x05rival\x18\b \x01(\v2\x10.synapse.v1.KpakR\x05rival\x12\x1f\n" +
+	"\vdetected_at\x18\t \x01(\x03R\n" +
+	"detectedAt\x12\x1f\n" +
+	"\vreported_by\x18\n" +
+	" \x01(\tR\n" +
+	"reportedBy\"F\n" +
+	"\x11AnomaliesResponse\x121\n" +
+	"\tanomalies\x18\x01 \x03(\v2\x13.synapse.v1.AnomalyR\tanomalies\"\xec\x01\n" +
+	"\x0fOverrideRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x02 \x01(\tR\tpredicate\x12\x16\n" +
+	"\x06object\x18\x03 \x01(\tR\x06object\x12'\n" +
+	"\x05value\x18\x04 \x01(\v2\x11.synapse.v1.ValueR\x05value\x12\x1a\n" +
+	"\boperator\x18\x05 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x06 \x01(\tR\x06reason\x12\x14\n" +
+	"\x05until\x18\a \x01(\x03R\x05until\x12\x16\n" +
+	"\x06remove\x18\b \x01(\bR\x06remove\"V\n" +
+	"\x10OverrideResponse\x12(\n" +
+	"\x06pinned\x18\x01 \x01(\v2\x10.synapse.v1.KpakR\x06pinned\x12\x18\n" +
+	"\aremoved\x18\x02 \x01(\bR\aremoved\"\xf7\x01\n" +
+	"\n" +
+	"ReviewItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x05claim\x18\x02 \x01(\v2\x10.synapse.v1.KpakR\x05claim\x12\x18\n" +
+	"\areasons\x18\x03 \x03(\tR\areasons\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x17\n" +
+	"\aheld_at\x18\x05 \x01(\x03R\x06heldAt\x12\x17\n" +
+	"\aheld_by\x18\x06 \x01(\tR\x06heldBy\x12\x1a\n" +
+	"\breviewer\x18\a \x01(\tR\breviewer\x12\x12\n" +
+	"\x04note\x18\b \x01(\tR\x04note\x12\x1d\n" +
+	"\n" +
+	"decided_at\x18\t \x01(\x03R\tdecidedAt\"A\n" +
+	"\x11ListReviewRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"B\n" +
+	"\x12ListReviewResponse\x12,\n" +
+	"\x05items\x18\x01 \x03(\v2\x16.synapse.v1.ReviewItemR\x05items\"o\n" +
+	"\x13DecideReviewRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x1a\n" +
+	"\breviewer\x18\x03 \x01(\tR\breviewer\x12\x12\n" +
+	"\x04note\x18\x04 \x01(\tR\x04note\"^\n" +
+	"\x14DecideReviewResponse\x12*\n" +
+	"\x04item\x18\x01 \x01(\v2\x16.synapse.v1.ReviewItemR\x04item\x12\x1a\n" +
+	"\baccepted\x18\x02 \x01(\bR\baccepted\"*\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"G\n" +
+	"\x13SetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x1a\n" +
+	"\bprevious\x18\x02 \x01(\tR\bprevious\"Y\n" +
+	"\fWatchRequest\x12\x18\n" +
+	"\asubject\x18\x01 \x01(\tR\asubject\x12!\n" +
+	"\tpredicate\x18\x02 \x01(\tH\x00R\tpredicate\x88\x01\x01B\f\n" +
+	"\n" +
+	"_predicate\"\xe5\x01\n" +
+	"\vTruthChange\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04spid\x18\x02 \x01(\tR\x04spid\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\x12\x1c\n" +
+	"\tpredicate\x18\x04 \x01(\tR\tpredicate\x12,\n" +
+	"\bprevious\x18\x05 \x01(\v2\x10.synapse.v1.KpakR\bprevious\x12*\n" +
+	"\acurrent\x18\x06 \x01(\v2\x10.synapse.v1.KpakR\acurrent\x12\x1c\n" +
+	"\ttimestamp\x18\a \x01(\x03R\ttimestamp2\xb6\b\n" +
 	"\x0eSynapseService\x128\n" +
 	"\x06Ingest\x12\x10.synapse.v1.Kpak\x1a\x1a.synapse.v1.IngestResponse(\x01\x125\n" +
 	"\x05Query\x12\x18.synapse.v1.QueryRequest\x1a\x10.synapse.v1.Kpak0\x01\x12?\n" +
 	"\x06Health\x12\x19.synapse.v1.HealthRequest\x1a\x1a.synapse.v1.HealthResponse\x12?\n" +
 	"\bGetPeers\x12\x18.synapse.v1.PeersRequest\x1a\x19.synapse.v1.PeersResponse\x12E\n" +
 	"\n" +
-	"GetMetrics\x12\x1a.synapse.v1.MetricsRequest\x1a\x1b.synapse.v1.MetricsResponseB\x1fZ\x1dgithub.com/Pew-X/sutra/api/v1b\x06proto3"
+	"GetMetrics\x12\x1a.synapse.v1.MetricsRequest\x1a\x1b.synapse.v1.MetricsResponse\x12B\n" +
+	"\aRetract\x12\x1a.synapse.v1.RetractRequest\x1a\x1b.synapse.v1.RetractResponse\x12M\n" +
+	"\fDefineSchema\x12\x1b.synapse.v1.PredicateSchema\x1a .synapse.v1.DefineSchemaResponse\x12N\n" +
+	"\vListSchemas\x12\x1e.synapse.v1.ListSchemasRequest\x1a\x1f.synapse.v1.ListSchemasResponse\x12H\n" +
+	"\tConflicts\x12\x1c.synapse.v1.ConflictsRequest\x1a\x1d.synapse.v1.ConflictsResponse\x12H\n" +
+	"\tAnomalies\x12\x1c.synapse.v1.AnomaliesRequest\x1a\x1d.synapse.v1.AnomaliesResponse\x12E\n" +
+	"\bOverride\x12\x1b.synapse.v1.OverrideRequest\x1a\x1c.synapse.v1.OverrideResponse\x12K\n" +
+	"\n" +
+	"ListReview\x12\x1d.synapse.v1.ListReviewRequest\x1a\x1e.synapse.v1.ListReviewResponse\x12Q\n" +
+	"\fDecideReview\x12\x1f.synapse.v1.DecideReviewRequest\x1a .synapse.v1.DecideReviewResponse\x12N\n" +
+	"\vSetLogLevel\x12\x1e.synapse.v1.SetLogLevelRequest\x1a\x1f.synapse.v1.SetLogLevelResponse\x12<\n" +
+	"\x05Watch\x12\x18.synapse.v1.WatchRequest\x1a\x17.synapse.v1.TruthChange0\x01B\x1fZ\x1dgithub.com/Pew-X/sutra/api/v1b\x06proto3"
 
 var (
 	file_api_v1_synapse_proto_rawDescOnce sync.Once
@@ -709,36 +2962,116 @@ func file_api_v1_synapse_proto_rawDescGZIP() []byte {
 	return file_api_v1_synapse_proto_rawDescData
 }
 
-var file_api_v1_synapse_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_v1_synapse_proto_msgTypes = make([]protoimpl.MessageInfo, 41)
 var file_api_v1_synapse_proto_goTypes = []any{
-	(*Kpak)(nil),            // 0: synapse.v1.Kpak
-	(*IngestResponse)(nil),  // 1: synapse.v1.IngestResponse
-	(*QueryRequest)(nil),    // 2: synapse.v1.QueryRequest
-	(*HealthRequest)(nil),   // 3: synapse.v1.HealthRequest
-	(*HealthResponse)(nil),  // 4: synapse.v1.HealthResponse
-	(*PeersRequest)(nil),    // 5: synapse.v1.PeersRequest
-	(*PeersResponse)(nil),   // 6: synapse.v1.PeersResponse
-	(*PeerInfo)(nil),        // 7: synapse.v1.PeerInfo
-	(*MetricsRequest)(nil),  // 8: synapse.v1.MetricsRequest
-	(*MetricsResponse)(nil), // 9: synapse.v1.MetricsResponse
+	(*Kpak)(nil),                  // 0: synapse.v1.Kpak
+	(*Value)(nil),                 // 1: synapse.v1.Value
+	(*IngestResponse)(nil),        // 2: synapse.v1.IngestResponse
+	(*QueryRequest)(nil),          // 3: synapse.v1.QueryRequest
+	(*HealthRequest)(nil),         // 4: synapse.v1.HealthRequest
+	(*HealthResponse)(nil),        // 5: synapse.v1.HealthResponse
+	(*HealthCheck)(nil),           // 6: synapse.v1.HealthCheck
+	(*PeersRequest)(nil),          // 7: synapse.v1.PeersRequest
+	(*PeersResponse)(nil),         // 8: synapse.v1.PeersResponse
+	(*PeerInfo)(nil),              // 9: synapse.v1.PeerInfo
+	(*MetricsRequest)(nil),        // 10: synapse.v1.MetricsRequest
+	(*MetricsResponse)(nil),       // 11: synapse.v1.MetricsResponse
+	(*RetractRequest)(nil),        // 12: synapse.v1.RetractRequest
+	(*RetractResponse)(nil),       // 13: synapse.v1.RetractResponse
+	(*PredicateSchema)(nil),       // 14: synapse.v1.PredicateSchema
+	(*DefineSchemaResponse)(nil),  // 15: synapse.v1.DefineSchemaResponse
+	(*ListSchemasRequest)(nil),    // 16: synapse.v1.ListSchemasRequest
+	(*ListSchemasResponse)(nil),   // 17: synapse.v1.ListSchemasResponse
+	(*ConflictsRequest)(nil),      // 18: synapse.v1.ConflictsRequest
+	(*Conflict)(nil),              // 19: synapse.v1.Conflict
+	(*ConflictsResponse)(nil),     // 20: synapse.v1.ConflictsResponse
+	(*AnomaliesRequest)(nil),      // 21: synapse.v1.AnomaliesRequest
+	(*Anomaly)(nil),               // 22: synapse.v1.Anomaly
+	(*AnomaliesResponse)(nil),     // 23: synapse.v1.AnomaliesResponse
+	(*OverrideRequest)(nil),       // 24: synapse.v1.OverrideRequest
+	(*OverrideResponse)(nil),      // 25: synapse.v1.OverrideResponse
+	(*ReviewItem)(nil),            // 26: synapse.v1.ReviewItem
+	(*ListReviewRequest)(nil),     // 27: synapse.v1.ListReviewRequest
+	(*ListReviewResponse)(nil),    // 28: synapse.v1.ListReviewResponse
+	(*DecideReviewRequest)(nil),   // 29: synapse.v1.DecideReviewRequest
+	(*DecideReviewResponse)(nil),  // 30: synapse.v1.DecideReviewResponse
+	(*SetLogLevelRequest)(nil),    // 31: synapse.v1.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),   // 32: synapse.v1.SetLogLevelResponse
+	(*WatchRequest)(nil),          // 33: synapse.v1.WatchRequest
+	(*TruthChange)(nil),           // 34: synapse.v1.TruthChange
+	nil,                           // 35: synapse.v1.Kpak.VersionEntry
+	nil,                           // 36: synapse.v1.MetricsResponse.PolicyViolationsBySourceEntry
+	nil,                           // 37: synapse.v1.MetricsResponse.ThrottledBySourceEntry
+	nil,                           // 38: synapse.v1.MetricsResponse.QuotaExceededBySourceEntry
+	nil,                           // 39: synapse.v1.PredicateSchema.AliasesEntry
+	nil,                           // 40: synapse.v1.Conflict.LocalVersionEntry
+	(*timestamppb.Timestamp)(nil), // 41: google.protobuf.Timestamp
 }
 var file_api_v1_synapse_proto_depIdxs = []int32{
-	7, // 0: synapse.v1.PeersResponse.peers:type_name -> synapse.v1.PeerInfo
-	0, // 1: synapse.v1.SynapseService.Ingest:input_type -> synapse.v1.Kpak
-	2, // 2: synapse.v1.SynapseService.Query:input_type -> synapse.v1.QueryRequest
-	3, // 3: synapse.v1.SynapseService.Health:input_type -> synapse.v1.HealthRequest
-	5, // 4: synapse.v1.SynapseService.GetPeers:input_type -> synapse.v1.PeersRequest
-	8, // 5: synapse.v1.SynapseService.GetMetrics:input_type -> synapse.v1.MetricsRequest
-	1, // 6: synapse.v1.SynapseService.Ingest:output_type -> synapse.v1.IngestResponse
-	0, // 7: synapse.v1.SynapseService.Query:output_type -> synapse.v1.Kpak
-	4, // 8: synapse.v1.SynapseService.Health:output_type -> synapse.v1.HealthResponse
-	6, // 9: synapse.v1.SynapseService.GetPeers:output_type -> synapse.v1.PeersResponse
-	9, // 10: synapse.v1.SynapseService.GetMetrics:output_type -> synapse.v1.MetricsResponse
-	6, // [6:11] is the sub-list for method output_type
-	1, // [1:6] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	1,  // 0: synapse.v1.Kpak.value:type_name -> synapse.v1.Value
+	35, // 1: synapse.v1.Kpak.version:type_name -> synapse.v1.Kpak.VersionEntry
+	41, // 2: synapse.v1.Value.timestamp_value:type_name -> google.protobuf.Timestamp
+	6,  // 3: synapse.v1.HealthResponse.checks:type_name -> synapse.v1.HealthCheck
+	9,  // 4: synapse.v1.PeersResponse.peers:type_name -> synapse.v1.PeerInfo
+	36, // 5: synapse.v1.MetricsResponse.policy_violations_by_source:type_name -> synapse.v1.MetricsResponse.PolicyViolationsBySourceEntry
+	37, // 6: synapse.v1.MetricsResponse.throttled_by_source:type_name -> synapse.v1.MetricsResponse.ThrottledBySourceEntry
+	38, // 7: synapse.v1.MetricsResponse.quota_exceeded_by_source:type_name -> synapse.v1.MetricsResponse.QuotaExceededBySourceEntry
+	1,  // 8: synapse.v1.RetractRequest.value:type_name -> synapse.v1.Value
+	0,  // 9: synapse.v1.RetractResponse.current:type_name -> synapse.v1.Kpak
+	0,  // 10: synapse.v1.RetractResponse.members:type_name -> synapse.v1.Kpak
+	39, // 11: synapse.v1.PredicateSchema.aliases:type_name -> synapse.v1.PredicateSchema.AliasesEntry
+	14, // 12: synapse.v1.DefineSchemaResponse.schema:type_name -> synapse.v1.PredicateSchema
+	14, // 13: synapse.v1.ListSchemasResponse.schemas:type_name -> synapse.v1.PredicateSchema
+	0,  // 14: synapse.v1.Conflict.local:type_name -> synapse.v1.Kpak
+	0,  // 15: synapse.v1.Conflict.remote:type_name -> synapse.v1.Kpak
+	0,  // 16: synapse.v1.Conflict.winner:type_name -> synapse.v1.Kpak
+	40, // 17: synapse.v1.Conflict.local_version:type_name -> synapse.v1.Conflict.LocalVersionEntry
+	19, // 18: synapse.v1.ConflictsResponse.conflicts:type_name -> synapse.v1.Conflict
+	0,  // 19: synapse.v1.Anomaly.winner:type_name -> synapse.v1.Kpak
+	0,  // 20: synapse.v1.Anomaly.rival:type_name -> synapse.v1.Kpak
+	22, // 21: synapse.v1.AnomaliesResponse.anomalies:type_name -> synapse.v1.Anomaly
+	1,  // 22: synapse.v1.OverrideRequest.value:type_name -> synapse.v1.Value
+	0,  // 23: synapse.v1.OverrideResponse.pinned:type_name -> synapse.v1.Kpak
+	0,  // 24: synapse.v1.ReviewItem.claim:type_name -> synapse.v1.Kpak
+	26, // 25: synapse.v1.ListReviewResponse.items:type_name -> synapse.v1.ReviewItem
+	26, // 26: synapse.v1.DecideReviewResponse.item:type_name -> synapse.v1.ReviewItem
+	0,  // 27: synapse.v1.TruthChange.previous:type_name -> synapse.v1.Kpak
+	0,  // 28: synapse.v1.TruthChange.current:type_name -> synapse.v1.Kpak
+	0,  // 29: synapse.v1.SynapseService.Ingest:input_type -> synapse.v1.Kpak
+	3,  // 30: synapse.v1.SynapseService.Query:input_type -> synapse.v1.QueryRequest
+	4,  // 31: synapse.v1.SynapseService.Health:input_type -> synapse.v1.HealthRequest
+	7,  // 32: synapse.v1.SynapseService.GetPeers:input_type -> synapse.v1.PeersRequest
+	10, // 33: synapse.v1.SynapseService.GetMetrics:input_type -> synapse.v1.MetricsRequest
+	12, // 34: synapse.v1.SynapseService.Retract:input_type -> synapse.v1.RetractRequest
+	14, // 35: synapse.v1.SynapseService.DefineSchema:input_type -> synapse.v1.PredicateSchema
+	16, // 36: synapse.v1.SynapseService.ListSchemas:input_type -> synapse.v1.ListSchemasRequest
+	18, // 37: synapse.v1.SynapseService.Conflicts:input_type -> synapse.v1.ConflictsRequest
+	21, // 38: synapse.v1.SynapseService.Anomalies:input_type -> synapse.v1.AnomaliesRequest
+	24, // 39: synapse.v1.SynapseService.Override:input_type -> synapse.v1.OverrideRequest
+	27, // 40: synapse.v1.SynapseService.ListReview:input_type -> synapse.v1.ListReviewRequest
+	29, // 41: synapse.v1.SynapseService.DecideReview:input_type -> synapse.v1.DecideReviewRequest
+	31, // 42: synapse.v1.SynapseService.SetLogLevel:input_type -> synapse.v1.SetLogLevelRequest
+	33, // 43: synapse.v1.SynapseService.Watch:input_type -> synapse.v1.WatchRequest
+	2,  // 44: synapse.v1.SynapseService.Ingest:output_type -> synapse.v1.IngestResponse
+	0,  // 45: synapse.v1.SynapseService.Query:output_type -> synapse.v1.Kpak
+	5,  // 46: synapse.v1.SynapseService.Health:output_type -> synapse.v1.HealthResponse
+	8,  // 47: synapse.v1.SynapseService.GetPeers:output_type -> synapse.v1.PeersResponse
+	11, // 48: synapse.v1.SynapseService.GetMetrics:output_type -> synapse.v1.MetricsResponse
+	13, // 49: synapse.v1.SynapseService.Retract:output_type -> synapse.v1.RetractResponse
+	15, // 50: synapse.v1.SynapseService.DefineSchema:output_type -> synapse.v1.DefineSchemaResponse
+	17, // 51: synapse.v1.SynapseService.ListSchemas:output_type -> synapse.v1.ListSchemasResponse
+	20, // 52: synapse.v1.SynapseService.Conflicts:output_type -> synapse.v1.ConflictsResponse
+	23, // 53: synapse.v1.SynapseService.Anomalies:output_type -> synapse.v1.AnomaliesResponse
+	25, // 54: synapse.v1.SynapseService.Override:output_type -> synapse.v1.OverrideResponse
+	28, // 55: synapse.v1.SynapseService.ListReview:output_type -> synapse.v1.ListReviewResponse
+	30, // 56: synapse.v1.SynapseService.DecideReview:output_type -> synapse.v1.DecideReviewResponse
+	32, // 57: synapse.v1.SynapseService.SetLogLevel:output_type -> synapse.v1.SetLogLevelResponse
+	34, // 58: synapse.v1.SynapseService.Watch:output_type -> synapse.v1.TruthChange
+	44, // [44:59] is the sub-list for method output_type
+	29, // [29:44] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_synapse_proto_init() }
@@ -746,14 +3079,26 @@ func file_api_v1_synapse_proto_init() {
 	if File_api_v1_synapse_proto != nil {
 		return
 	}
-	file_api_v1_synapse_proto_msgTypes[2].OneofWrappers = []any{}
+	file_api_v1_synapse_proto_msgTypes[1].OneofWrappers = []any{
+		(*Value_StringValue)(nil),
+		(*Value_IntValue)(nil),
+		(*Value_FloatValue)(nil),
+		(*Value_BoolValue)(nil),
+		(*Value_BytesValue)(nil),
+		(*Value_TimestampValue)(nil),
+		(*Value_JsonValue)(nil),
+		(*Value_RefValue)(nil),
+	}
+	file_api_v1_synapse_proto_msgTypes[3].OneofWrappers = []any{}
+	file_api_v1_synapse_proto_msgTypes[14].OneofWrappers = []any{}
+	file_api_v1_synapse_proto_msgTypes[33].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_synapse_proto_rawDesc), len(file_api_v1_synapse_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   41,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
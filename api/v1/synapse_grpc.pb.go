@@ -19,11 +19,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SynapseService_Ingest_FullMethodName     = "/synapse.v1.SynapseService/Ingest"
-	SynapseService_Query_FullMethodName      = "/synapse.v1.SynapseService/Query"
-	SynapseService_Health_FullMethodName     = "/synapse.v1.SynapseService/Health"
-	SynapseService_GetPeers_FullMethodName   = "/synapse.v1.SynapseService/GetPeers"
-	SynapseService_GetMetrics_FullMethodName = "/synapse.v1.SynapseService/GetMetrics"
+	SynapseService_Ingest_FullMethodName       = "/synapse.v1.SynapseService/Ingest"
+	SynapseService_Query_FullMethodName        = "/synapse.v1.SynapseService/Query"
+	SynapseService_Health_FullMethodName       = "/synapse.v1.SynapseService/Health"
+	SynapseService_GetPeers_FullMethodName     = "/synapse.v1.SynapseService/GetPeers"
+	SynapseService_GetMetrics_FullMethodName   = "/synapse.v1.SynapseService/GetMetrics"
+	SynapseService_Retract_FullMethodName      = "/synapse.v1.SynapseService/Retract"
+	SynapseService_DefineSchema_FullMethodName = "/synapse.v1.SynapseService/DefineSchema"
+	SynapseService_ListSchemas_FullMethodName  = "/synapse.v1.SynapseService/ListSchemas"
+	SynapseService_Conflicts_FullMethodName    = "/synapse.v1.SynapseService/Conflicts"
+	SynapseService_Anomalies_FullMethodName    = "/synapse.v1.SynapseService/Anomalies"
+	SynapseService_Override_FullMethodName     = "/synapse.v1.SynapseService/Override"
+	SynapseService_ListReview_FullMethodName   = "/synapse.v1.SynapseService/ListReview"
+	SynapseService_DecideReview_FullMethodName = "/synapse.v1.SynapseService/DecideReview"
+	SynapseService_SetLogLevel_FullMethodName  = "/synapse.v1.SynapseService/SetLogLevel"
+	SynapseService_Watch_FullMethodName        = "/synapse.v1.SynapseService/Watch"
 )
 
 // SynapseServiceClient is the client API for SynapseService service.
@@ -42,6 +52,26 @@ type SynapseServiceClient interface {
 	GetPeers(ctx context.Context, in *PeersRequest, opts ...grpc.CallOption) (*PeersResponse, error)
 	// GetMetrics returns agent performance metrics
 	GetMetrics(ctx context.Context, in *MetricsRequest, opts ...grpc.CallOption) (*MetricsResponse, error)
+	// Retract withdraws a source's claims about a subject+predicate
+	Retract(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractResponse, error)
+	// DefineSchema declares or replaces the schema for a predicate across the mesh
+	DefineSchema(ctx context.Context, in *PredicateSchema, opts ...grpc.CallOption) (*DefineSchemaResponse, error)
+	// ListSchemas returns the predicate schemas this agent enforces
+	ListSchemas(ctx context.Context, in *ListSchemasRequest, opts ...grpc.CallOption) (*ListSchemasResponse, error)
+	// Conflicts lists recent concurrent updates to the same fact
+	Conflicts(ctx context.Context, in *ConflictsRequest, opts ...grpc.CallOption) (*ConflictsResponse, error)
+	// Anomalies lists flapping facts and contradictions reported by analyzers in the mesh
+	Anomalies(ctx context.Context, in *AnomaliesRequest, opts ...grpc.CallOption) (*AnomaliesResponse, error)
+	// Override pins a fact to an operator-chosen value across the mesh, or lifts the pin
+	Override(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*OverrideResponse, error)
+	// ListReview lists claims held for review and recent decisions
+	ListReview(ctx context.Context, in *ListReviewRequest, opts ...grpc.CallOption) (*ListReviewResponse, error)
+	// DecideReview approves or rejects a held claim across the mesh
+	DecideReview(ctx context.Context, in *DecideReviewRequest, opts ...grpc.CallOption) (*DecideReviewResponse, error)
+	// SetLogLevel changes the agent's log level at runtime, or reports it
+	SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error)
+	// Watch streams changes to the accepted truth as they happen on this agent
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TruthChange], error)
 }
 
 type synapseServiceClient struct {
@@ -114,6 +144,115 @@ func (c *synapseServiceClient) GetMetrics(ctx context.Context, in *MetricsReques
 	return out, nil
 }
 
+func (c *synapseServiceClient) Retract(ctx context.Context, in *RetractRequest, opts ...grpc.CallOption) (*RetractResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetractResponse)
+	err := c.cc.Invoke(ctx, SynapseService_Retract_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) DefineSchema(ctx context.Context, in *PredicateSchema, opts ...grpc.CallOption) (*DefineSchemaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DefineSchemaResponse)
+	err := c.cc.Invoke(ctx, SynapseService_DefineSchema_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) ListSchemas(ctx context.Context, in *ListSchemasRequest, opts ...grpc.CallOption) (*ListSchemasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSchemasResponse)
+	err := c.cc.Invoke(ctx, SynapseService_ListSchemas_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) Conflicts(ctx context.Context, in *ConflictsRequest, opts ...grpc.CallOption) (*ConflictsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConflictsResponse)
+	err := c.cc.Invoke(ctx, SynapseService_Conflicts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) Anomalies(ctx context.Context, in *AnomaliesRequest, opts ...grpc.CallOption) (*AnomaliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AnomaliesResponse)
+	err := c.cc.Invoke(ctx, SynapseService_Anomalies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) Override(ctx context.Context, in *OverrideRequest, opts ...grpc.CallOption) (*OverrideResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(OverrideResponse)
+	err := c.cc.Invoke(ctx, SynapseService_Override_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) ListReview(ctx context.Context, in *ListReviewRequest, opts ...grpc.CallOption) (*ListReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListReviewResponse)
+	err := c.cc.Invoke(ctx, SynapseService_ListReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) DecideReview(ctx context.Context, in *DecideReviewRequest, opts ...grpc.CallOption) (*DecideReviewResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DecideReviewResponse)
+	err := c.cc.Invoke(ctx, SynapseService_DecideReview_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) SetLogLevel(ctx context.Context, in *SetLogLevelRequest, opts ...grpc.CallOption) (*SetLogLevelResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetLogLevelResponse)
+	err := c.cc.Invoke(ctx, SynapseService_SetLogLevel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *synapseServiceClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TruthChange], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SynapseService_ServiceDesc.Streams[2], SynapseService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, TruthChange]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SynapseService_WatchClient = grpc.ServerStreamingClient[TruthChange]
+
 // SynapseServiceServer is the server API for SynapseService service.
 // All implementations must embed UnimplementedSynapseServiceServer
 // for forward compatibility.
@@ -130,6 +269,26 @@ type SynapseServiceServer interface {
 	GetPeers(context.Context, *PeersRequest) (*PeersResponse, error)
 	// GetMetrics returns agent performance metrics
 	GetMetrics(context.Context, *MetricsRequest) (*MetricsResponse, error)
+	// Retract withdraws a source's claims about a subject+predicate
+	Retract(context.Context, *RetractRequest) (*RetractResponse, error)
+	// DefineSchema declares or replaces the schema for a predicate across the mesh
+	DefineSchema(context.Context, *PredicateSchema) (*DefineSchemaResponse, error)
+	// ListSchemas returns the predicate schemas this agent enforces
+	ListSchemas(context.Context, *ListSchemasRequest) (*ListSchemasResponse, error)
+	// Conflicts lists recent concurrent updates to the same fact
+	Conflicts(context.Context, *ConflictsRequest) (*ConflictsResponse, error)
+	// Anomalies lists flapping facts and contradictions reported by analyzers in the mesh
+	Anomalies(context.Context, *AnomaliesRequest) (*AnomaliesResponse, error)
+	// Override pins a fact to an operator-chosen value across the mesh, or lifts the pin
+	Override(context.Context, *OverrideRequest) (*OverrideResponse, error)
+	// ListReview lists claims held for review and recent decisions
+	ListReview(context.Context, *ListReviewRequest) (*ListReviewResponse, error)
+	// DecideReview approves or rejects a held claim across the mesh
+	DecideReview(context.Context, *DecideReviewRequest) (*DecideReviewResponse, error)
+	// SetLogLevel changes the agent's log level at runtime, or reports it
+	SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error)
+	// Watch streams changes to the accepted truth as they happen on this agent
+	Watch(*WatchRequest, grpc.ServerStreamingServer[TruthChange]) error
 	mustEmbedUnimplementedSynapseServiceServer()
 }
 
@@ -155,6 +314,36 @@ func (UnimplementedSynapseServiceServer) GetPeers(context.Context, *PeersRequest
 func (UnimplementedSynapseServiceServer) GetMetrics(context.Context, *MetricsRequest) (*MetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
 }
+func (UnimplementedSynapseServiceServer) Retract(context.Context, *RetractRequest) (*RetractResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Retract not implemented")
+}
+func (UnimplementedSynapseServiceServer) DefineSchema(context.Context, *PredicateSchema) (*DefineSchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DefineSchema not implemented")
+}
+func (UnimplementedSynapseServiceServer) ListSchemas(context.Context, *ListSchemasRequest) (*ListSchemasResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSchemas not implemented")
+}
+func (UnimplementedSynapseServiceServer) Conflicts(context.Context, *ConflictsRequest) (*ConflictsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Conflicts not implemented")
+}
+func (UnimplementedSynapseServiceServer) Anomalies(context.Context, *AnomaliesRequest) (*AnomaliesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Anomalies not implemented")
+}
+func (UnimplementedSynapseServiceServer) Override(context.Context, *OverrideRequest) (*OverrideResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Override not implemented")
+}
+func (UnimplementedSynapseServiceServer) ListReview(context.Context, *ListReviewRequest) (*ListReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListReview not implemented")
+}
+func (UnimplementedSynapseServiceServer) DecideReview(context.Context, *DecideReviewRequest) (*DecideReviewResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DecideReview not implemented")
+}
+func (UnimplementedSynapseServiceServer) SetLogLevel(context.Context, *SetLogLevelRequest) (*SetLogLevelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetLogLevel not implemented")
+}
+func (UnimplementedSynapseServiceServer) Watch(*WatchRequest, grpc.ServerStreamingServer[TruthChange]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedSynapseServiceServer) mustEmbedUnimplementedSynapseServiceServer() {}
 func (UnimplementedSynapseServiceServer) testEmbeddedByValue()                        {}
 
@@ -248,6 +437,179 @@ func _SynapseService_GetMetrics_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SynapseService_Retract_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).Retract(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_Retract_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).Retract(ctx, req.(*RetractRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_DefineSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PredicateSchema)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).DefineSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_DefineSchema_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).DefineSchema(ctx, req.(*PredicateSchema))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_ListSchemas_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSchemasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).ListSchemas(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_ListSchemas_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).ListSchemas(ctx, req.(*ListSchemasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_Conflicts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConflictsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).Conflicts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_Conflicts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).Conflicts(ctx, req.(*ConflictsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_Anomalies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnomaliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).Anomalies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_Anomalies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).Anomalies(ctx, req.(*AnomaliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_Override_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OverrideRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).Override(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_Override_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).Override(ctx, req.(*OverrideRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_ListReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).ListReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_ListReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).ListReview(ctx, req.(*ListReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_DecideReview_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DecideReviewRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).DecideReview(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_DecideReview_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).DecideReview(ctx, req.(*DecideReviewRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_SetLogLevel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetLogLevelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SynapseServiceServer).SetLogLevel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SynapseService_SetLogLevel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SynapseServiceServer).SetLogLevel(ctx, req.(*SetLogLevelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SynapseService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SynapseServiceServer).Watch(m, &grpc.GenericServerStream[WatchRequest, TruthChange]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SynapseService_WatchServer = grpc.ServerStreamingServer[TruthChange]
+
 // SynapseService_ServiceDesc is the grpc.ServiceDesc for SynapseService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -267,6 +629,42 @@ var SynapseService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMetrics",
 			Handler:    _SynapseService_GetMetrics_Handler,
 		},
+		{
+			MethodName: "Retract",
+			Handler:    _SynapseService_Retract_Handler,
+		},
+		{
+			MethodName: "DefineSchema",
+			Handler:    _SynapseService_DefineSchema_Handler,
+		},
+		{
+			MethodName: "ListSchemas",
+			Handler:    _SynapseService_ListSchemas_Handler,
+		},
+		{
+			MethodName: "Conflicts",
+			Handler:    _SynapseService_Conflicts_Handler,
+		},
+		{
+			MethodName: "Anomalies",
+			Handler:    _SynapseService_Anomalies_Handler,
+		},
+		{
+			MethodName: "Override",
+			Handler:    _SynapseService_Override_Handler,
+		},
+		{
+			MethodName: "ListReview",
+			Handler:    _SynapseService_ListReview_Handler,
+		},
+		{
+			MethodName: "DecideReview",
+			Handler:    _SynapseService_DecideReview_Handler,
+		},
+		{
+			MethodName: "SetLogLevel",
+			Handler:    _SynapseService_SetLogLevel_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -279,6 +677,11 @@ var SynapseService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SynapseService_Query_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _SynapseService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "api/v1/synapse.proto",
 }
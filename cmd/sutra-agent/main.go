@@ -3,6 +3,7 @@ package main
 import (
 	"flag"
 	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
@@ -10,6 +11,8 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/Pew-X/sutra/internal/agent"
+	"github.com/Pew-X/sutra/internal/gossip"
+	"github.com/Pew-X/sutra/internal/logging"
 )
 
 func main() {
@@ -23,6 +26,11 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	// Route every log through the configured level and format
+	if err := logging.Setup(os.Stderr, config.LogLevel, config.LogFormat, gossip.NodeName(config.Host, config.GossipPort)); err != nil {
+		log.Fatalf("Invalid logging configuration: %v", err)
+	}
+
 	// Create and start agent
 	synapseAgent, err := agent.NewAgent(*config)
 	if err != nil {
@@ -63,10 +71,10 @@ func waitForShutdown(synapseAgent *agent.Agent) {
 
 	// Block until signal is received
 	sig := <-sigChan
-	log.Printf("Received signal: %v", sig)
+	slog.Info("Received signal", "signal", sig.String())
 
 	// Graceful shutdown
 	if err := synapseAgent.Shutdown(); err != nil {
-		log.Printf("Error during shutdown: %v", err)
+		slog.Error("Shutdown failed", logging.KeyError, err)
 	}
 }
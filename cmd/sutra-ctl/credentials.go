@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// ctlConfig is the optional sutra-ctl config file.
+type ctlConfig struct {
+	Agent string `yaml:"agent"` // Address of the agent
+	Token string `yaml:"token"` // Bearer token or JWT sent with every request
+}
+
+// defaultConfigPath returns where sutra-ctl looks for its config file when --config is not given.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "sutra", "sutra-ctl.yaml")
+}
+
+// loadCredentials fills in the agent address and token that were not given as
+// flags, from SUTRA_AGENT/SUTRA_TOKEN and then the config file.
+func loadCredentials(cmd *cobra.Command) error {
+	file := ctlConfig{}
+	path := configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			if err := yaml.Unmarshal(data, &file); err != nil {
+				return fmt.Errorf("invalid config file %s: %w", path, err)
+			}
+		case !os.IsNotExist(err) || configPath != "":
+			return fmt.Errorf("failed to read config file: %w", err)
+		}
+	}
+
+	flags := cmd.Flags()
+	if !flags.Changed("agent") {
+		if env := os.Getenv("SUTRA_AGENT"); env != "" {
+			agentAddr = env
+		} else if file.Agent != "" {
+			agentAddr = file.Agent
+		}
+	}
+	if !flags.Changed("token") {
+		if env := os.Getenv("SUTRA_TOKEN"); env != "" {
+			token = env
+		} else {
+			token = file.Token
+		}
+	}
+	return nil
+}
+
+// bearerToken sends a token in the authorization metadata of every request.
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity is false because agents do not serve TLS yet.
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
+}
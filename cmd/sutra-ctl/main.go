@@ -2,20 +2,30 @@ package main
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	v1 "github.com/Pew-X/sutra/api/v1"
 )
 
 var (
-	agentAddr string
-	timeout   time.Duration
+	agentAddr  string
+	timeout    time.Duration
+	token      string
+	configPath string
 )
 
 func main() {
@@ -24,11 +34,16 @@ func main() {
 		Short: "Sutra Control - CLI for interacting with Sutra agents",
 		Long: `sutractl is a command line interface for querying and managing
 Sutra knowledge mesh agents.`,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return loadCredentials(cmd)
+		},
 	}
 
 	// Global flags
-	rootCmd.PersistentFlags().StringVar(&agentAddr, "agent", "localhost:9090", "Address of Sutra agent")
+	rootCmd.PersistentFlags().StringVar(&agentAddr, "agent", "localhost:9090", "Address of Sutra agent (or $SUTRA_AGENT)")
 	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 10*time.Second, "Request timeout")
+	rootCmd.PersistentFlags().StringVar(&token, "token", "", "Bearer token or JWT for agents with auth enabled (or $SUTRA_TOKEN)")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file with agent and token (default "+defaultConfigPath()+")")
 
 	// subcommands
 	rootCmd.AddCommand(queryCmd())
@@ -37,6 +52,15 @@ Sutra knowledge mesh agents.`,
 	rootCmd.AddCommand(healthCmd())
 	rootCmd.AddCommand(metricsCmd())
 	rootCmd.AddCommand(peersCmd())
+	rootCmd.AddCommand(retractCmd())
+	rootCmd.AddCommand(schemaCmd())
+	rootCmd.AddCommand(conflictsCmd())
+	rootCmd.AddCommand(anomaliesCmd())
+	rootCmd.AddCommand(pinCmd())
+	rootCmd.AddCommand(unpinCmd())
+	rootCmd.AddCommand(reviewCmd())
+	rootCmd.AddCommand(logLevelCmd())
+	rootCmd.AddCommand(meshCmd())
 
 	if err := rootCmd.Execute(); err != nil {
 		log.Fatal(err)
@@ -45,6 +69,8 @@ Sutra knowledge mesh agents.`,
 
 // queryCmd creates the query subcommand
 func queryCmd() *cobra.Command {
+	var minConfidence float64
+
 	cmd := &cobra.Command{
 		Use:   "query <subject> [predicate]",
 		Short: "Query knowledge about a subject",
@@ -57,10 +83,18 @@ func queryCmd() *cobra.Command {
 				predicate = &args[1]
 			}
 
-			return queryKnowledge(subject, predicate)
+			var minConf *float32
+			if cmd.Flags().Changed("min-confidence") {
+				value := float32(minConfidence)
+				minConf = &value
+			}
+
+			return queryKnowledge(subject, predicate, minConf)
 		},
 	}
 
+	cmd.Flags().Float64Var(&minConfidence, "min-confidence", 0, "Only show facts whose effective confidence is at least this value")
+
 	return cmd
 }
 
@@ -70,6 +104,7 @@ func ingestCmd() *cobra.Command {
 		source     string
 		confidence float64
 		ttlSeconds int64
+		valueType  string
 	)
 
 	cmd := &cobra.Command{
@@ -82,13 +117,19 @@ func ingestCmd() *cobra.Command {
 			predicate := args[1]
 			object := args[2]
 
-			return ingestKnowledge(subject, predicate, object, source, float32(confidence), ttlSeconds)
+			value, err := parseValue(valueType, object)
+			if err != nil {
+				return err
+			}
+
+			return ingestKnowledge(subject, predicate, object, value, source, float32(confidence), ttlSeconds)
 		},
 	}
 
 	cmd.Flags().StringVar(&source, "source", "synctl", "Source identifier for this knowledge")
 	cmd.Flags().Float64Var(&confidence, "confidence", 1.0, "Confidence level (0.0-1.0)")
 	cmd.Flags().Int64Var(&ttlSeconds, "ttl", 0, "Time-to-live in seconds (0 = never expires)")
+	cmd.Flags().StringVar(&valueType, "type", "string", "Object type: string, int, float, bool, bytes (base64), timestamp (RFC 3339), json or ref")
 
 	return cmd
 }
@@ -149,14 +190,307 @@ func peersCmd() *cobra.Command {
 	return cmd
 }
 
+// logLevelCmd creates the log-level subcommand
+func logLevelCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "log-level [debug|info|warn|error]",
+		Short: "Show or change the agent's log level",
+		Long:  "Show the agent's log level, or change it until the agent restarts",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			level := ""
+			if len(args) == 1 {
+				level = args[0]
+			}
+			return setLogLevel(level)
+		},
+	}
+
+	return cmd
+}
+
+// retractCmd creates the retract subcommand
+func retractCmd() *cobra.Command {
+	var (
+		source    string
+		object    string
+		valueType string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "retract <subject> <predicate>",
+		Short: "Withdraw a source's claims",
+		Long:  "Withdraw every claim a source made about a subject+predicate; the next-best claim takes over",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var value *v1.Value
+			if cmd.Flags().Changed("object") {
+				parsed, err := parseValue(valueType, object)
+				if err != nil {
+					return err
+				}
+				value = parsed
+			}
+			return retractKnowledge(args[0], args[1], source, object, value)
+		},
+	}
+
+	cmd.Flags().StringVar(&source, "source", "synctl", "Source whose claims are withdrawn")
+	cmd.Flags().StringVar(&object, "object", "", "Only withdraw claims of this value (e.g. one member of a multi-valued predicate)")
+	cmd.Flags().StringVar(&valueType, "type", "string", "Type of --object: string, int, float, bool, bytes, timestamp, json or ref")
+
+	return cmd
+}
+
+// pinCmd creates the pin subcommand
+func pinCmd() *cobra.Command {
+	var (
+		reason    string
+		until     string
+		operator  string
+		valueType string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pin <subject> <predicate> <object>",
+		Short: "Force a fact to a value across the mesh",
+		Long:  "Pin a subject+predicate to an operator-chosen value until the pin expires or is lifted; claims keep being reconciled underneath",
+		Args:  cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			value, err := parseValue(valueType, args[2])
+			if err != nil {
+				return err
+			}
+			expiresAt, err := parseUntil(until)
+			if err != nil {
+				return err
+			}
+			return pinFact(&v1.OverrideRequest{
+				Subject:   args[0],
+				Predicate: args[1],
+				Object:    args[2],
+				Value:     value,
+				Operator:  operator,
+				Reason:    reason,
+				Until:     expiresAt,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the value is pinned (required)")
+	cmd.Flags().StringVar(&until, "until", "", "When the pin lapses, as a duration (2h) or RFC 3339 time (empty = until unpinned)")
+	cmd.Flags().StringVar(&operator, "operator", os.Getenv("USER"), "Operator setting the pin")
+	cmd.Flags().StringVar(&valueType, "type", "string", "Object type: string, int, float, bool, bytes, timestamp, json or ref")
+	cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+// unpinCmd creates the unpin subcommand
+func unpinCmd() *cobra.Command {
+	var operator string
+
+	cmd := &cobra.Command{
+		Use:   "unpin <subject> <predicate>",
+		Short: "Lift a pin so the reconciled truth shows again",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return pinFact(&v1.OverrideRequest{
+				Subject:   args[0],
+				Predicate: args[1],
+				Operator:  operator,
+				Remove:    true,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&operator, "operator", os.Getenv("USER"), "Operator lifting the pin")
+
+	return cmd
+}
+
+// reviewCmd creates the review subcommand
+func reviewCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "review",
+		Short: "Review held claims",
+		Long:  "List, approve or reject claims held by the agents' review rules; decisions apply across the mesh",
+	}
+
+	var (
+		status string
+		limit  int32
+	)
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List claims held for review",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listReview(status, limit)
+		},
+	}
+	listCmd.Flags().StringVar(&status, "status", "pending", "Show pending, approved, rejected or all claims")
+	listCmd.Flags().Int32Var(&limit, "limit", 50, "Maximum number of claims to show (0 = all)")
+
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(decideCmd("approve", "Approve a held claim so it is reconciled", true))
+	cmd.AddCommand(decideCmd("reject", "Reject a held claim", false))
+
+	return cmd
+}
+
+// decideCmd creates the review approve and reject subcommands
+func decideCmd(name, short string, approve bool) *cobra.Command {
+	var reviewer, note string
+
+	cmd := &cobra.Command{
+		Use:   name + " <claim-id>",
+		Short: short,
+		Long:  short + "; a unique prefix of the claim ID is enough",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return decideReview(&v1.DecideReviewRequest{Id: args[0], Approve: approve, Reviewer: reviewer, Note: note})
+		},
+	}
+
+	cmd.Flags().StringVar(&reviewer, "reviewer", os.Getenv("USER"), "Who is deciding")
+	cmd.Flags().StringVar(&note, "note", "", "Note kept with the decision")
+
+	return cmd
+}
+
+// schemaCmd creates the schema subcommand
+func schemaCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "schema",
+		Short: "Manage predicate schemas",
+		Long:  "List or define the predicate schemas enforced across the mesh",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List predicate schemas",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return listSchemas()
+		},
+	}
+
+	var (
+		req      v1.PredicateSchema
+		min, max float64
+		aliases  []string
+	)
+	defineCmd := &cobra.Command{
+		Use:   "define <predicate>",
+		Short: "Define or replace a predicate schema",
+		Long:  "Define the schema for a predicate or pattern (\"health_*\"); the schema is shared with the whole mesh",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			req.Predicate = args[0]
+			if cmd.Flags().Changed("min") {
+				req.Min = &min
+			}
+			if cmd.Flags().Changed("max") {
+				req.Max = &max
+			}
+			req.Aliases = make(map[string]string)
+			for _, alias := range aliases {
+				from, to, ok := strings.Cut(alias, "=")
+				if !ok {
+					return fmt.Errorf("invalid alias %q, expected <spelling>=<enum value>", alias)
+				}
+				req.Aliases[from] = to
+			}
+			return defineSchema(&req)
+		},
+	}
+	defineCmd.Flags().StringVar(&req.Type, "type", "", "Required object type: string, int, float, bool, bytes, timestamp, json or ref")
+	defineCmd.Flags().StringSliceVar(&req.Enum, "enum", nil, "Allowed values (comma separated)")
+	defineCmd.Flags().StringSliceVar(&aliases, "alias", nil, "Alternative spelling of an enum value, as <spelling>=<enum value>")
+	defineCmd.Flags().Float64Var(&min, "min", 0, "Lowest allowed numeric value")
+	defineCmd.Flags().Float64Var(&max, "max", 0, "Highest allowed numeric value")
+	defineCmd.Flags().StringVar(&req.Cardinality, "cardinality", "", "single (default) or multi")
+	defineCmd.Flags().Int64Var(&req.DefaultTtlSeconds, "ttl", 0, "Default TTL in seconds for claims without an expiry")
+	defineCmd.Flags().StringVar(&req.Resolver, "resolver", "", "Reconciliation mode for this predicate (highest_confidence or fusion)")
+	defineCmd.Flags().StringVar(&req.Crdt, "crdt", "", "Merge updates as a CRDT: g_counter, pn_counter, or_set, lww_register or lww_map")
+	defineCmd.Flags().Int64Var(&req.Version, "version", 0, "Schema version (0 = next version)")
+
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(defineCmd)
+	return cmd
+}
+
+// conflictsCmd creates the conflicts subcommand
+func conflictsCmd() *cobra.Command {
+	var (
+		predicate string
+		limit     int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "conflicts [subject]",
+		Short: "List concurrent updates to the same fact",
+		Long:  "List recent claims that were made concurrently with a different value on another agent, and how they were resolved",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subject := ""
+			if len(args) > 0 {
+				subject = args[0]
+			}
+			return listConflicts(subject, predicate, limit)
+		},
+	}
+
+	cmd.Flags().StringVar(&predicate, "predicate", "", "Only show conflicts about this predicate")
+	cmd.Flags().Int32Var(&limit, "limit", 20, "Maximum number of conflicts to show (0 = all)")
+
+	return cmd
+}
+
+// anomaliesCmd creates the anomalies subcommand
+func anomaliesCmd() *cobra.Command {
+	var (
+		kind  string
+		limit int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "anomalies [subject]",
+		Short: "List flapping facts and contradictions",
+		Long:  "List the facts whose winner keeps changing and the winners contested by a nearly as trusted claim, as reported by analyzers across the mesh",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			subject := ""
+			if len(args) > 0 {
+				subject = args[0]
+			}
+			return listAnomalies(subject, kind, limit)
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "", "Only show anomalies of this kind (flapping or contradiction)")
+	cmd.Flags().Int32Var(&limit, "limit", 20, "Maximum number of anomalies to show (0 = all)")
+
+	return cmd
+}
+
 // Connect to the agent
 func connectToAgent() (v1.SynapseServiceClient, *grpc.ClientConn, error) {
+	return connectTo(agentAddr)
+}
+
+// connectTo connects to the agent at addr with the configured credentials
+func connectTo(addr string) (v1.SynapseServiceClient, *grpc.ClientConn, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
-	conn, err := grpc.DialContext(ctx, agentAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	options := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	if token != "" {
+		options = append(options, grpc.WithPerRPCCredentials(bearerToken(token)))
+	}
+
+	conn, err := grpc.DialContext(ctx, addr, options...)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to connect to agent at %s: %w", agentAddr, err)
+		return nil, nil, fmt.Errorf("failed to connect to agent at %s: %w", addr, err)
 	}
 
 	client := v1.NewSynapseServiceClient(conn)
@@ -164,7 +498,7 @@ func connectToAgent() (v1.SynapseServiceClient, *grpc.ClientConn, error) {
 }
 
 // queryKnowledge queries the mesh for knowledge
-func queryKnowledge(subject string, predicate *string) error {
+func queryKnowledge(subject string, predicate *string, minConfidence *float32) error {
 	client, conn, err := connectToAgent()
 	if err != nil {
 		return err
@@ -175,8 +509,9 @@ func queryKnowledge(subject string, predicate *string) error {
 	defer cancel()
 
 	req := &v1.QueryRequest{
-		Subject:   subject,
-		Predicate: predicate,
+		Subject:       subject,
+		Predicate:     predicate,
+		MinConfidence: minConfidence,
 	}
 
 	stream, err := client.Query(ctx, req)
@@ -184,22 +519,47 @@ func queryKnowledge(subject string, predicate *string) error {
 		return fmt.Errorf("query failed: %w", err)
 	}
 
+	// Agents that are not ready yet answer on the data they have, or refuse
+	header, err := stream.Header()
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
 	fmt.Printf("Knowledge about '%s':\n", subject)
 	if predicate != nil {
 		fmt.Printf("  (filtered by predicate: %s)\n", *predicate)
 	}
+	if state := header.Get("sutra-state"); len(header.Get("sutra-stale")) > 0 {
+		fmt.Printf("  WARNING: the agent is %s; answers may be stale\n", strings.Join(state, ""))
+	}
 	fmt.Println()
 
 	count := 0
 	for {
 		kpak, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
 		if err != nil {
-			break // End of stream
+			return fmt.Errorf("query failed: %w", err)
 		}
 
 		count++
 		fmt.Printf("  %s %s %s\n", kpak.Subject, kpak.Predicate, kpak.Object)
-		fmt.Printf("    Source: %s, Confidence: %.2f, ID: %s\n", kpak.Source, kpak.Confidence, kpak.Id)
+		fmt.Printf("    Source: %s, Confidence: %.2f, Type: %s, ID: %s\n", kpak.Source, kpak.Confidence, valueTypeName(kpak.Value), kpak.Id)
+		if kpak.EffectiveConfidence != kpak.Confidence {
+			fmt.Printf("    Effective confidence: %.2f (decayed)\n", kpak.EffectiveConfidence)
+		}
+		if len(kpak.SupportingSources) > 1 {
+			fmt.Printf("    Fused confidence: %.2f from %v\n", kpak.FusedConfidence, kpak.SupportingSources)
+		}
+		if kpak.Pending {
+			fmt.Printf("    PENDING: winning since %s with %d confirmation(s); not the truth yet\n",
+				time.Unix(kpak.PendingSince, 0).Format(time.RFC3339), kpak.Confirmations)
+		}
+		if kpak.Pinned {
+			fmt.Printf("    PINNED by %s: %s\n", kpak.PinnedBy, kpak.PinReason)
+		}
 		fmt.Println()
 	}
 
@@ -213,7 +573,7 @@ func queryKnowledge(subject string, predicate *string) error {
 }
 
 // ingestKnowledge sends a knowledge packet to the mesh
-func ingestKnowledge(subject, predicate, object, source string, confidence float32, ttlSeconds int64) error {
+func ingestKnowledge(subject, predicate, object string, value *v1.Value, source string, confidence float32, ttlSeconds int64) error {
 	client, conn, err := connectToAgent()
 	if err != nil {
 		return err
@@ -237,6 +597,7 @@ func ingestKnowledge(subject, predicate, object, source string, confidence float
 		Subject:    subject,
 		Predicate:  predicate,
 		Object:     object,
+		Value:      value,
 		Source:     source,
 		Confidence: confidence,
 		Timestamp:  time.Now().Unix(),
@@ -260,6 +621,9 @@ func ingestKnowledge(subject, predicate, object, source string, confidence float
 			fmt.Printf(", TTL: %ds", ttlSeconds)
 		}
 		fmt.Printf("\n")
+	} else if response.Held > 0 {
+		fmt.Printf("⏸ Knowledge packet held for review\n")
+		fmt.Printf("  See 'sutra-ctl review list' to approve or reject it\n")
 	} else {
 		fmt.Printf("✗ Knowledge packet rejected\n")
 		if len(response.Errors) > 0 {
@@ -311,8 +675,20 @@ func checkHealth() error {
 
 	fmt.Printf("Agent Health Status:\n")
 	fmt.Printf("  Status: %s\n", resp.Status)
+	if resp.State != "" {
+		fmt.Printf("  State: %s\n", resp.State)
+	}
+	if resp.Message != "" {
+		fmt.Printf("  Message: %s\n", resp.Message)
+	}
 	fmt.Printf("  Knowledge packets: %d\n", resp.KpakCount)
 	fmt.Printf("  Uptime: %d seconds\n", resp.UptimeSeconds)
+	if len(resp.Checks) > 0 {
+		fmt.Printf("\nChecks:\n")
+		for _, check := range resp.Checks {
+			fmt.Printf("  %-8s %-10s %s\n", check.Name, check.Status, check.Message)
+		}
+	}
 
 	return nil
 }
@@ -341,15 +717,55 @@ func showMetrics() error {
 	fmt.Printf("  Total k-paks: %d\n", resp.TotalKpaks)
 	fmt.Printf("  Total subjects: %d\n", resp.TotalSubjects)
 	fmt.Printf("\nPerformance:\n")
-	fmt.Printf("  Ingest rate: %d k-paks/min\n", resp.IngestRatePerMin)
-	fmt.Printf("  Query rate: %d queries/min\n", resp.QueryRatePerMin)
+	fmt.Printf("  Ingest rate: %d k-paks/min (5m avg %.1f, 15m avg %.1f)\n", resp.IngestRatePerMin, resp.IngestRatePerMinAvg5, resp.IngestRatePerMinAvg15)
+	fmt.Printf("  Query rate: %d queries/min (5m avg %.1f, 15m avg %.1f)\n", resp.QueryRatePerMin, resp.QueryRatePerMinAvg5, resp.QueryRatePerMinAvg15)
 	fmt.Printf("\nSystem Resources:\n")
-	fmt.Printf("  Memory usage: %.2f MB\n", float64(resp.MemoryUsageBytes)/(1024*1024))
+	fmt.Printf("  Memory usage: %.2f MB (heap %.2f MB)\n", float64(resp.MemoryUsageBytes)/(1024*1024), float64(resp.HeapAllocBytes)/(1024*1024))
 	fmt.Printf("  CPU usage: %.1f%%\n", resp.CpuUsagePercent)
+	fmt.Printf("  Goroutines: %d\n", resp.Goroutines)
+	if resp.OpenFds >= 0 {
+		fmt.Printf("  Open files: %d\n", resp.OpenFds)
+	}
+	fmt.Printf("  GC: %d runs, last pause %v, max pause %v, total %v\n", resp.GcRuns,
+		time.Duration(resp.GcPauseLastNs), time.Duration(resp.GcPauseMaxNs), time.Duration(resp.GcPauseTotalNs))
 	fmt.Printf("\nActive Sources:\n")
 	for _, source := range resp.ActiveSources {
 		fmt.Printf("  - %s\n", source)
 	}
+	if resp.PolicyViolations > 0 || resp.ConfidenceCapped > 0 {
+		fmt.Printf("\nSource Policy:\n")
+		fmt.Printf("  Violations: %d\n", resp.PolicyViolations)
+		fmt.Printf("  Confidence capped: %d\n", resp.ConfidenceCapped)
+		sources := make([]string, 0, len(resp.PolicyViolationsBySource))
+		for source := range resp.PolicyViolationsBySource {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			fmt.Printf("  - %s: %d refused\n", source, resp.PolicyViolationsBySource[source])
+		}
+	}
+	if resp.Throttled > 0 || resp.QuotaExceeded > 0 {
+		fmt.Printf("\nRate Limits:\n")
+		fmt.Printf("  Throttled: %d\n", resp.Throttled)
+		fmt.Printf("  Daily quota exceeded: %d\n", resp.QuotaExceeded)
+		sources := make([]string, 0, len(resp.ThrottledBySource))
+		for source := range resp.ThrottledBySource {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			fmt.Printf("  - %s: %d throttled\n", source, resp.ThrottledBySource[source])
+		}
+		sources = sources[:0]
+		for source := range resp.QuotaExceededBySource {
+			sources = append(sources, source)
+		}
+		sort.Strings(sources)
+		for _, source := range sources {
+			fmt.Printf("  - %s: %d over daily quota\n", source, resp.QuotaExceededBySource[source])
+		}
+	}
 
 	return nil
 }
@@ -397,3 +813,406 @@ func listPeers() error {
 
 	return nil
 }
+
+// retractKnowledge withdraws a source's claims about a subject+predicate
+func retractKnowledge(subject, predicate, source, object string, value *v1.Value) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	req := &v1.RetractRequest{
+		Subject:   subject,
+		Predicate: predicate,
+		Source:    source,
+		Object:    object,
+		Value:     value,
+	}
+	resp, err := client.Retract(ctx, req)
+	if err != nil {
+		return fmt.Errorf("retract failed: %w", err)
+	}
+
+	fmt.Printf("✓ Retracted %d claim(s) from %s\n", resp.Retracted, source)
+	if resp.Current != nil {
+		fmt.Printf("  Current truth: %s %s %s\n", resp.Current.Subject, resp.Current.Predicate, resp.Current.Object)
+		fmt.Printf("  Source: %s, Confidence: %.2f\n", resp.Current.Source, resp.Current.Confidence)
+	} else if len(resp.Members) > 0 {
+		fmt.Printf("  Remaining members of %s %s:\n", subject, predicate)
+		for _, member := range resp.Members {
+			fmt.Printf("    %s (Source: %s, Confidence: %.2f)\n", member.Object, member.Source, member.Confidence)
+		}
+	} else {
+		fmt.Printf("  No remaining claims for %s %s\n", subject, predicate)
+	}
+
+	return nil
+}
+
+func pinFact(req *v1.OverrideRequest) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Override(ctx, req)
+	if err != nil {
+		return fmt.Errorf("override failed: %w", err)
+	}
+
+	if resp.Removed {
+		fmt.Printf("✓ Unpinned %s %s (was %s)\n", req.Subject, req.Predicate, resp.Pinned.Object)
+		return nil
+	}
+	fmt.Printf("✓ Pinned %s %s %s\n", resp.Pinned.Subject, resp.Pinned.Predicate, resp.Pinned.Object)
+	if resp.Pinned.ExpiresAt > 0 {
+		fmt.Printf("  Until: %s\n", time.Unix(resp.Pinned.ExpiresAt, 0).Format(time.RFC3339))
+	}
+	return nil
+}
+
+// parseUntil turns a duration from now or an RFC 3339 time into a Unix timestamp (0 when empty).
+func parseUntil(until string) (int64, error) {
+	if until == "" {
+		return 0, nil
+	}
+	if d, err := time.ParseDuration(until); err == nil {
+		return time.Now().Add(d).Unix(), nil
+	}
+	t, err := time.Parse(time.RFC3339, until)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --until %q: use a duration (2h) or an RFC 3339 time", until)
+	}
+	return t.Unix(), nil
+}
+
+// listSchemas prints the schemas enforced by the agent
+func listSchemas() error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListSchemas(ctx, &v1.ListSchemasRequest{})
+	if err != nil {
+		return fmt.Errorf("list schemas failed: %w", err)
+	}
+
+	if len(resp.Schemas) == 0 {
+		fmt.Println("No schemas defined.")
+		return nil
+	}
+
+	fmt.Printf("Predicate Schemas (%d):\n", len(resp.Schemas))
+	for _, schema := range resp.Schemas {
+		printSchema(schema)
+	}
+	return nil
+}
+
+// defineSchema sends a schema definition to the agent
+func defineSchema(req *v1.PredicateSchema) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.DefineSchema(ctx, req)
+	if err != nil {
+		return fmt.Errorf("define schema failed: %w", err)
+	}
+
+	fmt.Printf("✓ Schema defined\n")
+	printSchema(resp.Schema)
+	return nil
+}
+
+// listConflicts prints the concurrent updates recorded by the agent
+func listConflicts(subject, predicate string, limit int32) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Conflicts(ctx, &v1.ConflictsRequest{Subject: subject, Predicate: predicate, Limit: limit})
+	if err != nil {
+		return fmt.Errorf("list conflicts failed: %w", err)
+	}
+
+	if len(resp.Conflicts) == 0 {
+		fmt.Println("No conflicts recorded.")
+		return nil
+	}
+
+	fmt.Printf("Conflicts (%d, newest first):\n", len(resp.Conflicts))
+	for _, conflict := range resp.Conflicts {
+		fmt.Printf("  %s %s (detected %s)\n", conflict.Subject, conflict.Predicate, time.Unix(conflict.DetectedAt, 0).Format(time.RFC3339))
+		fmt.Printf("    Local:  %s from %s (%.2f) at %s\n", conflict.Local.Object, conflict.Local.Source, conflict.Local.Confidence, formatVersion(conflict.LocalVersion))
+		fmt.Printf("    Remote: %s from %s (%.2f) at %s\n", conflict.Remote.Object, conflict.Remote.Source, conflict.Remote.Confidence, formatVersion(conflict.Remote.Version))
+		if conflict.Winner != nil {
+			fmt.Printf("    Winner: %s from %s\n", conflict.Winner.Object, conflict.Winner.Source)
+		}
+	}
+	return nil
+}
+
+// listReview prints the claims held for review
+func listReview(status string, limit int32) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.ListReview(ctx, &v1.ListReviewRequest{Status: status, Limit: limit})
+	if err != nil {
+		return fmt.Errorf("list review failed: %w", err)
+	}
+
+	if len(resp.Items) == 0 {
+		fmt.Println("No claims in review.")
+		return nil
+	}
+
+	fmt.Printf("Claims in review (%d, oldest first):\n", len(resp.Items))
+	for _, item := range resp.Items {
+		fmt.Printf("  %s [%s]\n", item.Id, item.Status)
+		fmt.Printf("    %s %s %s\n", item.Claim.Subject, item.Claim.Predicate, item.Claim.Object)
+		fmt.Printf("    Source: %s, Confidence: %.2f, Held by %s at %s\n", item.Claim.Source, item.Claim.Confidence, item.HeldBy, time.Unix(item.HeldAt, 0).Format(time.RFC3339))
+		fmt.Printf("    Reasons: %s\n", strings.Join(item.Reasons, "; "))
+		if item.Status != "pending" {
+			fmt.Printf("    Decided by %s at %s", item.Reviewer, time.Unix(item.DecidedAt, 0).Format(time.RFC3339))
+			if item.Note != "" {
+				fmt.Printf(": %s", item.Note)
+			}
+			fmt.Println()
+		}
+	}
+	return nil
+}
+
+// decideReview approves or rejects a held claim
+func decideReview(req *v1.DecideReviewRequest) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.DecideReview(ctx, req)
+	if err != nil {
+		return fmt.Errorf("review failed: %w", err)
+	}
+
+	claim := resp.Item.Claim
+	fmt.Printf("✓ Claim %s %s\n", resp.Item.Id, resp.Item.Status)
+	fmt.Printf("  %s %s %s (Source: %s)\n", claim.Subject, claim.Predicate, claim.Object, claim.Source)
+	if req.Approve && !resp.Accepted {
+		fmt.Println("  A more trusted claim holds the truth; the approved claim was kept as a runner-up or dropped")
+	}
+	return nil
+}
+
+// setLogLevel changes the agent's log level, or prints it when level is empty
+func setLogLevel(level string) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.SetLogLevel(ctx, &v1.SetLogLevelRequest{Level: level})
+	if err != nil {
+		return fmt.Errorf("log level failed: %w", err)
+	}
+
+	if level == "" {
+		fmt.Printf("Log level: %s\n", resp.Level)
+		return nil
+	}
+	fmt.Printf("✓ Log level changed from %s to %s\n", resp.Previous, resp.Level)
+	return nil
+}
+
+// listAnomalies prints the anomalies reported across the mesh
+func listAnomalies(subject, kind string, limit int32) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.Anomalies(ctx, &v1.AnomaliesRequest{Subject: subject, Kind: kind, Limit: limit})
+	if err != nil {
+		return fmt.Errorf("list anomalies failed: %w", err)
+	}
+
+	if len(resp.Anomalies) == 0 {
+		fmt.Println("No anomalies reported.")
+		return nil
+	}
+
+	fmt.Printf("Anomalies (%d, newest first):\n", len(resp.Anomalies))
+	for _, anomaly := range resp.Anomalies {
+		fmt.Printf("  [%s] %s %s (detected %s by %s)\n", anomaly.Kind, anomaly.Subject, anomaly.Predicate,
+			time.Unix(anomaly.DetectedAt, 0).Format(time.RFC3339), anomaly.ReportedBy)
+		switch anomaly.Kind {
+		case "flapping":
+			fmt.Printf("    Winner changed %d times in %ds between %s\n", anomaly.Changes, anomaly.WindowSeconds, strings.Join(anomaly.Values, ", "))
+		case "contradiction":
+			if anomaly.Winner != nil && anomaly.Rival != nil {
+				fmt.Printf("    Winner: %s from %s (%.2f)\n", anomaly.Winner.Object, anomaly.Winner.Source, anomaly.Winner.Confidence)
+				fmt.Printf("    Rival:  %s from %s (%.2f)\n", anomaly.Rival.Object, anomaly.Rival.Source, anomaly.Rival.Confidence)
+			}
+		}
+	}
+	return nil
+}
+
+// formatVersion prints a version vector as "agent:count" pairs in agent order.
+func formatVersion(version map[string]uint64) string {
+	agents := make([]string, 0, len(version))
+	for agent := range version {
+		agents = append(agents, agent)
+	}
+	sort.Strings(agents)
+
+	parts := make([]string, len(agents))
+	for i, agent := range agents {
+		parts[i] = fmt.Sprintf("%s:%d", agent, version[agent])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
+
+// printSchema prints a single schema
+func printSchema(schema *v1.PredicateSchema) {
+	fmt.Printf("  %s (version %d)\n", schema.Predicate, schema.Version)
+	if schema.Type != "" {
+		fmt.Printf("    Type: %s\n", schema.Type)
+	}
+	if len(schema.Enum) > 0 {
+		fmt.Printf("    Enum: %s\n", strings.Join(schema.Enum, ", "))
+	}
+	for alias, target := range schema.Aliases {
+		fmt.Printf("    Alias: %s -> %s\n", alias, target)
+	}
+	if schema.Min != nil {
+		fmt.Printf("    Min: %v\n", *schema.Min)
+	}
+	if schema.Max != nil {
+		fmt.Printf("    Max: %v\n", *schema.Max)
+	}
+	if schema.Cardinality != "" {
+		fmt.Printf("    Cardinality: %s\n", schema.Cardinality)
+	}
+	if schema.DefaultTtlSeconds > 0 {
+		fmt.Printf("    Default TTL: %ds\n", schema.DefaultTtlSeconds)
+	}
+	if schema.Resolver != "" {
+		fmt.Printf("    Resolver: %s\n", schema.Resolver)
+	}
+	if schema.Crdt != "" {
+		fmt.Printf("    CRDT: %s\n", schema.Crdt)
+	}
+}
+
+// parseValue converts a command line object into a typed value of the given type.
+func parseValue(valueType, object string) (*v1.Value, error) {
+	switch valueType {
+	case "", "string":
+		return &v1.Value{Kind: &v1.Value_StringValue{StringValue: object}}, nil
+	case "int":
+		i, err := strconv.ParseInt(object, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid int object %q: %w", object, err)
+		}
+		return &v1.Value{Kind: &v1.Value_IntValue{IntValue: i}}, nil
+	case "float":
+		f, err := strconv.ParseFloat(object, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid float object %q: %w", object, err)
+		}
+		return &v1.Value{Kind: &v1.Value_FloatValue{FloatValue: f}}, nil
+	case "bool":
+		b, err := strconv.ParseBool(object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bool object %q: %w", object, err)
+		}
+		return &v1.Value{Kind: &v1.Value_BoolValue{BoolValue: b}}, nil
+	case "bytes":
+		data, err := base64.StdEncoding.DecodeString(object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base64 object: %w", err)
+		}
+		return &v1.Value{Kind: &v1.Value_BytesValue{BytesValue: data}}, nil
+	case "timestamp":
+		t, err := time.Parse(time.RFC3339Nano, object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp object %q: %w", object, err)
+		}
+		return &v1.Value{Kind: &v1.Value_TimestampValue{TimestampValue: timestamppb.New(t)}}, nil
+	case "json":
+		if !json.Valid([]byte(object)) {
+			return nil, fmt.Errorf("invalid json object %q", object)
+		}
+		return &v1.Value{Kind: &v1.Value_JsonValue{JsonValue: object}}, nil
+	case "ref":
+		return &v1.Value{Kind: &v1.Value_RefValue{RefValue: object}}, nil
+	default:
+		return nil, fmt.Errorf("unknown object type %q", valueType)
+	}
+}
+
+// valueTypeName returns the display name of a typed value.
+func valueTypeName(value *v1.Value) string {
+	switch value.GetKind().(type) {
+	case *v1.Value_IntValue:
+		return "int"
+	case *v1.Value_FloatValue:
+		return "float"
+	case *v1.Value_BoolValue:
+		return "bool"
+	case *v1.Value_BytesValue:
+		return "bytes"
+	case *v1.Value_TimestampValue:
+		return "timestamp"
+	case *v1.Value_JsonValue:
+		return "json"
+	case *v1.Value_RefValue:
+		return "ref"
+	default:
+		return "string"
+	}
+}
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+)
+
+// probePredicate is the predicate of convergence probes.
+const probePredicate = "convergence_probe"
+
+// meshCmd creates the mesh subcommand
+func meshCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "mesh",
+		Short: "Inspect the mesh",
+	}
+
+	var (
+		wait     time.Duration
+		interval time.Duration
+		source   string
+		ttl      int64
+	)
+	convergenceCmd := &cobra.Command{
+		Use:   "convergence",
+		Short: "Measure how long every peer takes to reflect a new k-pak",
+		Long: `Write a probe k-pak to the agent and poll every peer that advertises its API
+until the probe shows up there. Latencies include the ingest and are only as fine
+as the poll interval.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return measureConvergence(wait, interval, source, ttl)
+		},
+	}
+	convergenceCmd.Flags().DurationVar(&wait, "wait", 30*time.Second, "How long to wait for every peer")
+	convergenceCmd.Flags().DurationVar(&interval, "interval", 10*time.Millisecond, "How often to poll each peer")
+	convergenceCmd.Flags().StringVar(&source, "source", "sutra-ctl", "Source of the probe k-pak")
+	convergenceCmd.Flags().Int64Var(&ttl, "ttl", 300, "Seconds until the probe expires (0 = never)")
+
+	cmd.AddCommand(convergenceCmd)
+
+	return cmd
+}
+
+// peerConvergence is how long one peer took to reflect the probe.
+type peerConvergence struct {
+	name    string
+	address string
+	latency time.Duration
+	err     error
+}
+
+// measureConvergence writes a probe k-pak and times its arrival on every peer
+func measureConvergence(wait, interval time.Duration, source string, ttl int64) error {
+	client, conn, err := connectToAgent()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	resp, err := client.GetPeers(ctx, &v1.PeersRequest{})
+	if err != nil {
+		return fmt.Errorf("peers request failed: %w", err)
+	}
+
+	now := time.Now()
+	probe := &v1.Kpak{
+		Subject:    fmt.Sprintf("sutra-probe:%d", now.UnixNano()),
+		Predicate:  probePredicate,
+		Object:     now.UTC().Format(time.RFC3339Nano),
+		Source:     source,
+		Confidence: 1,
+		Timestamp:  now.Unix(),
+	}
+	if ttl > 0 {
+		probe.ExpiresAt = now.Unix() + ttl
+	}
+
+	start := time.Now()
+	stream, err := client.Ingest(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start ingest stream: %w", err)
+	}
+	if err := stream.Send(probe); err != nil {
+		return fmt.Errorf("failed to send probe: %w", err)
+	}
+	ingest, err := stream.CloseAndRecv()
+	if err != nil {
+		return fmt.Errorf("failed to receive response: %w", err)
+	}
+	acked := time.Since(start)
+	if ingest.Accepted == 0 {
+		if ingest.Held > 0 {
+			return fmt.Errorf("probe was held for review; exempt source %q from review or pick another --source", source)
+		}
+		return fmt.Errorf("probe was rejected: %v", ingest.Errors)
+	}
+
+	results := make([]peerConvergence, len(resp.Peers))
+	var wg sync.WaitGroup
+	for i, peer := range resp.Peers {
+		results[i] = peerConvergence{name: peer.Name, address: peer.GrpcAddress}
+		if peer.GrpcAddress == "" {
+			results[i].err = fmt.Errorf("does not advertise its API address")
+			continue
+		}
+		if peer.State != 0 {
+			results[i].err = fmt.Errorf("is not alive")
+			continue
+		}
+		wg.Add(1)
+		go func(result *peerConvergence) {
+			defer wg.Done()
+			result.latency, result.err = awaitProbe(result.address, probe.Subject, start, wait, interval)
+		}(&results[i])
+	}
+	wg.Wait()
+
+	sort.Slice(results, func(i, j int) bool {
+		if (results[i].err == nil) != (results[j].err == nil) {
+			return results[i].err == nil
+		}
+		if results[i].latency != results[j].latency {
+			return results[i].latency < results[j].latency
+		}
+		return results[i].name < results[j].name
+	})
+
+	fmt.Printf("Convergence of %s written to %s (accepted in %v):\n", probe.Subject, agentAddr, acked.Round(time.Microsecond))
+	var slowest time.Duration
+	failed := 0
+	for _, result := range results {
+		if result.err != nil {
+			failed++
+			fmt.Printf("  %-32s %-22s %v\n", result.name, result.address, result.err)
+			continue
+		}
+		if result.latency > slowest {
+			slowest = result.latency
+		}
+		fmt.Printf("  %-32s %-22s %v\n", result.name, result.address, result.latency.Round(time.Microsecond))
+	}
+	fmt.Println()
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d peers did not reflect the probe", failed, len(results))
+	}
+	fmt.Printf("✓ Mesh converged in %v\n", slowest.Round(time.Microsecond))
+	return nil
+}
+
+// awaitProbe polls the agent at addr until it answers with the probe, and
+// returns how long after start that was
+func awaitProbe(addr, subject string, start time.Time, wait, interval time.Duration) (time.Duration, error) {
+	client, conn, err := connectTo(addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	deadline := start.Add(wait)
+	predicate := probePredicate
+	for {
+		found, err := hasProbe(client, subject, predicate)
+		if found {
+			return time.Since(start), nil
+		}
+		if time.Now().After(deadline) {
+			if err != nil {
+				return 0, fmt.Errorf("not reflected after %v: %w", wait, err)
+			}
+			return 0, fmt.Errorf("not reflected after %v", wait)
+		}
+		time.Sleep(interval)
+	}
+}
+
+// hasProbe reports whether the agent answers a query for the probe
+func hasProbe(client v1.SynapseServiceClient, subject, predicate string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := client.Query(ctx, &v1.QueryRequest{Subject: subject, Predicate: &predicate})
+	if err != nil {
+		return false, err
+	}
+	if _, err := stream.Recv(); err != nil {
+		if err == io.EOF {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
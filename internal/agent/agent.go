@@ -3,21 +3,50 @@ package agent
 import (
 	"context"
 	"fmt"
-	"log"
 	"net"
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/analyzer"
+	"github.com/Pew-X/sutra/internal/auth"
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
 	"github.com/Pew-X/sutra/internal/gossip"
+	"github.com/Pew-X/sutra/internal/health"
+	"github.com/Pew-X/sutra/internal/logging"
 	"github.com/Pew-X/sutra/internal/monitoring"
+	"github.com/Pew-X/sutra/internal/policy"
+	"github.com/Pew-X/sutra/internal/ratelimit"
 	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
 	"github.com/Pew-X/sutra/internal/store"
+	"github.com/Pew-X/sutra/internal/tracing"
 )
 
+// logger is the agent's logger.
+var logger = logging.For("agent")
+
+// tracerScope names the agent's spans.
+const tracerScope = "github.com/Pew-X/sutra/internal/agent"
+
+// processSampleInterval is how often CPU time is sampled for the usage average.
+const processSampleInterval = 5 * time.Second
+
 // Config holds the agent config.
 type Config struct {
 	Host       string   `yaml:"host"`
@@ -25,12 +54,53 @@ type Config struct {
 	GossipPort int      `yaml:"gossip_port"`
 	JoinPeers  []string `yaml:"join_peers"`
 	LogLevel   string   `yaml:"log_level"`
+	LogFormat  string   `yaml:"log_format"` // text (default) or json
 	WALPath    string   `yaml:"wal_path"`
 
 	// TTL and Garbage Collection settings
 	DefaultTTLSeconds int64 `yaml:"default_ttl_seconds"` // Default TTL for k-paks (0 = never expires)
 	GCIntervalSeconds int64 `yaml:"gc_interval_seconds"` // How often to run garbage collection
 	GCEnabled         bool  `yaml:"gc_enabled"`          // Whether to enable garbage collection
+
+	// Reconciliation settings
+	MaxCandidates      int                          `yaml:"max_candidates"`      // Ranked claims kept per subject+predicate for fallback (0 = engine default)
+	ReconciliationMode string                       `yaml:"reconciliation_mode"` // "highest_confidence" (default) or "fusion"
+	Decay              []reconciliation.DecayRule   `yaml:"decay"`               // Per-predicate confidence decay rules
+	Damping            []reconciliation.DampingRule `yaml:"damping"`             // Per-predicate hysteresis before a new value replaces the truth
+
+	// Predicate schemas enforced on ingest and gossip; peers may add newer versions
+	Schemas []schema.Schema `yaml:"schemas"`
+
+	// Flapping and contradiction detection; findings are published as k-paks
+	Analyzer analyzer.Config `yaml:"analyzer"`
+
+	// Rules that hold ingested claims for human review before reconciliation
+	Review review.Config `yaml:"review"`
+
+	// Which sources may assert which facts, and at what confidence (empty = any source, anything)
+	PolicyFile string `yaml:"policy_file"`
+
+	// Token-bucket limits and daily quotas on ingested k-paks
+	RateLimits ratelimit.Config `yaml:"rate_limits"`
+
+	// Bearer token or JWT authentication and roles for the gRPC API
+	Auth auth.Config `yaml:"auth"`
+
+	// Address of the HTTP listener serving Prometheus metrics on /metrics (empty = disabled)
+	MetricsAddr string `yaml:"metrics_addr"`
+
+	// Address of the HTTP/JSON gateway to the API, described by api/v1/synapse.openapi.yaml (empty = disabled)
+	GatewayAddr string `yaml:"gateway_addr"`
+
+	// Thresholds of the health checks behind Health and grpc.health.v1
+	Health health.Config `yaml:"health"`
+
+	// OTLP export of spans covering ingest, reconciliation, the WAL and gossip
+	Tracing tracing.Config `yaml:"tracing"`
+
+	// Readiness: how long to wait for the mesh after WAL replay, and how queries are answered until then
+	SyncTimeoutSeconds int64  `yaml:"sync_timeout_seconds"` // (0 = 30)
+	UnreadyQueries     string `yaml:"unready_queries"`      // "flag" (default), "refuse" or "serve"
 }
 
 // Agent is the main coordinator that manages all mesh components.
@@ -39,13 +109,33 @@ type Agent struct {
 
 	config    Config
 	engine    *reconciliation.Engine
+	schemas   *schema.Registry
 	wal       *store.WAL
 	gossip    *gossip.Manager
 	metrics   *monitoring.Metrics
 	gc        *GarbageCollector
+	analyzer  *analyzer.Analyzer // nil unless enabled
+	review    *review.Queue
+	policy    *policy.Policy // nil unless a policy file is configured
+	limiter   *ratelimit.Limiter
+	auth      *auth.Authenticator // nil unless enabled
 	server    *grpc.Server
+	grpcAddr  net.Addr     // Where server listens
+	http      *http.Server // nil unless MetricsAddr is set
+	gateway   *http.Server // nil unless GatewayAddr is set
+	health    *health.Registry
+	watchers  *watchers
 	startTime time.Time
 
+	grpcHealth *grpchealth.Server
+	stopHealth chan struct{}
+	lifecycle  lifecycle
+	stopSync   chan struct{}
+
+	stopTracing  func(context.Context) error // nil unless tracing is configured
+	gatewayConn  *grpc.ClientConn            // The gateway's connection to server
+	gatewayToken string                      // Sent by the gateway with every call (empty without one)
+
 	// State
 	mutex   sync.RWMutex
 	running bool
@@ -54,7 +144,83 @@ type Agent struct {
 // NewAgent creates a new Synapse agent.
 func NewAgent(config Config) (*Agent, error) {
 	// Initialize reconciliation engine
-	engine := reconciliation.NewEngine()
+	mode, err := reconciliation.ParseMode(config.ReconciliationMode)
+	if err != nil {
+		return nil, err
+	}
+	for _, rule := range config.Decay {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, rule := range config.Damping {
+		if err := rule.Validate(); err != nil {
+			return nil, err
+		}
+	}
+	engine := reconciliation.NewEngineWithConfig(reconciliation.Config{
+		MaxCandidates: config.MaxCandidates,
+		Mode:          mode,
+		Decay:         config.Decay,
+		Damping:       config.Damping,
+		Actor:         gossip.NodeName(config.Host, config.GossipPort),
+	})
+
+	// Initialize schema registry
+	schemas := schema.NewRegistry()
+	for i := range config.Schemas {
+		if _, err := schemas.Put(&config.Schemas[i]); err != nil {
+			return nil, err
+		}
+	}
+	engine.SetModeResolver(schemas.Resolver)
+	engine.SetMultiValuedResolver(schemas.MultiValued)
+	engine.SetCRDTResolver(schemas.CRDT)
+
+	// Initialize review queue
+	queue, err := review.NewQueue(config.Review)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize ingest rate limits
+	limiter, err := ratelimit.New(config.RateLimits)
+	if err != nil {
+		return nil, err
+	}
+
+	// Initialize API authentication
+	var authenticator *auth.Authenticator
+	if config.Auth.Enabled {
+		if authenticator, err = auth.New(config.Auth, requiredRole); err != nil {
+			return nil, err
+		}
+	}
+
+	// Check health thresholds
+	if err := config.Health.Validate(); err != nil {
+		return nil, err
+	}
+	if err := validateUnreadyQueries(config.UnreadyQueries); err != nil {
+		return nil, err
+	}
+	if err := config.Tracing.Validate(); err != nil {
+		return nil, err
+	}
+	if _, err := logging.ParseLevel(config.LogLevel); err != nil {
+		return nil, err
+	}
+	if err := logging.ValidateFormat(config.LogFormat); err != nil {
+		return nil, err
+	}
+
+	// Load source authorization policy
+	var sourcePolicy *policy.Policy
+	if config.PolicyFile != "" {
+		if sourcePolicy, err = policy.Load(config.PolicyFile); err != nil {
+			return nil, err
+		}
+	}
 
 	// Initialize WAL
 	wal, err := store.NewWAL(config.WALPath)
@@ -80,28 +246,100 @@ func NewAgent(config Config) (*Agent, error) {
 
 	// Initialize garbage collector
 	gc := NewGarbageCollector(engine, config.GCIntervalSeconds, config.GCEnabled)
+	gc.onCollect = metrics.RecordGC
+
+	// Time every WAL write and count gossip traffic
+	wal.SetObserver(metrics.ObserveWAL)
+	gossipManager.SetMessageObserver(metrics.RecordGossip)
 
 	agent := &Agent{
 		config:    config,
 		engine:    engine,
+		schemas:   schemas,
 		wal:       wal,
 		gossip:    gossipManager,
 		metrics:   metrics,
 		gc:        gc,
+		review:    queue,
+		policy:    sourcePolicy,
+		limiter:   limiter,
+		auth:      authenticator,
+		health:    health.NewRegistry(),
+		watchers:  newWatchers(),
 		startTime: time.Now(),
+
+		grpcHealth: newGRPCHealthServer(),
+		lifecycle:  lifecycle{state: StateStarting, since: time.Now()},
 	}
+	agent.registerHealthChecks(config.Health.WithDefaults())
 
-	// Set up gossip callback for handling received k-paks
-	gossipManager.SetKpakHandler(func(kpak *core.Kpak) bool {
-		accepted := agent.engine.Reconcile(kpak)
-		if accepted {
-			// Persist to WAL
-			if err := agent.wal.Append(kpak); err != nil {
-				log.Printf("Warning: failed to persist gossiped k-pak to WAL: %v", err)
+	// Initialize anomaly analyzer
+	if config.Analyzer.Enabled {
+		agent.analyzer = analyzer.New(config.Analyzer, engine, agent.publishFinding)
+	}
+
+	// Set up gossip callbacks for handling received k-paks and sharing them with joining peers
+	gossipManager.SetKpakHandler(agent.handleGossipKpak)
+	gossipManager.SetKpakSource(engine.GetAllCandidates)
+
+	// Set up gossip callback for handling received retractions
+	gossipManager.SetRetractHandler(func(retraction *gossip.Retraction) {
+		if agent.applyRetraction(retraction) == 0 {
+			return
+		}
+		if err := agent.wal.AppendEntry(retractionEntry(retraction)); err != nil {
+			logger.Warn("Failed to persist gossiped retraction to WAL", logging.KeySPID, logging.SPID(retraction.Subject, retraction.Predicate), logging.KeySource, retraction.Source, logging.KeyError, err)
+		}
+	})
+
+	// Set up gossip callbacks for sharing schemas
+	gossipManager.SetSchemaHandler(func(s *schema.Schema) {
+		if _, err := agent.putSchema(s); err != nil {
+			logger.Warn("Ignored invalid schema from gossip", "predicate", s.Predicate, logging.KeyError, err)
+		}
+	})
+	gossipManager.SetSchemaSource(schemas.List)
+
+	// Set up gossip callbacks for merging CRDT states
+	gossipManager.SetCRDTHandler(func(state *crdt.State) {
+		before := agent.engine.GetCRDT(state.Subject, state.Predicate)
+		changed, err := agent.engine.MergeCRDT(state)
+		if err != nil {
+			logger.Warn("Failed to merge CRDT state", logging.KeySPID, logging.SPID(state.Subject, state.Predicate), logging.KeyError, err)
+			return
+		}
+		if changed {
+			agent.persistCRDT(state.Subject, state.Predicate, before)
+		}
+	})
+	gossipManager.SetCRDTSource(engine.GetAllCRDTs)
+
+	// Set up gossip callbacks for sharing operator pins
+	gossipManager.SetPinHandler(func(pin *reconciliation.Pin) {
+		applied, err := agent.engine.SetPin(pin)
+		if err != nil {
+			logger.Warn("Ignored invalid pin from gossip", logging.KeyError, err)
+			return
+		}
+		if applied {
+			if err := agent.wal.AppendEntry(&store.Entry{Type: store.EntryPin, Pin: pin, Timestamp: time.Now().Unix()}); err != nil {
+				logger.Warn("Failed to persist gossiped pin to WAL", logging.KeyError, err)
 			}
 		}
-		agent.metrics.RecordIngest(kpak.Source, accepted)
-		return accepted
+	})
+	gossipManager.SetPinSource(engine.GetAllPins)
+
+	// Set up gossip callbacks for sharing the review queue
+	gossipManager.SetReviewHandler(func(item *review.Item) {
+		if !agent.review.Merge(item) {
+			return
+		}
+		if err := agent.wal.AppendEntry(reviewEntry(item)); err != nil {
+			logger.Warn("Failed to persist gossiped review item to WAL", logging.KeyError, err)
+		}
+	})
+	gossipManager.SetReviewSource(func() []*review.Item {
+		return queue.List("")
 	})
 
 	return agent, nil
@@ -116,18 +354,42 @@ func (a *Agent) Start() error {
 		return fmt.Errorf("agent is already running")
 	}
 
-	log.Printf("Starting Synapse agent...")
+	logger.Info("Starting Synapse agent")
+
+	// Export spans before anything produces them
+	if a.config.Tracing.Endpoint != "" && a.stopTracing == nil {
+		stop, err := tracing.Setup(a.config.Tracing, gossip.NodeName(a.config.Host, a.config.GossipPort))
+		if err != nil {
+			return err
+		}
+		a.stopTracing = stop
+		logger.Info("Exporting traces", "endpoint", a.config.Tracing.Endpoint)
+	}
 
 	// Load existing knowledge from WAL
+	a.setState(StateReplaying)
 	if err := a.loadFromWAL(); err != nil {
 		return fmt.Errorf("failed to load from WAL: %w", err)
 	}
 
-	// Start gRPC server
+	// Persist and share promotions from here on; replayed state needs neither
+	a.engine.SetChangeHandler(a.handleTruthChange)
+
+	// Start gRPC server; queries are gated until the agent is ready
 	if err := a.startGRPCServer(); err != nil {
 		return fmt.Errorf("failed to start gRPC server: %w", err)
 	}
 
+	// Start metrics exporter
+	if err := a.startMetricsServer(); err != nil {
+		return fmt.Errorf("failed to start metrics server: %w", err)
+	}
+
+	// Start HTTP/JSON gateway
+	if err := a.startGateway(); err != nil {
+		return fmt.Errorf("failed to start HTTP gateway: %w", err)
+	}
+
 	// Start gossip manager
 	if err := a.gossip.Start(); err != nil {
 		return fmt.Errorf("failed to start gossip manager: %w", err)
@@ -136,33 +398,258 @@ func (a *Agent) Start() error {
 	// Start garbage collector
 	a.gc.Start()
 
+	// Keep the CPU usage window filled between metrics requests
+	a.metrics.Process().Start(processSampleInterval)
+
+	// Report health to load balancers and probes
+	a.stopHealth = make(chan struct{})
+	go a.watchHealth(a.stopHealth)
+
+	// Ready once in touch with the mesh; the first agent of a mesh has no one to wait for
+	a.stopSync = make(chan struct{})
+	if len(a.config.JoinPeers) == 0 || a.gossip.Synced() {
+		a.setState(StateReady)
+	} else {
+		a.setState(StateSyncing)
+		go a.awaitSync(a.stopSync)
+	}
+
 	a.running = true
-	log.Printf("Sutra agent started successfully on %s:%d", a.config.Host, a.config.GRPCPort)
-	log.Printf("Gossip network active on %s:%d", a.config.Host, a.config.GossipPort)
+	logger.Info("Sutra agent started", "grpc", fmt.Sprintf("%s:%d", a.config.Host, a.config.GRPCPort), "gossip", fmt.Sprintf("%s:%d", a.config.Host, a.config.GossipPort))
 
 	return nil
 }
 
 // loadFromWAL restores the agent's state from the Write-Ahead Log.
 func (a *Agent) loadFromWAL() error {
-	log.Printf("Loading knowledge from WAL: %s", a.config.WALPath)
+	logger.Info("Loading knowledge from WAL", "path", a.config.WALPath)
 
-	kpaks, err := a.wal.Load()
+	entries, err := a.wal.LoadEntries()
 	if err != nil {
 		return err
 	}
 
+	loaded := 0
 	accepted := 0
-	for _, kpak := range kpaks {
-		if a.engine.Reconcile(kpak) {
-			accepted++
+	for _, entry := range entries {
+		switch entry.Type {
+		case store.EntryKpak, store.EntryPromote:
+			loaded++
+			a.review.Learn(entry.Kpak.Source)
+			if a.engine.Reconcile(entry.Kpak) {
+				accepted++
+			}
+		case store.EntryRetract:
+			if entry.ObjectKey != "" {
+				a.engine.RetractMember(entry.Subject, entry.Predicate, entry.Source, entry.ObjectKey, entry.Timestamp)
+			} else {
+				a.engine.Retract(entry.Subject, entry.Predicate, entry.Source, entry.Timestamp)
+			}
+		case store.EntryCRDT:
+			if _, err := a.engine.MergeCRDT(entry.CRDT); err != nil {
+				logger.Warn("Skipped invalid CRDT state in WAL", logging.KeyError, err)
+			}
+		case store.EntrySchema:
+			if _, err := a.storeSchema(entry.Schema); err != nil {
+				logger.Warn("Skipped invalid schema in WAL", logging.KeyError, err)
+			}
+		case store.EntryPin:
+			if _, err := a.engine.SetPin(entry.Pin); err != nil {
+				logger.Warn("Skipped invalid pin in WAL", logging.KeyError, err)
+			}
+		case store.EntryReview:
+			a.review.Merge(entry.Review)
 		}
 	}
 
-	log.Printf("Loaded %d k-paks from WAL, %d accepted as current truth", len(kpaks), accepted)
+	logger.Info("Loaded k-paks from WAL", "loaded", loaded, "accepted", accepted)
 	return nil
 }
 
+// handleGossipKpak reconciles a k-pak received from a peer and reports whether it
+// was accepted. Peers are held to the same schemas and source policy as clients.
+// ctx carries the sending agent's trace.
+func (a *Agent) handleGossipKpak(ctx context.Context, kpak *core.Kpak) bool {
+	// Claims already held say nothing about how fast the mesh is
+	origin, stamped := gossip.OriginFromContext(ctx)
+	if a.engine.Known(kpak) {
+		if stamped {
+			a.metrics.RecordGossipDuplicate(origin.Node)
+		}
+		return false
+	}
+	if stamped {
+		a.metrics.ObservePropagation(origin.Node, time.Since(origin.At))
+	}
+
+	// CRDT updates travel as merged state; applying one again here would count it twice
+	if a.schemas.CRDT(kpak.Predicate) != "" {
+		logger.With(logging.KpakFields(kpak)...).Warn("Ignored gossiped k-pak for a CRDT predicate")
+		return false
+	}
+
+	if err := a.schemas.Validate(kpak); err != nil {
+		logger.With(logging.KpakFields(kpak)...).Warn("Rejected gossiped k-pak", logging.KeyError, err)
+		a.metrics.RecordIngest(kpak.Source, false)
+		a.metrics.RecordIngestError()
+		return false
+	}
+	if err := a.authorize(kpak); err != nil {
+		logger.With(logging.KpakFields(kpak)...).Warn("Rejected gossiped k-pak", logging.KeyError, err)
+		a.metrics.RecordIngest(kpak.Source, false)
+		a.metrics.RecordIngestError()
+		return false
+	}
+
+	// The peer that took the claim in already admitted it
+	a.review.Learn(kpak.Source)
+
+	outcome := a.reconcile(ctx, kpak)
+	persisted := true
+	if outcome != reconciliation.OutcomeRejected {
+		// Persist to WAL, runner-ups included so they survive a restart
+		if err := a.wal.AppendContext(ctx, kpak); err != nil {
+			logger.With(logging.KpakFields(kpak)...).Warn("Failed to persist gossiped k-pak to WAL", logging.KeyError, err)
+			a.metrics.RecordIngestError()
+			persisted = false
+		}
+	}
+	if outcome == reconciliation.OutcomeCandidate {
+		a.observeClaim(kpak)
+	}
+	accepted := outcome == reconciliation.OutcomeAccepted
+	a.metrics.RecordIngest(kpak.Source, accepted && persisted)
+	return accepted
+}
+
+// reconcile runs a claim through the engine, traced under ctx, and counts the decision.
+func (a *Agent) reconcile(ctx context.Context, kpak *core.Kpak) reconciliation.Outcome {
+	outcome := a.engine.ReconcileContext(ctx, kpak)
+	a.metrics.RecordReconcile(outcome.String())
+	return outcome
+}
+
+// handleTruthChange persists runner-up promotions and shares them with the mesh,
+// so every agent records the same fallback even if it missed the original claim.
+func (a *Agent) handleTruthChange(change reconciliation.TruthChange) {
+	a.publishChange(change)
+	if a.analyzer != nil {
+		a.analyzer.ObserveChange(change)
+	}
+	if change.Type != reconciliation.ChangePromoted {
+		return
+	}
+
+	entry := &store.Entry{
+		Type:      store.EntryPromote,
+		Kpak:      change.Current,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := a.wal.AppendEntry(entry); err != nil {
+		logger.With(logging.KpakFields(change.Current)...).Warn("Failed to persist promotion to WAL", logging.KeyError, err)
+	}
+
+	if err := a.gossip.BroadcastKpak(change.Current); err != nil {
+		logger.With(logging.KpakFields(change.Current)...).Warn("Failed to broadcast promoted k-pak to mesh", logging.KeyError, err)
+	}
+}
+
+// observeClaim lets the analyzer check a claim kept as a runner-up.
+func (a *Agent) observeClaim(kpak *core.Kpak) {
+	if a.analyzer != nil {
+		a.analyzer.ObserveClaim(kpak)
+	}
+}
+
+// publishFinding stores an analyzer finding as a k-pak and shares it with the mesh.
+func (a *Agent) publishFinding(finding *analyzer.Finding) {
+	logger.Info("Anomaly detected", "kind", finding.Kind, logging.KeySPID, logging.SPID(finding.Subject, finding.Predicate), "subject", finding.Subject, "predicate", finding.Predicate, "values", finding.Values)
+
+	source := "analyzer@" + gossip.NodeName(a.config.Host, a.config.GossipPort)
+	kpak, err := finding.Kpak(source, a.analyzer.Config().FindingTTLSeconds)
+	if err != nil {
+		logger.Warn("Failed to publish finding", logging.KeyError, err)
+		return
+	}
+	a.gossip.StampOrigin(kpak)
+	if a.reconcile(context.Background(), kpak) == reconciliation.OutcomeRejected {
+		return
+	}
+
+	if err := a.wal.Append(kpak); err != nil {
+		logger.With(logging.KpakFields(kpak)...).Warn("Failed to persist finding to WAL", logging.KeyError, err)
+	}
+	if err := a.gossip.BroadcastKpak(kpak); err != nil {
+		logger.With(logging.KpakFields(kpak)...).Warn("Failed to broadcast finding to mesh", logging.KeyError, err)
+	}
+}
+
+// putSchema stores a schema if it is newer than the one in force and persists it.
+func (a *Agent) putSchema(s *schema.Schema) (bool, error) {
+	stored, err := a.storeSchema(s)
+	if err != nil || !stored {
+		return false, err
+	}
+
+	entry := &store.Entry{
+		Type:      store.EntrySchema,
+		Schema:    s,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := a.wal.AppendEntry(entry); err != nil {
+		logger.Warn("Failed to persist schema to WAL", logging.KeyError, err)
+	}
+	return true, nil
+}
+
+// storeSchema stores a schema if it is newer than the one in force. When the
+// predicate switches between single- and multi-valued, its claims move to the
+// keys the new cardinality uses.
+func (a *Agent) storeSchema(s *schema.Schema) (bool, error) {
+	multiValued := a.schemas.MultiValued(s.Predicate)
+	stored, err := a.schemas.Put(s)
+	if err != nil || !stored {
+		return false, err
+	}
+	if a.schemas.MultiValued(s.Predicate) != multiValued {
+		a.engine.Rekey(s.Predicate)
+	}
+	return true, nil
+}
+
+// persistCRDT writes what changed in the state of a CRDT predicate since before
+// to the WAL and returns that change. Replay merges the changes back together.
+func (a *Agent) persistCRDT(subject, predicate string, before *crdt.State) *crdt.State {
+	state := a.engine.GetCRDT(subject, predicate)
+	if state == nil {
+		return nil
+	}
+
+	delta := state.Diff(before)
+	entry := &store.Entry{
+		Type:      store.EntryCRDT,
+		CRDT:      delta,
+		Timestamp: time.Now().Unix(),
+	}
+	if err := a.wal.AppendEntry(entry); err != nil {
+		logger.Warn("Failed to persist CRDT state to WAL", logging.KeySPID, logging.SPID(subject, predicate), logging.KeyError, err)
+	}
+	return delta
+}
+
+// shareCRDT persists the change to a CRDT predicate and sends it to the mesh. The
+// state delta is shared rather than the update, so peers merge it without double
+// counting; peers that miss it catch up on the full state at the next push/pull.
+func (a *Agent) shareCRDT(subject, predicate string, before *crdt.State) {
+	delta := a.persistCRDT(subject, predicate, before)
+	if delta == nil {
+		return
+	}
+	if err := a.gossip.BroadcastCRDT(delta); err != nil {
+		logger.Warn("Failed to broadcast CRDT state to mesh", logging.KeySPID, logging.SPID(subject, predicate), logging.KeyError, err)
+	}
+}
+
 // startGRPCServer initializes and starts the gRPC server.
 func (a *Agent) startGRPCServer() error {
 	listen, err := net.Listen("tcp", fmt.Sprintf("%s:%d", a.config.Host, a.config.GRPCPort))
@@ -170,18 +657,101 @@ func (a *Agent) startGRPCServer() error {
 		return err
 	}
 
-	a.server = grpc.NewServer()
+	a.grpcAddr = listen.Addr()
+	a.gossip.AdvertiseGRPCPort(listen.Addr().(*net.TCPAddr).Port)
+	a.server = grpc.NewServer(a.serverOptions()...)
 	v1.RegisterSynapseServiceServer(a.server, a)
+	healthpb.RegisterHealthServer(a.server, a.grpcHealth)
 
 	go func() {
 		if err := a.server.Serve(listen); err != nil {
-			log.Printf("gRPC server error: %v", err)
+			logger.Error("gRPC server failed", logging.KeyError, err)
 		}
 	}()
 
 	return nil
 }
 
+// startMetricsServer serves Prometheus metrics over HTTP when an address is configured.
+func (a *Agent) startMetricsServer() error {
+	if a.config.MetricsAddr == "" {
+		return nil
+	}
+
+	listen, err := net.Listen("tcp", a.config.MetricsAddr)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", monitoring.Handler(a.metrics, a.gauges))
+	mux.HandleFunc("/readyz", a.serveReadiness)
+	a.http = &http.Server{Addr: listen.Addr().String(), Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := a.http.Serve(listen); err != nil && err != http.ErrServerClosed {
+			logger.Error("Metrics server failed", logging.KeyError, err)
+		}
+	}()
+
+	logger.Info("Prometheus metrics available", "url", "http://"+a.http.Addr+"/metrics")
+	return nil
+}
+
+// gauges reads the current size of the engine, mesh and review queue.
+func (a *Agent) gauges() monitoring.Gauges {
+	stats := a.engine.GetStats()
+	return monitoring.Gauges{
+		Kpaks:          stats["total_kpaks"].(int),
+		Subjects:       stats["total_subjects"].(int),
+		Candidates:     stats["total_candidates"].(int),
+		Conflicts:      stats["total_conflicts"].(int),
+		Peers:          len(a.gossip.GetMembers()),
+		PendingReviews: len(a.review.List(review.StatusPending)),
+	}
+}
+
+// serverOptions returns the gRPC server options, interceptors included.
+func (a *Agent) serverOptions() []grpc.ServerOption {
+	var options []grpc.ServerOption
+	if a.auth != nil {
+		options = append(options,
+			grpc.ChainUnaryInterceptor(a.auth.UnaryInterceptor()),
+			grpc.ChainStreamInterceptor(a.auth.StreamInterceptor()),
+		)
+	}
+	return append(options,
+		grpc.ChainUnaryInterceptor(a.readinessUnaryInterceptor()),
+		grpc.ChainStreamInterceptor(a.readinessStreamInterceptor()),
+	)
+}
+
+// requiredRole returns the role a client needs to call an RPC. Methods not listed
+// here are administrative.
+func requiredRole(method string) auth.Role {
+	// Load balancers and probes check health without credentials
+	if strings.HasPrefix(method, "/"+healthpb.Health_ServiceDesc.ServiceName+"/") {
+		return ""
+	}
+
+	switch method {
+	case v1.SynapseService_Query_FullMethodName,
+		v1.SynapseService_Health_FullMethodName,
+		v1.SynapseService_GetPeers_FullMethodName,
+		v1.SynapseService_GetMetrics_FullMethodName,
+		v1.SynapseService_ListSchemas_FullMethodName,
+		v1.SynapseService_Conflicts_FullMethodName,
+		v1.SynapseService_Anomalies_FullMethodName,
+		v1.SynapseService_ListReview_FullMethodName,
+		v1.SynapseService_Watch_FullMethodName:
+		return auth.RoleReader
+	case v1.SynapseService_Ingest_FullMethodName,
+		v1.SynapseService_Retract_FullMethodName:
+		return auth.RoleWriter
+	}
+	return auth.RoleAdmin
+}
+
 // gracefully shuts down the agent.
 func (a *Agent) Shutdown() error {
 	a.mutex.Lock()
@@ -191,30 +761,60 @@ func (a *Agent) Shutdown() error {
 		return nil
 	}
 
-	log.Printf("Shutting down Synapse agent...")
+	logger.Info("Shutting down Synapse agent")
+	a.setState(StateDraining)
+	close(a.stopSync)
 
 	// Stop garbage collector
 	if a.gc != nil {
 		a.gc.Stop()
 	}
+	a.metrics.Process().Stop()
+
+	// Stop reporting health; probes see the agent go away
+	if a.stopHealth != nil {
+		close(a.stopHealth)
+		a.stopHealth = nil
+	}
+	a.grpcHealth.Shutdown()
 
 	// Stop gossip manager
 	if a.gossip != nil {
 		a.gossip.Stop()
 	}
 
-	// Stop gRPC server
+	// End watch streams, which would hold the servers open, then stop the
+	// HTTP gateway and the gRPC server it calls
+	a.watchers.close()
+	a.stopGateway()
 	if a.server != nil {
 		a.server.GracefulStop()
 	}
 
+	// Stop metrics exporter
+	if a.http != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		a.http.Shutdown(ctx)
+		cancel()
+	}
+
 	// Close WAL
 	if a.wal != nil {
 		a.wal.Close()
 	}
 
+	// Flush the last spans
+	if a.stopTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := a.stopTracing(ctx); err != nil {
+			logger.Warn("Failed to flush traces", logging.KeyError, err)
+		}
+		cancel()
+		a.stopTracing = nil
+	}
+
 	a.running = false
-	log.Printf("Sutra agent shut down")
+	logger.Info("Sutra agent shut down")
 
 	return nil
 }
@@ -225,7 +825,20 @@ func (a *Agent) Shutdown() error {
 func (a *Agent) Ingest(stream v1.SynapseService_IngestServer) error {
 	accepted := int32(0)
 	rejected := int32(0)
+	held := int32(0)
 	var errors []string
+	client := a.clientID(stream.Context())
+	caller := auth.FromContext(stream.Context())
+
+	ctx, span := tracing.Start(stream.Context(), tracerScope, "Agent.Ingest", attribute.String("client", client))
+	defer func() {
+		span.SetAttributes(
+			attribute.Int("ingest.accepted", int(accepted)),
+			attribute.Int("ingest.rejected", int(rejected)),
+			attribute.Int("ingest.held", int(held)),
+		)
+		span.End()
+	}()
 
 	for {
 		protoKpak, err := stream.Recv()
@@ -234,58 +847,287 @@ func (a *Agent) Ingest(stream v1.SynapseService_IngestServer) error {
 			break
 		}
 
+		// Writers may only speak for the sources their credentials are bound to
+		if caller != nil && !caller.MayWriteAs(protoKpak.Source) {
+			errors = append(errors, fmt.Sprintf("%q may not write as source %q", caller.Name, protoKpak.Source))
+			rejected++
+			a.metrics.RecordIngest(protoKpak.Source, false)
+			a.metrics.RecordIngestError()
+			continue
+		}
+
+		// Runaway sources and clients are slowed down or cut off before any work is done
+		if err := a.limitRate(ctx, client, protoKpak.Source); err != nil {
+			return status.Errorf(status.Code(err), "%s (%d k-paks accepted earlier in this stream)", status.Convert(err).Message(), accepted)
+		}
+
+		// Reject values that cannot be decoded before they reach reconciliation
+		if _, err := objectFromProto(protoKpak); err != nil {
+			errors = append(errors, fmt.Sprintf("invalid value for %s %s: %v", protoKpak.Subject, protoKpak.Predicate, err))
+			rejected++
+			a.metrics.RecordIngest(protoKpak.Source, false)
+			a.metrics.RecordIngestError()
+			continue
+		}
+
+		// Findings are only published by analyzers
+		if analyzer.IsReserved(protoKpak.Subject) {
+			errors = append(errors, fmt.Sprintf("subject %q is reserved for anomaly findings", protoKpak.Subject))
+			rejected++
+			a.metrics.RecordIngest(protoKpak.Source, false)
+			a.metrics.RecordIngestError()
+			continue
+		}
+
 		// Convert proto k-pak to internal k-pak
 		kpak := a.protoToKpak(protoKpak)
+		a.gossip.StampOrigin(kpak)
+
+		// Enforce the predicate's schema, normalizing the object to its canonical form
+		if err := a.schemas.Validate(kpak); err != nil {
+			errors = append(errors, fmt.Sprintf("schema violation for %s: %v", kpak.Subject, err))
+			rejected++
+			a.metrics.RecordIngest(kpak.Source, false)
+			a.metrics.RecordIngestError()
+			continue
+		}
 
-		// Try to reconcile
-		if a.engine.Reconcile(kpak) {
-			// Accepted - persist to WAL
-			if err := a.wal.Append(kpak); err != nil {
-				errors = append(errors, fmt.Sprintf("failed to persist k-pak: %v", err))
+		// Sources may only assert what the policy grants them
+		if err := a.authorize(kpak); err != nil {
+			errors = append(errors, fmt.Sprintf("policy violation: %v", err))
+			rejected++
+			a.metrics.RecordIngest(kpak.Source, false)
+			a.metrics.RecordIngestError()
+			continue
+		}
+
+		// Claims matching a review rule wait for a human decision
+		if reasons := a.review.Screen(kpak); len(reasons) > 0 {
+			a.metrics.RecordIngest(kpak.Source, false)
+			if err := a.holdForReview(kpak, reasons); err != nil {
+				errors = append(errors, err.Error())
 				rejected++
-				a.metrics.RecordIngest(kpak.Source, false)
 			} else {
-				accepted++
-				a.metrics.RecordIngest(kpak.Source, true)
-
-				// Broadcast to gossip mesh
-				if err := a.gossip.BroadcastKpak(kpak); err != nil {
-					log.Printf("Warning: failed to broadcast k-pak to mesh: %v", err)
-				}
+				held++
 			}
+			continue
+		}
+
+		// Sources over their daily quota are cut off
+		if err := a.limiter.CheckQuota(kpak.Source); err != nil {
+			a.metrics.RecordQuotaExceeded(kpak.Source)
+			a.metrics.RecordIngest(kpak.Source, false)
+			a.metrics.RecordIngestError()
+			return status.Errorf(codes.ResourceExhausted, "%v (%d k-paks accepted earlier in this stream)", err, accepted)
+		}
+
+		ok, err := a.applyClaim(ctx, kpak)
+		if err != nil {
+			errors = append(errors, err.Error())
+		}
+		if ok {
+			accepted++
+			a.limiter.CountAccepted(kpak.Source)
 		} else {
 			rejected++
-			a.metrics.RecordIngest(kpak.Source, false)
 		}
+		a.metrics.RecordIngest(kpak.Source, ok)
 	}
 
 	return stream.SendAndClose(&v1.IngestResponse{
 		Accepted: accepted,
 		Rejected: rejected,
+		Held:     held,
 		Errors:   errors,
 	})
 }
 
+// applyClaim reconciles an admitted claim, persisting and sharing it, and reports
+// whether it became the truth.
+func (a *Agent) applyClaim(ctx context.Context, kpak *core.Kpak) (bool, error) {
+	ctx, span := tracing.Start(ctx, tracerScope, "Agent.applyClaim", tracing.KpakAttributes(kpak)...)
+	defer span.End()
+
+	a.review.Learn(kpak.Source)
+
+	// CRDT updates are merged into the replicated state, which is what gets persisted and shared
+	if a.schemas.CRDT(kpak.Predicate) != "" {
+		before := a.engine.GetCRDT(kpak.Subject, kpak.Predicate)
+		if a.reconcile(ctx, kpak) != reconciliation.OutcomeAccepted {
+			return false, fmt.Errorf("update for %s %s was invalid or changed nothing", kpak.Subject, kpak.Predicate)
+		}
+		a.shareCRDT(kpak.Subject, kpak.Predicate, before)
+		return true, nil
+	}
+
+	switch a.reconcile(ctx, kpak) {
+	case reconciliation.OutcomeAccepted:
+		// Accepted - persist to WAL
+		if err := a.wal.AppendContext(ctx, kpak); err != nil {
+			a.metrics.RecordIngestError()
+			return false, fmt.Errorf("failed to persist k-pak: %w", err)
+		}
+
+		// Broadcast to gossip mesh
+		if err := a.gossip.BroadcastKpakContext(ctx, kpak); err != nil {
+			logger.With(logging.KpakFields(kpak)...).Warn("Failed to broadcast k-pak to mesh", logging.KeyError, err)
+		}
+		return true, nil
+	case reconciliation.OutcomeCandidate:
+		// Kept as a runner-up - persist and share so the whole mesh can fall back to it
+		a.observeClaim(kpak)
+		if err := a.wal.AppendContext(ctx, kpak); err != nil {
+			a.metrics.RecordIngestError()
+			return false, fmt.Errorf("failed to persist k-pak: %w", err)
+		}
+		if err := a.gossip.BroadcastKpakContext(ctx, kpak); err != nil {
+			logger.With(logging.KpakFields(kpak)...).Warn("Failed to broadcast k-pak to mesh", logging.KeyError, err)
+		}
+	}
+	return false, nil
+}
+
+// limitRate takes a rate-limit token for a claim. In throttle mode it waits for
+// the token; otherwise, or if the stream ends first, it returns a gRPC status error.
+func (a *Agent) limitRate(ctx context.Context, client, source string) error {
+	for recorded := false; ; recorded = true {
+		err := a.limiter.Allow(client, source)
+		limitErr, limited := err.(*ratelimit.Error)
+		if !limited {
+			return err
+		}
+		if !recorded {
+			a.metrics.RecordThrottled(source)
+		}
+		if a.limiter.Mode() != ratelimit.ModeThrottle {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+
+		timer := time.NewTimer(limitErr.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return status.FromContextError(ctx.Err()).Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// clientID identifies the client on the other end of a stream: its authenticated
+// name, or else its address, as forwarded by the gateway for HTTP clients.
+func (a *Agent) clientID(ctx context.Context) string {
+	if caller := auth.FromContext(ctx); caller != nil {
+		return caller.Name
+	}
+	if forwarded, ok := a.forwardedClient(ctx); ok {
+		return forwarded
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return "unknown"
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
+
+// authorize enforces the source policy on a claim, capping its confidence in
+// place. Analyzer findings are published by agents, not sources, and are exempt.
+func (a *Agent) authorize(kpak *core.Kpak) error {
+	if a.policy == nil || analyzer.IsReserved(kpak.Subject) {
+		return nil
+	}
+
+	capped, err := a.policy.Authorize(kpak)
+	if err != nil {
+		a.metrics.RecordPolicyViolation(kpak.Source)
+		return err
+	}
+	if capped {
+		a.metrics.RecordConfidenceCapped()
+	}
+	return nil
+}
+
+// holdForReview queues a claim for review and shares it with the mesh, so it can
+// be decided on any agent.
+func (a *Agent) holdForReview(kpak *core.Kpak, reasons []string) error {
+	item, held, err := a.review.Hold(kpak, reasons, gossip.NodeName(a.config.Host, a.config.GossipPort))
+	if err != nil {
+		return err
+	}
+	if !held {
+		if item.Status == review.StatusRejected {
+			return fmt.Errorf("claim %s was rejected in review by %s", item.ID, item.Reviewer)
+		}
+		return nil
+	}
+
+	if err := a.wal.AppendEntry(reviewEntry(item)); err != nil {
+		return fmt.Errorf("failed to persist held claim: %w", err)
+	}
+	if err := a.gossip.BroadcastReview(item); err != nil {
+		logger.With(logging.KpakFields(item.Kpak)...).Warn("Failed to broadcast held claim to mesh", logging.KeyError, err)
+	}
+	return nil
+}
+
 // query handles k-pak queries.
 func (a *Agent) Query(req *v1.QueryRequest, stream v1.SynapseService_QueryServer) error {
 	a.metrics.RecordQuery()
+	defer func(start time.Time) {
+		a.metrics.ObserveQueryLatency(time.Since(start))
+	}(time.Now())
 
 	var kpaks []*core.Kpak
 
 	if req.Predicate != nil && *req.Predicate != "" {
-		// Specific subject+predicate query
-		kpak := a.engine.QueryBySubjectPredicate(req.Subject, *req.Predicate)
-		if kpak != nil {
-			kpaks = []*core.Kpak{kpak}
-		}
+		// Specific subject+predicate query; multi-valued predicates return the whole set
+		kpaks = a.engine.QueryValues(req.Subject, *req.Predicate)
 	} else {
 		// All k-paks for subject
 		kpaks = a.engine.QueryBySubject(req.Subject)
 	}
 
 	// Stream results
+	now := time.Now()
 	for _, kpak := range kpaks {
 		protoKpak := a.kpakToProto(kpak)
+		protoKpak.EffectiveConfidence = a.engine.EffectiveConfidence(kpak, now)
+		protoKpak.FusedConfidence = protoKpak.EffectiveConfidence
+		if evidence := a.engine.GetEvidenceFor(kpak); evidence != nil {
+			protoKpak.FusedConfidence = evidence.Confidence
+			protoKpak.SupportingSources = evidence.Sources
+		}
+		if pin := a.engine.GetPin(kpak.Subject, kpak.Predicate); pin != nil && pin.Kpak.ID == kpak.ID {
+			protoKpak.Pinned = true
+			protoKpak.PinnedBy = pin.Operator
+			protoKpak.PinReason = pin.Reason
+		}
+		if req.MinConfidence != nil && protoKpak.FusedConfidence < *req.MinConfidence {
+			continue
+		}
+		if err := stream.Send(protoKpak); err != nil {
+			return err
+		}
+	}
+
+	// Values waiting to replace a damped truth are shown alongside it
+	predicate := ""
+	if req.Predicate != nil {
+		predicate = *req.Predicate
+	}
+	for _, pending := range a.engine.GetPending(req.Subject, predicate) {
+		protoKpak := a.kpakToProto(pending.Kpak)
+		protoKpak.EffectiveConfidence = a.engine.EffectiveConfidence(pending.Kpak, now)
+		protoKpak.FusedConfidence = protoKpak.EffectiveConfidence
+		protoKpak.Pending = true
+		protoKpak.PendingSince = pending.Since.Unix()
+		protoKpak.Confirmations = int32(pending.Confirmations)
+		if req.MinConfidence != nil && protoKpak.EffectiveConfidence < *req.MinConfidence {
+			continue
+		}
 		if err := stream.Send(protoKpak); err != nil {
 			return err
 		}
@@ -297,15 +1139,23 @@ func (a *Agent) Query(req *v1.QueryRequest, stream v1.SynapseService_QueryServer
 // Health returns the agent's health status.
 func (a *Agent) Health(ctx context.Context, req *v1.HealthRequest) (*v1.HealthResponse, error) {
 	stats := a.engine.GetStats()
-	totalKpaks := int32(stats["total_kpaks"].(int))
-
-	health := a.metrics.GetHealthStatus(totalKpaks)
-
-	return &v1.HealthResponse{
-		Status:        health.Status,
-		KpakCount:     health.KpakCount,
-		UptimeSeconds: health.UptimeSeconds,
-	}, nil
+	report := a.health.Run()
+
+	response := &v1.HealthResponse{
+		Status:        string(report.Status),
+		KpakCount:     int32(stats["total_kpaks"].(int)),
+		UptimeSeconds: int64(time.Since(a.startTime).Seconds()),
+		Message:       report.Message(),
+		State:         string(a.State()),
+	}
+	for _, result := range report.Results {
+		response.Checks = append(response.Checks, &v1.HealthCheck{
+			Name:    result.Name,
+			Status:  string(result.Status),
+			Message: result.Message,
+		})
+	}
+	return response, nil
 }
 
 // GetPeers returns information about mesh peers.
@@ -320,6 +1170,9 @@ func (a *Agent) GetPeers(ctx context.Context, req *v1.PeersRequest) (*v1.PeersRe
 			State:    int32(member.State),
 			LastSeen: time.Now().Unix(), // TODO: Get actual last seen time
 		}
+		if member.GRPCPort > 0 {
+			peers[i].GrpcAddress = fmt.Sprintf("%s:%d", member.Addr, member.GRPCPort)
+		}
 	}
 
 	return &v1.PeersResponse{
@@ -345,11 +1198,404 @@ func (a *Agent) GetMetrics(ctx context.Context, req *v1.MetricsRequest) (*v1.Met
 		CpuUsagePercent:  metrics.CPUUsagePercent,
 		Version:          metrics.Version,
 		ActiveSources:    metrics.ActiveSources,
+		PolicyViolations: metrics.PolicyViolations,
+		ConfidenceCapped: metrics.ConfidenceCapped,
+
+		PolicyViolationsBySource: metrics.PolicyViolationsBySource,
+		Throttled:                metrics.Throttled,
+		ThrottledBySource:        metrics.ThrottledBySource,
+		QuotaExceeded:            metrics.QuotaExceeded,
+		QuotaExceededBySource:    metrics.QuotaExceededBySource,
+		HeapAllocBytes:           metrics.HeapAllocBytes,
+		Goroutines:               metrics.Goroutines,
+		OpenFds:                  metrics.OpenFDs,
+		GcRuns:                   metrics.GCRuns,
+		GcPauseTotalNs:           metrics.GCPauseTotalNs,
+		GcPauseLastNs:            metrics.GCPauseLastNs,
+		GcPauseMaxNs:             metrics.GCPauseMaxNs,
+		IngestRatePerMinAvg5:     metrics.IngestRates[1],
+		IngestRatePerMinAvg15:    metrics.IngestRates[2],
+		QueryRatePerMinAvg5:      metrics.QueryRates[1],
+		QueryRatePerMinAvg15:     metrics.QueryRates[2],
 	}, nil
 }
 
+// Retract withdraws a source's claims about a subject+predicate and shares the retraction with the mesh.
+func (a *Agent) Retract(ctx context.Context, req *v1.RetractRequest) (*v1.RetractResponse, error) {
+	if caller := auth.FromContext(ctx); caller != nil && !caller.MayWriteAs(req.Source) {
+		return nil, status.Errorf(codes.PermissionDenied, "%q may not retract claims of source %q", caller.Name, req.Source)
+	}
+
+	retraction := &gossip.Retraction{
+		Subject:   req.Subject,
+		Predicate: req.Predicate,
+		Source:    req.Source,
+		Timestamp: time.Now().Unix(),
+	}
+
+	// Narrow the retraction to one value, spelled the way the schema stores it
+	if req.Object != "" || req.Value != nil {
+		object, err := objectFromValue(req.Value, req.Object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		if object, err = a.schemas.NormalizeObject(req.Predicate, object); err != nil {
+			return nil, err
+		}
+		retraction.ObjectKey = core.CanonicalObject(object)
+	}
+
+	// OR-set removals travel as merged state, so peers only drop the adds seen here
+	if a.schemas.CRDT(req.Predicate) != "" {
+		before := a.engine.GetCRDT(req.Subject, req.Predicate)
+		resp := &v1.RetractResponse{Retracted: int32(a.applyRetraction(retraction))}
+		if resp.Retracted > 0 {
+			a.shareCRDT(req.Subject, req.Predicate, before)
+		}
+		if current := a.engine.QueryBySubjectPredicate(req.Subject, req.Predicate); current != nil {
+			resp.Current = a.kpakToProto(current)
+		}
+		return resp, nil
+	}
+
+	retracted := a.applyRetraction(retraction)
+	if retracted > 0 {
+		if err := a.wal.AppendEntry(retractionEntry(retraction)); err != nil {
+			return nil, fmt.Errorf("failed to persist retraction: %w", err)
+		}
+	}
+
+	// Peers may hold claims this agent already dropped, so always share the retraction
+	if err := a.gossip.BroadcastRetract(retraction); err != nil {
+		logger.Warn("Failed to broadcast retraction to mesh", logging.KeySPID, logging.SPID(retraction.Subject, retraction.Predicate), logging.KeySource, retraction.Source, logging.KeyError, err)
+	}
+
+	resp := &v1.RetractResponse{Retracted: int32(retracted)}
+	if a.schemas.MultiValued(req.Predicate) {
+		for _, member := range a.engine.QueryValues(req.Subject, req.Predicate) {
+			resp.Members = append(resp.Members, a.kpakToProto(member))
+		}
+	} else if current := a.engine.QueryBySubjectPredicate(req.Subject, req.Predicate); current != nil {
+		resp.Current = a.kpakToProto(current)
+	}
+	return resp, nil
+}
+
+// DefineSchema declares or replaces a predicate schema and shares it with the mesh.
+func (a *Agent) DefineSchema(ctx context.Context, req *v1.PredicateSchema) (*v1.DefineSchemaResponse, error) {
+	s := schemaFromProto(req)
+	current := a.schemas.Version(s.Predicate)
+	if s.Version == 0 {
+		s.Version = current + 1
+	}
+	if s.Version <= current {
+		return nil, fmt.Errorf("schema for %q version %d is not newer than version %d", s.Predicate, s.Version, current)
+	}
+
+	if _, err := a.putSchema(s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+
+	if err := a.gossip.BroadcastSchema(s); err != nil {
+		logger.Warn("Failed to broadcast schema to mesh", "predicate", s.Predicate, logging.KeyError, err)
+	}
+
+	return &v1.DefineSchemaResponse{Schema: schemaToProto(s)}, nil
+}
+
+// ListSchemas returns the predicate schemas this agent enforces.
+func (a *Agent) ListSchemas(ctx context.Context, req *v1.ListSchemasRequest) (*v1.ListSchemasResponse, error) {
+	schemas := a.schemas.List()
+	resp := &v1.ListSchemasResponse{Schemas: make([]*v1.PredicateSchema, len(schemas))}
+	for i, s := range schemas {
+		resp.Schemas[i] = schemaToProto(s)
+	}
+	return resp, nil
+}
+
+// Conflicts lists recent concurrent updates recorded by the reconciliation engine.
+func (a *Agent) Conflicts(ctx context.Context, req *v1.ConflictsRequest) (*v1.ConflictsResponse, error) {
+	conflicts := a.engine.GetConflicts(req.Subject, req.Predicate)
+	if req.Limit > 0 && len(conflicts) > int(req.Limit) {
+		conflicts = conflicts[:req.Limit]
+	}
+
+	resp := &v1.ConflictsResponse{Conflicts: make([]*v1.Conflict, len(conflicts))}
+	for i, conflict := range conflicts {
+		resp.Conflicts[i] = &v1.Conflict{
+			Subject:      conflict.Subject,
+			Predicate:    conflict.Predicate,
+			Local:        a.kpakToProto(conflict.Local),
+			Remote:       a.kpakToProto(conflict.Remote),
+			LocalVersion: conflict.LocalVersion,
+			DetectedAt:   conflict.DetectedAt.Unix(),
+		}
+		if conflict.Winner != nil {
+			resp.Conflicts[i].Winner = a.kpakToProto(conflict.Winner)
+		}
+	}
+	return resp, nil
+}
+
+// Anomalies lists the findings published by analyzers anywhere in the mesh.
+func (a *Agent) Anomalies(ctx context.Context, req *v1.AnomaliesRequest) (*v1.AnomaliesResponse, error) {
+	var kpaks []*core.Kpak
+	if req.Subject != "" {
+		kpaks = a.engine.QueryBySubject(analyzer.Namespace + req.Subject)
+	} else {
+		kpaks = a.engine.GetAllTruths()
+	}
+
+	var anomalies []*v1.Anomaly
+	for _, kpak := range kpaks {
+		if !analyzer.IsReserved(kpak.Subject) || kpak.IsExpired() {
+			continue
+		}
+		finding, err := analyzer.FromKpak(kpak)
+		if err != nil || (req.Kind != "" && string(finding.Kind) != req.Kind) {
+			continue
+		}
+
+		anomaly := &v1.Anomaly{
+			Kind:          string(finding.Kind),
+			Subject:       finding.Subject,
+			Predicate:     finding.Predicate,
+			Changes:       int32(finding.Changes),
+			WindowSeconds: finding.WindowSeconds,
+			Values:        finding.Values,
+			DetectedAt:    finding.DetectedAt,
+			ReportedBy:    kpak.Source,
+		}
+		if finding.Winner != nil {
+			anomaly.Winner = a.kpakToProto(finding.Winner)
+		}
+		if finding.Rival != nil {
+			anomaly.Rival = a.kpakToProto(finding.Rival)
+		}
+		anomalies = append(anomalies, anomaly)
+	}
+
+	sort.Slice(anomalies, func(i, j int) bool {
+		return anomalies[i].DetectedAt > anomalies[j].DetectedAt
+	})
+	if req.Limit > 0 && len(anomalies) > int(req.Limit) {
+		anomalies = anomalies[:req.Limit]
+	}
+	return &v1.AnomaliesResponse{Anomalies: anomalies}, nil
+}
+
+// Override pins a fact to an operator-chosen value, or lifts the pin, and shares
+// the update with the mesh.
+func (a *Agent) Override(ctx context.Context, req *v1.OverrideRequest) (*v1.OverrideResponse, error) {
+	if req.Subject == "" || req.Predicate == "" {
+		return nil, fmt.Errorf("subject and predicate are required")
+	}
+	// An authenticated caller is always the operator, whatever the request claims
+	if caller := auth.FromContext(ctx); caller != nil {
+		req.Operator = caller.Name
+	}
+	if req.Operator == "" {
+		return nil, fmt.Errorf("operator is required")
+	}
+
+	pin := &reconciliation.Pin{Operator: req.Operator, Reason: req.Reason, Removed: req.Remove}
+	if req.Remove {
+		current := a.engine.GetPin(req.Subject, req.Predicate)
+		if current == nil {
+			return nil, fmt.Errorf("%s %s is not pinned", req.Subject, req.Predicate)
+		}
+		pin.Kpak = current.Kpak
+	} else {
+		if req.Reason == "" {
+			return nil, fmt.Errorf("a reason is required to pin a value")
+		}
+		if req.Until > 0 && req.Until <= time.Now().Unix() {
+			return nil, fmt.Errorf("pin expiry %d is in the past", req.Until)
+		}
+		object, err := objectFromValue(req.Value, req.Object)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value: %w", err)
+		}
+		kpak := core.NewKpak(req.Subject, req.Predicate, object, req.Operator, 1.0)
+		kpak.ExpiresAt = req.Until
+		if err := a.schemas.Validate(kpak); err != nil {
+			return nil, err
+		}
+		pin.Kpak = kpak
+	}
+	pin.UpdatedAt = time.Now().UnixNano()
+
+	if _, err := a.engine.SetPin(pin); err != nil {
+		return nil, err
+	}
+	if err := a.wal.AppendEntry(&store.Entry{Type: store.EntryPin, Pin: pin, Timestamp: time.Now().Unix()}); err != nil {
+		return nil, fmt.Errorf("failed to persist pin: %w", err)
+	}
+	if err := a.gossip.BroadcastPin(pin); err != nil {
+		logger.Warn("Failed to broadcast pin to mesh", logging.KeyError, err)
+	}
+
+	return &v1.OverrideResponse{Pinned: a.kpakToProto(pin.Kpak), Removed: pin.Removed}, nil
+}
+
+// ListReview lists claims held for review, or recent decisions.
+func (a *Agent) ListReview(ctx context.Context, req *v1.ListReviewRequest) (*v1.ListReviewResponse, error) {
+	var status review.Status
+	if req.Status != "all" {
+		parsed, err := review.ParseStatus(req.Status)
+		if err != nil {
+			return nil, err
+		}
+		status = parsed
+	}
+
+	items := a.review.List(status)
+	if req.Limit > 0 && len(items) > int(req.Limit) {
+		items = items[:req.Limit]
+	}
+
+	resp := &v1.ListReviewResponse{Items: make([]*v1.ReviewItem, len(items))}
+	for i, item := range items {
+		resp.Items[i] = a.reviewItemToProto(item)
+	}
+	return resp, nil
+}
+
+// DecideReview approves or rejects a held claim and shares the decision with the
+// mesh. An approved claim is reconciled here and gossiped like any ingested claim.
+func (a *Agent) DecideReview(ctx context.Context, req *v1.DecideReviewRequest) (*v1.DecideReviewResponse, error) {
+	// An authenticated caller is always the reviewer, whatever the request claims
+	if caller := auth.FromContext(ctx); caller != nil {
+		req.Reviewer = caller.Name
+	}
+	// Approved claims count against their source's daily quota like ingested ones;
+	// an exhausted quota leaves the claim pending for another day
+	if req.Approve {
+		if held, err := a.review.Find(req.Id); err == nil {
+			if err := a.limiter.CheckQuota(held.Kpak.Source); err != nil {
+				a.metrics.RecordQuotaExceeded(held.Kpak.Source)
+				a.metrics.RecordIngestError()
+				return nil, status.Error(codes.ResourceExhausted, err.Error())
+			}
+		}
+	}
+	item, err := a.review.Decide(req.Id, req.Approve, req.Reviewer, req.Note)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.wal.AppendEntry(reviewEntry(item)); err != nil {
+		return nil, fmt.Errorf("failed to persist review decision: %w", err)
+	}
+	if err := a.gossip.BroadcastReview(item); err != nil {
+		logger.With(logging.KpakFields(item.Kpak)...).Warn("Failed to broadcast review decision to mesh", logging.KeyError, err)
+	}
+
+	resp := &v1.DecideReviewResponse{Item: a.reviewItemToProto(item)}
+	if item.Status == review.StatusApproved {
+		// The claim enters the mesh now, not when it was held
+		claim := *item.Kpak
+		a.gossip.StampOrigin(&claim)
+		accepted, err := a.applyClaim(ctx, &claim)
+		if err != nil {
+			return nil, err
+		}
+		if accepted {
+			a.limiter.CountAccepted(claim.Source)
+		}
+		resp.Accepted = accepted
+	}
+	return resp, nil
+}
+
+// SetLogLevel changes the log level until the agent restarts; an empty level only reports it.
+func (a *Agent) SetLogLevel(ctx context.Context, req *v1.SetLogLevelRequest) (*v1.SetLogLevelResponse, error) {
+	if req.Level == "" {
+		level := logging.Level()
+		return &v1.SetLogLevelResponse{Level: level, Previous: level}, nil
+	}
+	previous, err := logging.SetLevel(req.Level)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	logger.Info("Log level changed", "from", previous, "to", logging.Level())
+	return &v1.SetLogLevelResponse{Level: logging.Level(), Previous: previous}, nil
+}
+
 // Helper methods
 
+func schemaFromProto(proto *v1.PredicateSchema) *schema.Schema {
+	return &schema.Schema{
+		Predicate:         proto.Predicate,
+		Type:              core.ValueType(proto.Type),
+		Enum:              proto.Enum,
+		Aliases:           proto.Aliases,
+		Min:               proto.Min,
+		Max:               proto.Max,
+		Cardinality:       schema.Cardinality(proto.Cardinality),
+		DefaultTTLSeconds: proto.DefaultTtlSeconds,
+		Resolver:          proto.Resolver,
+		CRDT:              crdt.Type(proto.Crdt),
+		Version:           proto.Version,
+	}
+}
+
+func schemaToProto(s *schema.Schema) *v1.PredicateSchema {
+	return &v1.PredicateSchema{
+		Predicate:         s.Predicate,
+		Type:              string(s.Type),
+		Enum:              s.Enum,
+		Aliases:           s.Aliases,
+		Min:               s.Min,
+		Max:               s.Max,
+		Cardinality:       string(s.Cardinality),
+		DefaultTtlSeconds: s.DefaultTTLSeconds,
+		Resolver:          s.Resolver,
+		Crdt:              string(s.CRDT),
+		Version:           s.Version,
+	}
+}
+
+func reviewEntry(item *review.Item) *store.Entry {
+	return &store.Entry{Type: store.EntryReview, Review: item, Timestamp: time.Now().Unix()}
+}
+
+func (a *Agent) reviewItemToProto(item *review.Item) *v1.ReviewItem {
+	proto := &v1.ReviewItem{
+		Id:       item.ID,
+		Claim:    a.kpakToProto(item.Kpak),
+		Reasons:  item.Reasons,
+		Status:   string(item.Status),
+		HeldAt:   item.HeldAt.Unix(),
+		HeldBy:   item.HeldBy,
+		Reviewer: item.Reviewer,
+		Note:     item.Note,
+	}
+	if !item.DecidedAt.IsZero() {
+		proto.DecidedAt = item.DecidedAt.Unix()
+	}
+	return proto
+}
+
+func retractionEntry(retraction *gossip.Retraction) *store.Entry {
+	return &store.Entry{
+		Type:      store.EntryRetract,
+		Subject:   retraction.Subject,
+		Predicate: retraction.Predicate,
+		Source:    retraction.Source,
+		ObjectKey: retraction.ObjectKey,
+		Timestamp: retraction.Timestamp,
+	}
+}
+
+// applyRetraction withdraws the claims a retraction covers and returns how many were dropped.
+// Claims newer than the retraction are kept.
+func (a *Agent) applyRetraction(retraction *gossip.Retraction) int {
+	if retraction.ObjectKey != "" {
+		return a.engine.RetractMember(retraction.Subject, retraction.Predicate, retraction.Source, retraction.ObjectKey, retraction.Timestamp)
+	}
+	return a.engine.Retract(retraction.Subject, retraction.Predicate, retraction.Source, retraction.Timestamp)
+}
+
 func (a *Agent) protoToKpak(proto *v1.Kpak) *core.Kpak {
 	// Calculate TTL from expires_at field or use default
 	var ttlSeconds int64
@@ -360,15 +1606,23 @@ func (a *Agent) protoToKpak(proto *v1.Kpak) *core.Kpak {
 		} else {
 			ttlSeconds = 0 // Already expired, but we'll let reconciliation handle it
 		}
+	} else if schemaTTL := a.schemas.DefaultTTL(proto.Predicate); schemaTTL > 0 {
+		ttlSeconds = schemaTTL
 	} else if a.config.DefaultTTLSeconds > 0 {
 		ttlSeconds = a.config.DefaultTTLSeconds
 	}
 
+	// Prefer the typed value; Ingest has already rejected values that do not decode
+	object, err := objectFromProto(proto)
+	if err != nil {
+		object = proto.Object
+	}
+
 	// Create k-pak with TTL
 	kpak := core.NewKpakWithTTL(
 		proto.Subject,
 		proto.Predicate,
-		proto.Object,
+		object,
 		proto.Source,
 		proto.Confidence,
 		ttlSeconds,
@@ -395,12 +1649,80 @@ func (a *Agent) kpakToProto(kpak *core.Kpak) *v1.Kpak {
 	return &v1.Kpak{
 		Subject:    kpak.Subject,
 		Predicate:  kpak.Predicate,
-		Object:     fmt.Sprintf("%v", kpak.Object), // Convert to string
+		Object:     core.FormatObject(kpak.Object), // Plain text for older clients
+		Value:      valueToProto(kpak.Object),
 		Source:     kpak.Source,
 		Confidence: kpak.Confidence,
 		Timestamp:  kpak.Timestamp,
 		Id:         kpak.ID,
 		Spid:       kpak.SPID,
 		ExpiresAt:  kpak.ExpiresAt,
+		Version:    kpak.Version,
+	}
+}
+
+// objectFromProto returns the typed object of a proto k-pak, falling back to the
+// plain string object when no typed value was sent.
+func objectFromProto(proto *v1.Kpak) (interface{}, error) {
+	return objectFromValue(proto.Value, proto.Object)
+}
+
+// objectFromValue decodes a typed value, or returns the plain object when no value is set.
+func objectFromValue(value *v1.Value, plain string) (interface{}, error) {
+	if value == nil || value.Kind == nil {
+		return plain, nil
+	}
+
+	var object interface{}
+	switch kind := value.Kind.(type) {
+	case *v1.Value_StringValue:
+		object = kind.StringValue
+	case *v1.Value_IntValue:
+		object = kind.IntValue
+	case *v1.Value_FloatValue:
+		object = kind.FloatValue
+	case *v1.Value_BoolValue:
+		object = kind.BoolValue
+	case *v1.Value_BytesValue:
+		object = kind.BytesValue
+	case *v1.Value_TimestampValue:
+		if err := kind.TimestampValue.CheckValid(); err != nil {
+			return nil, fmt.Errorf("invalid timestamp value: %w", err)
+		}
+		object = kind.TimestampValue.AsTime()
+	case *v1.Value_JsonValue:
+		object = core.JSON(kind.JsonValue)
+	case *v1.Value_RefValue:
+		object = core.Ref(kind.RefValue)
+	default:
+		return nil, fmt.Errorf("unsupported value kind %T", kind)
+	}
+
+	if err := core.ValidateObject(object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// valueToProto converts a k-pak object to its typed proto form.
+func valueToProto(object interface{}) *v1.Value {
+	switch core.TypeOf(object) {
+	case core.ValueString:
+		return &v1.Value{Kind: &v1.Value_StringValue{StringValue: object.(string)}}
+	case core.ValueInt:
+		return &v1.Value{Kind: &v1.Value_IntValue{IntValue: reflect.ValueOf(object).Convert(reflect.TypeOf(int64(0))).Int()}}
+	case core.ValueFloat:
+		return &v1.Value{Kind: &v1.Value_FloatValue{FloatValue: reflect.ValueOf(object).Float()}}
+	case core.ValueBool:
+		return &v1.Value{Kind: &v1.Value_BoolValue{BoolValue: object.(bool)}}
+	case core.ValueBytes:
+		return &v1.Value{Kind: &v1.Value_BytesValue{BytesValue: object.([]byte)}}
+	case core.ValueTimestamp:
+		return &v1.Value{Kind: &v1.Value_TimestampValue{TimestampValue: timestamppb.New(object.(time.Time))}}
+	case core.ValueRef:
+		return &v1.Value{Kind: &v1.Value_RefValue{RefValue: string(object.(core.Ref))}}
+	default:
+		canonical := core.CanonicalObject(object)
+		return &v1.Value{Kind: &v1.Value_JsonValue{JsonValue: strings.TrimPrefix(canonical, string(core.ValueJSON)+":")}}
 	}
 }
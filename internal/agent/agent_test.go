@@ -3,12 +3,42 @@ package agent
 import (
 	"context"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
 
 	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/analyzer"
+	"github.com/Pew-X/sutra/internal/auth"
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/gossip"
+	"github.com/Pew-X/sutra/internal/health"
+	"github.com/Pew-X/sutra/internal/logging"
+	"github.com/Pew-X/sutra/internal/monitoring"
+	"github.com/Pew-X/sutra/internal/ratelimit"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
+	"github.com/Pew-X/sutra/internal/tracing"
 )
 
 func TestNewAgent(t *testing.T) {
@@ -87,6 +117,46 @@ func TestNewAgent_InvalidWALPath(t *testing.T) {
 	}
 }
 
+func TestNewAgent_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config Config
+	}{
+		{"unknown reconciliation mode", Config{ReconciliationMode: "majority"}},
+		{"invalid schema", Config{Schemas: []schema.Schema{{Predicate: "status", Cardinality: "several"}}}},
+		{"damping rule without dwell time or confirmations", Config{Damping: []reconciliation.DampingRule{{Pattern: "status"}}}},
+		{"review rule that holds nothing", Config{Review: review.Config{Rules: []review.Rule{{Predicate: "status"}}}}},
+		{"missing policy file", Config{PolicyFile: "missing.yaml"}},
+		{"unknown rate limit mode", Config{RateLimits: ratelimit.Config{Mode: "drop"}}},
+		{"auth without credentials", Config{Auth: auth.Config{Enabled: true}}},
+		{"invalid health threshold", Config{Health: health.Config{IngestErrorRatioDegraded: 2}}},
+		{"unknown unready_queries mode", Config{UnreadyQueries: "ignore"}},
+		{"invalid sample ratio", Config{Tracing: tracing.Config{SampleRatio: 2}}},
+		{"unknown log level", Config{LogLevel: "verbose"}},
+		{"unknown log format", Config{LogFormat: "xml"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "agent_test")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			config := test.config
+			config.Host = "127.0.0.1"
+			config.WALPath = filepath.Join(tempDir, "test.log")
+			if config.PolicyFile != "" {
+				config.PolicyFile = filepath.Join(tempDir, config.PolicyFile)
+			}
+			if _, err := NewAgent(config); err == nil {
+				t.Fatal("Expected the config to be rejected")
+			}
+		})
+	}
+}
+
 func TestAgent_StartShutdown(t *testing.T) {
 	// Create temporary WAL directory
 	tempDir, err := os.MkdirTemp("", "agent_test")
@@ -300,6 +370,10 @@ func TestAgent_GetMetrics(t *testing.T) {
 		t.Fatal("Memory usage should be non-negative")
 	}
 
+	if resp.Goroutines <= 0 || resp.HeapAllocBytes <= 0 {
+		t.Fatalf("Expected process resources to be reported, got %+v", resp)
+	}
+
 	if resp.Version == "" {
 		t.Fatal("Version should not be empty")
 	}
@@ -568,3 +642,1717 @@ func mustMarshal(kpak *core.Kpak) string {
 	}
 	return string(data)
 }
+
+// fakeIngestStream feeds k-paks to Agent.Ingest and captures its response.
+type fakeIngestStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	kpaks    []*v1.Kpak
+	response *v1.IngestResponse
+}
+
+func (s *fakeIngestStream) Context() context.Context {
+	if s.ctx != nil {
+		return s.ctx
+	}
+	return context.Background()
+}
+
+func (s *fakeIngestStream) Recv() (*v1.Kpak, error) {
+	if len(s.kpaks) == 0 {
+		return nil, io.EOF
+	}
+	kpak := s.kpaks[0]
+	s.kpaks = s.kpaks[1:]
+	return kpak, nil
+}
+
+func (s *fakeIngestStream) SendAndClose(response *v1.IngestResponse) error {
+	s.response = response
+	return nil
+}
+
+// fakeQueryStream collects the k-paks streamed by Agent.Query.
+type fakeQueryStream struct {
+	grpc.ServerStream
+	kpaks []*v1.Kpak
+}
+
+func (s *fakeQueryStream) Context() context.Context {
+	return context.Background()
+}
+
+func (s *fakeQueryStream) Send(kpak *v1.Kpak) error {
+	s.kpaks = append(s.kpaks, kpak)
+	return nil
+}
+
+func TestAgent_RetractPromotesRunnerUp(t *testing.T) {
+	// Create temporary WAL directory
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		Host:       "127.0.0.1",
+		GRPCPort:   0,
+		GossipPort: 0,
+		JoinPeers:  []string{},
+		LogLevel:   "INFO",
+		WALPath:    filepath.Join(tempDir, "test.log"),
+	}
+
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	err = agent.Start()
+	if err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+
+	winner := core.NewKpak("db-1", "status", "up", "MonitorA", 0.9)
+	runnerUp := core.NewKpak("db-1", "status", "down", "MonitorB", 0.8)
+	for _, kpak := range []*core.Kpak{winner, runnerUp} {
+		agent.engine.ReconcileOutcome(kpak)
+		if err := agent.wal.Append(kpak); err != nil {
+			t.Fatalf("Failed to append k-pak: %v", err)
+		}
+	}
+
+	resp, err := agent.Retract(context.Background(), &v1.RetractRequest{Subject: "db-1", Predicate: "status", Source: "MonitorA"})
+	if err != nil {
+		t.Fatalf("Retract failed: %v", err)
+	}
+	if resp.Retracted != 1 {
+		t.Fatalf("Expected 1 retracted claim, got %d", resp.Retracted)
+	}
+	if resp.Current == nil || resp.Current.Source != "MonitorB" {
+		t.Fatal("Runner-up should be reported as the current truth")
+	}
+
+	agent.Shutdown()
+
+	// A restarted agent replays the retraction and lands on the same truth
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to recreate agent: %v", err)
+	}
+	if err := restarted.Start(); err != nil {
+		t.Fatalf("Failed to restart agent: %v", err)
+	}
+	defer restarted.Shutdown()
+
+	result := restarted.engine.QueryBySubjectPredicate("db-1", "status")
+	if result == nil || result.ID != runnerUp.ID {
+		t.Fatal("Replayed WAL should keep the promoted runner-up as truth")
+	}
+}
+
+func TestAgent_TypedValueConversion(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	values := []*v1.Value{
+		{Kind: &v1.Value_StringValue{StringValue: "hello"}},
+		{Kind: &v1.Value_IntValue{IntValue: 42}},
+		{Kind: &v1.Value_FloatValue{FloatValue: 98.6}},
+		{Kind: &v1.Value_BoolValue{BoolValue: true}},
+		{Kind: &v1.Value_BytesValue{BytesValue: []byte{1, 2, 3}}},
+		{Kind: &v1.Value_JsonValue{JsonValue: `{"b":2,"a":1}`}},
+		{Kind: &v1.Value_RefValue{RefValue: "bob"}},
+	}
+
+	for _, value := range values {
+		kpak := agent.protoToKpak(&v1.Kpak{Subject: "s", Predicate: "p", Value: value, Source: "src", Confidence: 0.9})
+		back := agent.kpakToProto(kpak)
+
+		again := agent.protoToKpak(back)
+		if again.ObjectKey() != kpak.ObjectKey() {
+			t.Fatalf("Expected %s to survive the round trip, got %s", kpak.ObjectKey(), again.ObjectKey())
+		}
+		if back.Object != core.FormatObject(kpak.Object) {
+			t.Fatalf("Expected display object %q, got %q", core.FormatObject(kpak.Object), back.Object)
+		}
+	}
+
+	// Typed values must not be confused with their string spelling
+	intKpak := agent.protoToKpak(&v1.Kpak{Subject: "s", Predicate: "p", Value: values[1], Source: "src", Confidence: 0.9})
+	strKpak := agent.protoToKpak(&v1.Kpak{Subject: "s", Predicate: "p", Object: "42", Source: "src", Confidence: 0.9})
+	if intKpak.ObjectKey() == strKpak.ObjectKey() {
+		t.Fatal("Expected int 42 and string \"42\" to be different objects")
+	}
+}
+
+func TestObjectFromProto_RejectsInvalidValues(t *testing.T) {
+	invalid := []*v1.Kpak{
+		{Value: &v1.Value{Kind: &v1.Value_JsonValue{JsonValue: `{"a":`}}},
+		{Value: &v1.Value{Kind: &v1.Value_FloatValue{FloatValue: math.NaN()}}},
+	}
+	for _, proto := range invalid {
+		if _, err := objectFromProto(proto); err == nil {
+			t.Fatalf("Expected %v to be rejected", proto.Value)
+		}
+	}
+}
+
+func TestAgent_IngestEnforcesSchema(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Schemas: []schema.Schema{{
+			Predicate: "status",
+			Type:      core.ValueString,
+			Enum:      []string{"up", "down"},
+			Aliases:   map[string]string{"0": "down"},
+		}},
+	}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "status", Object: "DOWN", Source: "scout-a", Confidence: 0.6},
+		{Subject: "server1", Predicate: "status", Object: "0", Source: "scout-b", Confidence: 0.9},
+		{Subject: "server1", Predicate: "status", Object: "sideways", Source: "scout-c", Confidence: 0.99},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	if stream.response.Accepted != 2 || stream.response.Rejected != 1 {
+		t.Fatalf("Expected 2 accepted and 1 rejected, got %d and %d", stream.response.Accepted, stream.response.Rejected)
+	}
+	if len(stream.response.Errors) != 1 {
+		t.Fatalf("Expected 1 error, got %v", stream.response.Errors)
+	}
+
+	truth := agent.engine.QueryBySubjectPredicate("server1", "status")
+	if truth == nil || truth.Object != "down" || truth.Source != "scout-b" {
+		t.Fatalf("Expected normalized truth 'down' from scout-b, got %+v", truth)
+	}
+	if candidates := agent.engine.GetCandidates("server1", "status"); len(candidates) != 2 || candidates[1].ObjectKey() != truth.ObjectKey() {
+		t.Fatal("Expected both spellings to be kept as the same value")
+	}
+}
+
+func TestAgent_DefineSchemaPersists(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	max := 100.0
+	resp, err := agent.DefineSchema(context.Background(), &v1.PredicateSchema{Predicate: "cpu_*", Type: "float", Max: &max})
+	if err != nil {
+		t.Fatalf("DefineSchema failed: %v", err)
+	}
+	if resp.Schema.Version != 1 {
+		t.Fatalf("Expected version 1, got %d", resp.Schema.Version)
+	}
+
+	if _, err := agent.DefineSchema(context.Background(), &v1.PredicateSchema{Predicate: "cpu_*", Type: "float", Version: 1}); err == nil {
+		t.Fatal("Expected a schema with a stale version to be refused")
+	}
+	if _, err := agent.DefineSchema(context.Background(), &v1.PredicateSchema{Predicate: "cpu_*", Type: "colour"}); err == nil {
+		t.Fatal("Expected an invalid schema to be refused")
+	}
+	agent.wal.Close()
+
+	// A restarted agent enforces the schema it learned
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := restarted.loadFromWAL(); err != nil {
+		t.Fatalf("Failed to load WAL: %v", err)
+	}
+
+	list, err := restarted.ListSchemas(context.Background(), &v1.ListSchemasRequest{})
+	if err != nil {
+		t.Fatalf("ListSchemas failed: %v", err)
+	}
+	if len(list.Schemas) != 1 || list.Schemas[0].Predicate != "cpu_*" || list.Schemas[0].Version != 1 {
+		t.Fatalf("Expected the cpu_* schema after restart, got %v", list.Schemas)
+	}
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu_usage", Object: "150", Source: "scout", Confidence: 0.9},
+	}}
+	if err := restarted.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if stream.response.Rejected != 1 {
+		t.Fatal("Expected a value above the maximum to be rejected")
+	}
+}
+
+func TestAgent_MultiValuedPredicate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Schemas: []schema.Schema{{Predicate: "depends_on", Type: core.ValueRef, Cardinality: schema.CardinalityMulti}},
+	}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "svc-a", Predicate: "depends_on", Object: "db", Source: "tracer", Confidence: 0.9},
+		{Subject: "svc-a", Predicate: "depends_on", Object: "cache", Source: "tracer", Confidence: 0.9},
+		{Subject: "svc-a", Predicate: "depends_on", Object: "queue", Source: "tracer", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if ingest.response.Accepted != 3 {
+		t.Fatalf("Expected every member to be accepted, got %d", ingest.response.Accepted)
+	}
+
+	predicate := "depends_on"
+	query := &fakeQueryStream{}
+	if err := agent.Query(&v1.QueryRequest{Subject: "svc-a", Predicate: &predicate}, query); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(query.kpaks) != 3 {
+		t.Fatalf("Expected the whole set of 3 members, got %d", len(query.kpaks))
+	}
+
+	resp, err := agent.Retract(context.Background(), &v1.RetractRequest{Subject: "svc-a", Predicate: "depends_on", Source: "tracer", Object: "cache"})
+	if err != nil {
+		t.Fatalf("Retract failed: %v", err)
+	}
+	if resp.Retracted != 1 || len(resp.Members) != 2 {
+		t.Fatalf("Expected 1 retracted and 2 remaining members, got %d and %d", resp.Retracted, len(resp.Members))
+	}
+	agent.wal.Close()
+
+	// The member retraction survives a restart
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := restarted.loadFromWAL(); err != nil {
+		t.Fatalf("Failed to load WAL: %v", err)
+	}
+	members := restarted.engine.QueryValues("svc-a", "depends_on")
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members after restart, got %d", len(members))
+	}
+	for _, member := range members {
+		if member.Object == core.Ref("cache") {
+			t.Fatal("Retracted member came back after restart")
+		}
+	}
+}
+
+func TestAgent_CRDTPredicate(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Schemas: []schema.Schema{{Predicate: "incidents", CRDT: crdt.GCounter}},
+	}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "svc-a", Predicate: "incidents", Object: "1", Source: "pager", Confidence: 0.9},
+		{Subject: "svc-a", Predicate: "incidents", Object: "2", Source: "pager", Confidence: 0.9},
+		{Subject: "svc-a", Predicate: "incidents", Object: "-1", Source: "pager", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if ingest.response.Accepted != 2 || ingest.response.Rejected != 1 {
+		t.Fatalf("Expected 2 accepted and 1 rejected, got %d and %d", ingest.response.Accepted, ingest.response.Rejected)
+	}
+
+	// Another agent's increments merge into the local count
+	remote := crdt.NewState(crdt.GCounter, "svc-a", "incidents")
+	remote.Apply(core.NewKpak("svc-a", "incidents", int64(4), "pager", 0.9), "synapse-10.0.0.2-7946")
+	before := agent.engine.GetCRDT("svc-a", "incidents")
+	if changed, err := agent.engine.MergeCRDT(remote); err != nil || !changed {
+		t.Fatalf("Expected remote state to merge, got %v, %v", changed, err)
+	}
+	agent.persistCRDT("svc-a", "incidents", before)
+
+	// A retried update counts once, and gossiped claims do not count at all
+	sent := time.Now().Unix()
+	for i := 0; i < 2; i++ {
+		ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+			{Subject: "svc-a", Predicate: "incidents", Object: "3", Source: "pager", Confidence: 0.9, Timestamp: sent},
+		}}
+		if err := agent.Ingest(ingest); err != nil {
+			t.Fatalf("Ingest failed: %v", err)
+		}
+	}
+	if agent.handleGossipKpak(context.Background(), core.NewKpak("svc-a", "incidents", int64(5), "pager", 0.9)) {
+		t.Fatal("Expected a gossiped update to a CRDT predicate to be ignored")
+	}
+
+	if truth := agent.engine.QueryBySubjectPredicate("svc-a", "incidents"); truth == nil || truth.Object != int64(10) {
+		t.Fatalf("Expected count 10, got %+v", truth)
+	}
+
+	// Each log entry holds only what its update changed
+	entries, err := agent.wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to read WAL: %v", err)
+	}
+	last := entries[len(entries)-1]
+	if last.CRDT == nil || len(last.CRDT.Applied) != 1 {
+		t.Fatalf("Expected the last entry to record one update, got %+v", last.CRDT)
+	}
+	agent.wal.Close()
+
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := restarted.loadFromWAL(); err != nil {
+		t.Fatalf("Failed to load WAL: %v", err)
+	}
+	if truth := restarted.engine.QueryBySubjectPredicate("svc-a", "incidents"); truth == nil || truth.Object != int64(10) {
+		t.Fatalf("Expected count 10 after restart, got %+v", truth)
+	}
+}
+
+func TestAgent_Conflicts(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", GossipPort: 7946, WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "svc-a", Predicate: "owner", Object: "alice", Source: "hr", Confidence: 0.8},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	// A claim made on another agent that had not seen the local one
+	remote := core.NewKpak("svc-a", "owner", "bob", "directory", 0.9)
+	remote.Version = core.VersionVector{"synapse-10.0.0.2-7946": 1}
+	agent.engine.ReconcileOutcome(remote)
+
+	resp, err := agent.Conflicts(context.Background(), &v1.ConflictsRequest{Subject: "svc-a"})
+	if err != nil {
+		t.Fatalf("Conflicts failed: %v", err)
+	}
+	if len(resp.Conflicts) != 1 {
+		t.Fatalf("Expected 1 conflict, got %d", len(resp.Conflicts))
+	}
+	conflict := resp.Conflicts[0]
+	if conflict.Local.Object != "alice" || conflict.Remote.Object != "bob" || conflict.Winner.Object != "bob" {
+		t.Fatalf("Unexpected conflict %+v", conflict)
+	}
+	if conflict.LocalVersion["synapse-127.0.0.1-7946"] != 1 || conflict.Remote.Version["synapse-10.0.0.2-7946"] != 1 {
+		t.Fatalf("Unexpected versions %v and %v", conflict.LocalVersion, conflict.Remote.Version)
+	}
+
+	resp, err = agent.Conflicts(context.Background(), &v1.ConflictsRequest{Predicate: "status"})
+	if err != nil || len(resp.Conflicts) != 0 {
+		t.Fatalf("Expected the predicate filter to exclude the conflict, got %v, %v", resp, err)
+	}
+}
+
+func TestAgent_Anomalies(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:     "127.0.0.1",
+		WALPath:  filepath.Join(tempDir, "test.log"),
+		Analyzer: analyzer.Config{Enabled: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+	agent.engine.SetChangeHandler(agent.handleTruthChange)
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "svc-a", Predicate: "owner", Object: "alice", Source: "hr", Confidence: 0.9},
+		{Subject: "svc-a", Predicate: "owner", Object: "bob", Source: "directory", Confidence: 0.88},
+		{Subject: analyzer.Namespace + "svc-a", Predicate: "flapping/owner", Object: "{}", Source: "prankster", Confidence: 1},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if len(ingest.response.Errors) != 1 {
+		t.Fatalf("Expected the reserved subject to be refused, got %v", ingest.response.Errors)
+	}
+
+	resp, err := agent.Anomalies(context.Background(), &v1.AnomaliesRequest{Subject: "svc-a"})
+	if err != nil {
+		t.Fatalf("Anomalies failed: %v", err)
+	}
+	if len(resp.Anomalies) != 1 {
+		t.Fatalf("Expected 1 anomaly, got %d", len(resp.Anomalies))
+	}
+	anomaly := resp.Anomalies[0]
+	if anomaly.Kind != "contradiction" || anomaly.Winner.Object != "alice" || anomaly.Rival.Object != "bob" {
+		t.Fatalf("Unexpected anomaly %+v", anomaly)
+	}
+	if anomaly.ReportedBy != "analyzer@synapse-127.0.0.1-0" {
+		t.Fatalf("Unexpected reporter %q", anomaly.ReportedBy)
+	}
+
+	resp, err = agent.Anomalies(context.Background(), &v1.AnomaliesRequest{Kind: "flapping"})
+	if err != nil || len(resp.Anomalies) != 0 {
+		t.Fatalf("Expected the kind filter to exclude the contradiction, got %v, %v", resp, err)
+	}
+}
+
+func TestAgent_QueryShowsPendingValues(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Damping: []reconciliation.DampingRule{{Pattern: "health_*", DwellSeconds: 300}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "health_http", Object: "up", Source: "probe-a", Confidence: 0.8},
+		{Subject: "server1", Predicate: "health_http", Object: "down", Source: "probe-b", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	query := &fakeQueryStream{}
+	if err := agent.Query(&v1.QueryRequest{Subject: "server1"}, query); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(query.kpaks) != 2 {
+		t.Fatalf("Expected the truth and the pending value, got %d results", len(query.kpaks))
+	}
+	if query.kpaks[0].Object != "up" || query.kpaks[0].Pending {
+		t.Fatalf("Expected the truth first, got %+v", query.kpaks[0])
+	}
+	if query.kpaks[1].Object != "down" || !query.kpaks[1].Pending || query.kpaks[1].Confirmations != 1 || query.kpaks[1].PendingSince == 0 {
+		t.Fatalf("Expected the pending value second, got %+v", query.kpaks[1])
+	}
+}
+
+func TestAgent_OverridePinsFact(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "health", Object: "up", Source: "probe", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	if _, err := agent.Override(context.Background(), &v1.OverrideRequest{Subject: "server1", Predicate: "health", Object: "down", Operator: "alice"}); err == nil {
+		t.Fatal("Expected a pin without a reason to be refused")
+	}
+	if _, err := agent.Override(context.Background(), &v1.OverrideRequest{Subject: "server1", Predicate: "health", Object: "down", Operator: "alice", Reason: "x", Until: 1}); err == nil {
+		t.Fatal("Expected a pin expiring in the past to be refused")
+	}
+
+	resp, err := agent.Override(context.Background(), &v1.OverrideRequest{
+		Subject:   "server1",
+		Predicate: "health",
+		Object:    "down",
+		Operator:  "alice",
+		Reason:    "maintenance window",
+	})
+	if err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	if resp.Pinned.Object != "down" || resp.Pinned.Source != "alice" {
+		t.Fatalf("Unexpected pinned value %+v", resp.Pinned)
+	}
+
+	query := &fakeQueryStream{}
+	if err := agent.Query(&v1.QueryRequest{Subject: "server1"}, query); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(query.kpaks) != 1 || query.kpaks[0].Object != "down" || !query.kpaks[0].Pinned {
+		t.Fatalf("Expected the pinned value, got %+v", query.kpaks)
+	}
+	if query.kpaks[0].PinnedBy != "alice" || query.kpaks[0].PinReason != "maintenance window" {
+		t.Fatalf("Expected the pin's operator and reason, got %+v", query.kpaks[0])
+	}
+	agent.wal.Close()
+
+	// A restarted agent keeps the pin, and lifting it shows the reconciled truth
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer restarted.wal.Close()
+	if err := restarted.loadFromWAL(); err != nil {
+		t.Fatalf("Failed to load WAL: %v", err)
+	}
+	if truth := restarted.engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "down" {
+		t.Fatalf("Expected the pin after restart, got %v", truth.Object)
+	}
+
+	resp, err = restarted.Override(context.Background(), &v1.OverrideRequest{Subject: "server1", Predicate: "health", Operator: "bob", Remove: true})
+	if err != nil {
+		t.Fatalf("Unpin failed: %v", err)
+	}
+	if !resp.Removed {
+		t.Fatal("Expected the pin to be reported as removed")
+	}
+	if truth := restarted.engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "up" {
+		t.Fatalf("Expected the reconciled truth after unpinning, got %v", truth.Object)
+	}
+	if _, err := restarted.Override(context.Background(), &v1.OverrideRequest{Subject: "server1", Predicate: "health", Operator: "bob", Remove: true}); err == nil {
+		t.Fatal("Expected unpinning an unpinned fact to fail")
+	}
+}
+
+func TestAgent_OverrideRecordsCaller(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	// An authenticated caller can't record the pin under someone else's name
+	ctx := auth.NewContext(context.Background(), &auth.Identity{Name: "carol", Role: auth.RoleAdmin})
+	resp, err := agent.Override(ctx, &v1.OverrideRequest{
+		Subject:   "server1",
+		Predicate: "health",
+		Object:    "down",
+		Operator:  "alice",
+		Reason:    "maintenance window",
+	})
+	if err != nil {
+		t.Fatalf("Override failed: %v", err)
+	}
+	if resp.Pinned.Source != "carol" {
+		t.Fatalf("Expected the pin to be recorded for the caller, got %q", resp.Pinned.Source)
+	}
+}
+
+func TestAgent_ReviewQueue(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	config := Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Review: review.Config{Rules: []review.Rule{
+			{Predicate: "status", MinConfidence: 0.6},
+		}},
+	}
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "status", Object: "up", Source: "monitor", Confidence: 0.9},
+		{Subject: "server1", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.5},
+		{Subject: "server2", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.4},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if ingest.response.Accepted != 1 || ingest.response.Held != 2 || ingest.response.Rejected != 0 {
+		t.Fatalf("Expected 1 accepted and 2 held, got %+v", ingest.response)
+	}
+	if agent.engine.QueryBySubjectPredicate("server2", "status") != nil {
+		t.Fatal("Expected the held claim not to reach reconciliation")
+	}
+
+	list, err := agent.ListReview(context.Background(), &v1.ListReviewRequest{})
+	if err != nil {
+		t.Fatalf("ListReview failed: %v", err)
+	}
+	if len(list.Items) != 2 || list.Items[0].Status != "pending" || len(list.Items[0].Reasons) != 1 {
+		t.Fatalf("Expected two pending claims, got %+v", list.Items)
+	}
+	if _, err := agent.ListReview(context.Background(), &v1.ListReviewRequest{Status: "maybe"}); err == nil {
+		t.Fatal("Expected an unknown status to be refused")
+	}
+
+	var approveID, rejectID string
+	for _, item := range list.Items {
+		if item.Claim.Subject == "server2" {
+			approveID = item.Id
+		} else {
+			rejectID = item.Id
+		}
+	}
+
+	resp, err := agent.DecideReview(context.Background(), &v1.DecideReviewRequest{Id: approveID[:10], Approve: true, Reviewer: "alice"})
+	if err != nil {
+		t.Fatalf("Approve failed: %v", err)
+	}
+	if resp.Item.Status != "approved" || !resp.Accepted {
+		t.Fatalf("Expected the approved claim to become the truth, got %+v", resp)
+	}
+	if truth := agent.engine.QueryBySubjectPredicate("server2", "status"); truth == nil || truth.Object != "down" {
+		t.Fatalf("Expected the approved claim as truth, got %v", truth)
+	}
+	if _, err := agent.DecideReview(context.Background(), &v1.DecideReviewRequest{Id: rejectID, Reviewer: "alice", Note: "hallucinated"}); err != nil {
+		t.Fatalf("Reject failed: %v", err)
+	}
+	if truth := agent.engine.QueryBySubjectPredicate("server1", "status"); truth.Object != "up" {
+		t.Fatalf("Expected the rejected claim to leave the truth alone, got %v", truth.Object)
+	}
+
+	// The same rejected claim sent again stays out
+	rejected := agent.review.Get(rejectID).Kpak
+	again := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.5, Timestamp: rejected.Timestamp},
+	}}
+	if err := agent.Ingest(again); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if again.response.Rejected != 1 {
+		t.Fatalf("Expected the rejected claim to be refused, got %+v", again.response)
+	}
+	agent.wal.Close()
+
+	// The queue and its decisions survive a restart
+	restarted, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer restarted.wal.Close()
+	if err := restarted.loadFromWAL(); err != nil {
+		t.Fatalf("Failed to load WAL: %v", err)
+	}
+	all, err := restarted.ListReview(context.Background(), &v1.ListReviewRequest{Status: "all"})
+	if err != nil {
+		t.Fatalf("ListReview failed: %v", err)
+	}
+	if len(all.Items) != 2 {
+		t.Fatalf("Expected both decisions after restart, got %d", len(all.Items))
+	}
+	if item := restarted.review.Get(rejectID); item == nil || item.Status != review.StatusRejected || item.Note != "hallucinated" {
+		t.Fatalf("Expected the rejection after restart, got %+v", item)
+	}
+	if truth := restarted.engine.QueryBySubjectPredicate("server2", "status"); truth == nil || truth.Object != "down" {
+		t.Fatalf("Expected the approved claim after restart, got %v", truth)
+	}
+}
+
+func TestAgent_SourcePolicy(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	policyFile := filepath.Join(tempDir, "policy.yaml")
+	policyData := `
+default: deny
+sources:
+  - source: "ai-scout"
+    allow:
+      - predicate: "status"
+        max_confidence: 0.7
+`
+	if err := os.WriteFile(policyFile, []byte(policyData), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log"), PolicyFile: policyFile})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.95},
+		{Subject: "server1", Predicate: "owner", Object: "team-a", Source: "ai-scout", Confidence: 0.9},
+		{Subject: "server1", Predicate: "status", Object: "up", Source: "rogue", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if ingest.response.Accepted != 1 || ingest.response.Rejected != 2 || len(ingest.response.Errors) != 2 {
+		t.Fatalf("Expected 1 accepted and 2 policy violations, got %+v", ingest.response)
+	}
+	if !strings.Contains(ingest.response.Errors[0], "policy violation") {
+		t.Fatalf("Expected a policy violation error, got %q", ingest.response.Errors[0])
+	}
+	if truth := agent.engine.QueryBySubjectPredicate("server1", "status"); truth.Confidence != 0.7 {
+		t.Fatalf("Expected the confidence to be capped at 0.7, got %v", truth.Confidence)
+	}
+
+	// Claims gossiped by peers are held to the same policy
+	if agent.handleGossipKpak(context.Background(), core.NewKpak("server2", "status", "up", "rogue", 0.9)) {
+		t.Fatal("Expected a gossiped claim from an unauthorized source to be refused")
+	}
+	if !agent.handleGossipKpak(context.Background(), core.NewKpak("server2", "status", "up", "ai-scout", 0.5)) {
+		t.Fatal("Expected an authorized gossiped claim to be accepted")
+	}
+
+	metrics, err := agent.GetMetrics(context.Background(), &v1.MetricsRequest{})
+	if err != nil {
+		t.Fatalf("GetMetrics failed: %v", err)
+	}
+	if metrics.PolicyViolations != 3 || metrics.ConfidenceCapped != 1 || metrics.PolicyViolationsBySource["rogue"] != 2 {
+		t.Fatalf("Unexpected policy metrics %+v", metrics)
+	}
+}
+
+func TestAgent_IngestRateLimit(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:       "127.0.0.1",
+		WALPath:    filepath.Join(tempDir, "test.log"),
+		RateLimits: ratelimit.Config{PerSource: ratelimit.Limit{Rate: 0.001, Burst: 2}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "runaway", Confidence: 0.9},
+		{Subject: "server2", Predicate: "cpu", Object: "20", Source: "runaway", Confidence: 0.9},
+		{Subject: "server3", Predicate: "cpu", Object: "30", Source: "runaway", Confidence: 0.9},
+	}}
+	err = agent.Ingest(stream)
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted, got %v", err)
+	}
+	if agent.engine.QueryBySubjectPredicate("server2", "cpu") == nil || agent.engine.QueryBySubjectPredicate("server3", "cpu") != nil {
+		t.Fatal("Expected the claims before the limit to be kept and the rest refused")
+	}
+
+	// Other sources are unaffected
+	other := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server4", Predicate: "cpu", Object: "40", Source: "steady", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(other); err != nil || other.response.Accepted != 1 {
+		t.Fatalf("Expected another source to pass, got %v", err)
+	}
+
+	metrics, _ := agent.GetMetrics(context.Background(), &v1.MetricsRequest{})
+	if metrics.Throttled != 1 || metrics.ThrottledBySource["runaway"] != 1 {
+		t.Fatalf("Unexpected throttle metrics %+v", metrics)
+	}
+}
+
+func TestAgent_IngestThrottles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		RateLimits: ratelimit.Config{
+			Mode:      ratelimit.ModeThrottle,
+			PerClient: ratelimit.Limit{Rate: 50, Burst: 1},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "a", Confidence: 0.9},
+		{Subject: "server2", Predicate: "cpu", Object: "20", Source: "b", Confidence: 0.9},
+		{Subject: "server3", Predicate: "cpu", Object: "30", Source: "c", Confidence: 0.9},
+	}}
+	start := time.Now()
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if stream.response.Accepted != 3 {
+		t.Fatalf("Expected every claim to be accepted after waiting, got %+v", stream.response)
+	}
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("Expected the stream to be slowed down, took %v", elapsed)
+	}
+
+	// A stream that ends while waiting gives up
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := &fakeIngestStream{ctx: ctx, kpaks: []*v1.Kpak{
+		{Subject: "server4", Predicate: "cpu", Object: "40", Source: "d", Confidence: 0.9},
+		{Subject: "server5", Predicate: "cpu", Object: "50", Source: "d", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(canceled); status.Code(err) != codes.Canceled {
+		t.Fatalf("Expected Canceled, got %v", err)
+	}
+}
+
+func TestAgent_IngestDailyQuota(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:       "127.0.0.1",
+		WALPath:    filepath.Join(tempDir, "test.log"),
+		RateLimits: ratelimit.Config{DailyQuota: 1},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout", Confidence: 0.9},
+		{Subject: "server1", Predicate: "cpu", Object: "5", Source: "other", Confidence: 0.1},
+		{Subject: "server2", Predicate: "cpu", Object: "20", Source: "scout", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(stream); status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted once the quota is used up, got %v", err)
+	}
+	if agent.engine.QueryBySubjectPredicate("server2", "cpu") != nil {
+		t.Fatal("Expected the claim over quota to be refused")
+	}
+
+	metrics, _ := agent.GetMetrics(context.Background(), &v1.MetricsRequest{})
+	if metrics.QuotaExceeded != 1 {
+		t.Fatalf("Expected one claim over quota, got %d", metrics.QuotaExceeded)
+	}
+}
+
+func TestAgent_ReviewApprovalCountsAgainstQuota(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:       "127.0.0.1",
+		WALPath:    filepath.Join(tempDir, "test.log"),
+		RateLimits: ratelimit.Config{DailyQuota: 1},
+		Review:     review.Config{Rules: []review.Rule{{Predicate: "status", MinConfidence: 0.6}}},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.4},
+		{Subject: "server2", Predicate: "status", Object: "down", Source: "ai-scout", Confidence: 0.4},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if stream.response.Held != 2 {
+		t.Fatalf("Expected both claims to be held, got %+v", stream.response)
+	}
+
+	pending := agent.review.List(review.StatusPending)
+	resp, err := agent.DecideReview(context.Background(), &v1.DecideReviewRequest{Id: pending[0].ID, Approve: true, Reviewer: "alice"})
+	if err != nil || !resp.Accepted {
+		t.Fatalf("Expected the first approval to be accepted, got %+v, %v", resp, err)
+	}
+
+	// The approval used up ai-scout's quota, so the second claim stays pending
+	_, err = agent.DecideReview(context.Background(), &v1.DecideReviewRequest{Id: pending[1].ID, Approve: true, Reviewer: "alice"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Expected ResourceExhausted once the quota is used up, got %v", err)
+	}
+	if item := agent.review.Get(pending[1].ID); item.Status != review.StatusPending {
+		t.Fatalf("Expected the claim over quota to stay pending, got %s", item.Status)
+	}
+	if _, err := agent.DecideReview(context.Background(), &v1.DecideReviewRequest{Id: pending[1].ID, Reviewer: "alice"}); err != nil {
+		t.Fatalf("Expected a rejection to need no quota, got %v", err)
+	}
+}
+
+// tokenCredentials attaches a bearer token to every RPC in tests.
+type tokenCredentials string
+
+func (t tokenCredentials) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return false
+}
+
+func TestAgent_Authentication(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Auth: auth.Config{
+			Enabled: true,
+			Tokens: []auth.Token{
+				{Name: "dashboard", Token: "read-secret", Role: auth.RoleReader},
+				{Name: "scout", Token: "write-secret", Role: auth.RoleWriter, Sources: []string{"scout-*"}},
+				{Name: "ops", Token: "admin-secret", Role: auth.RoleAdmin},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(agent.serverOptions()...)
+	v1.RegisterSynapseServiceServer(server, agent)
+	healthpb.RegisterHealthServer(server, agent.grpcHealth)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	connect := func(token string) v1.SynapseServiceClient {
+		options := []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}
+		if token != "" {
+			options = append(options, grpc.WithPerRPCCredentials(tokenCredentials(token)))
+		}
+		conn, err := grpc.NewClient("passthrough:///bufnet", options...)
+		if err != nil {
+			t.Fatalf("Failed to dial: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		return v1.NewSynapseServiceClient(conn)
+	}
+	ctx := context.Background()
+
+	if _, err := connect("").Health(ctx, &v1.HealthRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated without a token, got %v", err)
+	}
+	if _, err := connect("wrong").Health(ctx, &v1.HealthRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated with an unknown token, got %v", err)
+	}
+
+	// Probes use the standard health service without credentials
+	probe, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer probe.Close()
+	if _, err := healthpb.NewHealthClient(probe).Check(ctx, &healthpb.HealthCheckRequest{}); err != nil {
+		t.Fatalf("Expected the health service to need no token, got %v", err)
+	}
+
+	reader := connect("read-secret")
+	if _, err := reader.Health(ctx, &v1.HealthRequest{}); err != nil {
+		t.Fatalf("Expected a reader to call Health, got %v", err)
+	}
+	if _, err := reader.Retract(ctx, &v1.RetractRequest{Subject: "server1", Predicate: "cpu", Source: "scout-1"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied for a reader retracting, got %v", err)
+	}
+
+	// Writers may only ingest as the sources their token is bound to
+	ingest, err := connect("write-secret").Ingest(ctx)
+	if err != nil {
+		t.Fatalf("Failed to open ingest stream: %v", err)
+	}
+	ingest.Send(&v1.Kpak{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout-1", Confidence: 0.9})
+	ingest.Send(&v1.Kpak{Subject: "server2", Predicate: "cpu", Object: "20", Source: "cmdb", Confidence: 0.9})
+	response, err := ingest.CloseAndRecv()
+	if err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if response.Accepted != 1 || response.Rejected != 1 || !strings.Contains(strings.Join(response.Errors, ";"), "may not write as source") {
+		t.Fatalf("Expected the foreign source to be refused, got %+v", response)
+	}
+	if _, err := connect("write-secret").Retract(ctx, &v1.RetractRequest{Subject: "server1", Predicate: "cpu", Source: "cmdb"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied retracting as a foreign source, got %v", err)
+	}
+	if _, err := connect("write-secret").DefineSchema(ctx, &v1.PredicateSchema{Predicate: "cpu", Type: "int"}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied for a writer defining a schema, got %v", err)
+	}
+
+	if _, err := connect("admin-secret").DefineSchema(ctx, &v1.PredicateSchema{Predicate: "cpu", Type: "int"}); err != nil {
+		t.Fatalf("Expected an admin to define a schema, got %v", err)
+	}
+}
+
+func TestAgent_PrometheusMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:        "127.0.0.1",
+		WALPath:     filepath.Join(tempDir, "test.log"),
+		MetricsAddr: "127.0.0.1:0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	defer agent.Shutdown()
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout", Confidence: 0.9},
+		{Subject: "server1", Predicate: "cpu", Object: "20", Source: "backup", Confidence: 0.5},
+		{Subject: "server1", Predicate: "cpu", Value: &v1.Value{Kind: &v1.Value_FloatValue{FloatValue: math.NaN()}}, Source: "scout", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if err := agent.Query(&v1.QueryRequest{Subject: "server1"}, &fakeQueryStream{}); err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+
+	resp, err := http.Get("http://" + agent.http.Addr + "/metrics")
+	if err != nil {
+		t.Fatalf("Failed to scrape metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	text := string(body)
+
+	for _, want := range []string{
+		`sutra_ingest_kpaks_total{source="scout",result="accepted"} 1`,
+		`sutra_ingest_kpaks_total{source="scout",result="rejected"} 1`,
+		`sutra_reconcile_decisions_total{outcome="accepted"} 1`,
+		`sutra_reconcile_decisions_total{outcome="candidate"} 1`,
+		"sutra_query_duration_seconds_count 1",
+		"sutra_wal_fsync_duration_seconds_count 2",
+		"sutra_engine_kpaks 1",
+		"sutra_engine_candidates 2",
+		"sutra_mesh_peers 1",
+		"# TYPE sutra_process_cpu_seconds_total counter",
+		"# TYPE sutra_go_goroutines gauge",
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Fatalf("Expected %q in metrics:\n%s", want, text)
+		}
+	}
+}
+
+func TestAgent_HealthChecks(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:      "127.0.0.1",
+		WALPath:   filepath.Join(tempDir, "test.log"),
+		GCEnabled: true,
+		Health:    health.Config{IngestErrorMinSamples: 2},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	defer agent.Shutdown()
+
+	ctx := context.Background()
+	resp, err := agent.Health(ctx, &v1.HealthRequest{})
+	if err != nil {
+		t.Fatalf("Health failed: %v", err)
+	}
+	checks := make(map[string]*v1.HealthCheck)
+	for _, check := range resp.Checks {
+		checks[check.Name] = check
+	}
+	for _, name := range []string{"process", "wal", "gossip", "gc", "ingest", "snapshot", "disk"} {
+		if checks[name] == nil {
+			t.Fatalf("Expected a %s check, got %+v", name, resp.Checks)
+		}
+	}
+	for _, name := range []string{"wal", "gossip", "gc", "ingest", "snapshot"} {
+		if checks[name].Status != "healthy" {
+			t.Fatalf("Expected %s to be healthy, got %+v", name, checks[name])
+		}
+	}
+	if checks["process"].Status != "degraded" || resp.Status != "degraded" || !strings.Contains(resp.Message, "recently started") {
+		t.Fatalf("Expected a freshly started agent to be degraded, got %s: %s", resp.Status, resp.Message)
+	}
+
+	agent.updateServingStatus()
+	serving, err := agent.grpcHealth.Check(ctx, &healthpb.HealthCheckRequest{Service: v1.SynapseService_ServiceDesc.ServiceName})
+	if err != nil || serving.Status != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected a degraded agent to keep serving, got %v, %v", serving, err)
+	}
+
+	// Mostly rejected ingests make the agent unhealthy and take it out of rotation
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: analyzer.Namespace + "x", Predicate: "cpu", Object: "1", Source: "scout", Confidence: 0.9},
+		{Subject: analyzer.Namespace + "y", Predicate: "cpu", Object: "1", Source: "scout", Confidence: 0.9},
+	}}
+	agent.Ingest(stream)
+	resp, _ = agent.Health(ctx, &v1.HealthRequest{})
+	if resp.Status != "unhealthy" {
+		t.Fatalf("Expected unhealthy after rejected ingests, got %s: %s", resp.Status, resp.Message)
+	}
+	agent.updateServingStatus()
+	serving, _ = agent.grpcHealth.Check(ctx, &healthpb.HealthCheckRequest{})
+	if serving.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Expected an unhealthy agent not to serve, got %v", serving.Status)
+	}
+}
+
+func TestAgent_IngestHealthIgnoresRunnerUps(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Health:  health.Config{IngestErrorMinSamples: 2},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	// Sources agreeing or disagreeing on a fact is how the mesh is meant to work
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "a", Confidence: 0.9},
+		{Subject: "server1", Predicate: "cpu", Object: "20", Source: "b", Confidence: 0.5},
+		{Subject: "server1", Predicate: "cpu", Object: "30", Source: "c", Confidence: 0.4},
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "d", Confidence: 0.3},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if stream.response.Accepted != 1 {
+		t.Fatalf("Expected the others to be kept as runner-ups, got %+v", stream.response)
+	}
+
+	for _, result := range agent.health.Run().Results {
+		if result.Name == "ingest" && result.Status != health.StatusHealthy {
+			t.Fatalf("Expected runner-ups to leave ingest healthy, got %s: %s", result.Status, result.Message)
+		}
+	}
+}
+
+func TestAgent_SnapshotAgeCheck(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Health:  health.Config{SnapshotAgeDegradedMinutes: 0.001, SnapshotAgeUnhealthyMinutes: 60},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	snapshot := func() health.Result {
+		for _, result := range agent.health.Run().Results {
+			if result.Name == "snapshot" {
+				return result
+			}
+		}
+		t.Fatal("Expected a snapshot check")
+		return health.Result{}
+	}
+
+	// Nothing reached the WAL for longer than the degraded threshold
+	time.Sleep(100 * time.Millisecond)
+	if result := snapshot(); result.Status != health.StatusDegraded {
+		t.Fatalf("Expected a stale snapshot to be degraded, got %s: %s", result.Status, result.Message)
+	}
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+	if result := snapshot(); result.Status != health.StatusHealthy {
+		t.Fatalf("Expected a fresh snapshot to be healthy, got %s: %s", result.Status, result.Message)
+	}
+}
+
+func TestAgent_Lifecycle(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{
+		Host:        "127.0.0.1",
+		WALPath:     filepath.Join(tempDir, "test.log"),
+		MetricsAddr: "127.0.0.1:0",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if agent.State() != StateStarting {
+		t.Fatalf("Expected a new agent to be starting, got %s", agent.State())
+	}
+
+	// The first agent of a mesh has no one to sync with
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	resp, err := agent.Health(context.Background(), &v1.HealthRequest{})
+	if err != nil || resp.State != string(StateReady) {
+		t.Fatalf("Expected a lone agent to be ready, got %v, %v", resp, err)
+	}
+	probe, err := http.Get("http://" + agent.http.Addr + "/readyz")
+	if err != nil {
+		t.Fatalf("Failed to probe readiness: %v", err)
+	}
+	probe.Body.Close()
+	if probe.StatusCode != http.StatusOK {
+		t.Fatalf("Expected /readyz to answer 200, got %d", probe.StatusCode)
+	}
+
+	agent.Shutdown()
+	if agent.State() != StateDraining {
+		t.Fatalf("Expected a shut down agent to be draining, got %s", agent.State())
+	}
+	serving, _ := agent.grpcHealth.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if serving.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Expected a draining agent not to serve, got %v", serving.Status)
+	}
+}
+
+func TestAgent_CatchesUpOnJoin(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	seed, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "seed.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := seed.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	defer seed.Shutdown()
+	// Claims taken in before the peer joins reach it only through state sync
+	ingest := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "health", Object: "up", Source: "probe", Confidence: 0.9},
+	}}
+	if err := seed.Ingest(ingest); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	members := seed.gossip.GetMembers()
+	if len(members) != 1 {
+		t.Fatalf("Expected the seed alone in its mesh, got %+v", members)
+	}
+	joiner, err := NewAgent(Config{
+		Host:      "127.0.0.1",
+		WALPath:   filepath.Join(tempDir, "joiner.log"),
+		JoinPeers: []string{fmt.Sprintf("%s:%d", members[0].Addr, members[0].Port)},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := joiner.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	defer joiner.Shutdown()
+
+	if joiner.State() != StateReady {
+		t.Fatalf("Expected the joiner to be ready, got %s", joiner.State())
+	}
+	if truth := joiner.engine.QueryBySubjectPredicate("server1", "health"); truth == nil || truth.Object != "up" {
+		t.Fatalf("Expected the seed's claims once ready, got %v", truth)
+	}
+}
+
+func TestAgent_ReadinessGating(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	// No peer listens on port 1, so the agent waits out its sync timeout
+	agent, err := NewAgent(Config{
+		Host:               "127.0.0.1",
+		WALPath:            filepath.Join(tempDir, "test.log"),
+		JoinPeers:          []string{"127.0.0.1:1"},
+		SyncTimeoutSeconds: 1,
+		UnreadyQueries:     UnreadyQueriesRefuse,
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	defer agent.Shutdown()
+	if agent.State() != StateSyncing {
+		t.Fatalf("Expected the agent to be syncing, got %s", agent.State())
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer(agent.serverOptions()...)
+	v1.RegisterSynapseServiceServer(server, agent)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+	client := v1.NewSynapseServiceClient(conn)
+	ctx := context.Background()
+
+	query := func() error {
+		stream, err := client.Query(ctx, &v1.QueryRequest{Subject: "server1"})
+		if err != nil {
+			return err
+		}
+		for {
+			if _, err := stream.Recv(); err != nil {
+				if err == io.EOF {
+					return nil
+				}
+				return err
+			}
+		}
+	}
+	if err := query(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("Expected queries to be refused while syncing, got %v", err)
+	}
+	if _, err := client.Health(ctx, &v1.HealthRequest{}); err != nil {
+		t.Fatalf("Expected health to answer while syncing, got %v", err)
+	}
+	serving, _ := agent.grpcHealth.Check(ctx, &healthpb.HealthCheckRequest{})
+	if serving.Status != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("Expected a syncing agent not to serve, got %v", serving.Status)
+	}
+
+	// Flagging answers but marks the response stale
+	agent.config.UnreadyQueries = UnreadyQueriesFlag
+	stream, err := client.Query(ctx, &v1.QueryRequest{Subject: "server1"})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	header, err := stream.Header()
+	if err != nil || len(header.Get(StaleHeader)) != 1 || header.Get(StateHeader)[0] != string(StateSyncing) {
+		t.Fatalf("Expected a stale header, got %v, %v", header, err)
+	}
+	agent.config.UnreadyQueries = UnreadyQueriesRefuse
+
+	// After the sync timeout the agent is ready, but degraded until it reaches a peer
+	for i := 0; i < 30 && agent.State() != StateReady; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if agent.State() != StateReady {
+		t.Fatalf("Expected the agent to be ready after the sync timeout, got %s", agent.State())
+	}
+	if err := query(); err != nil {
+		t.Fatalf("Expected queries to be answered once ready, got %v", err)
+	}
+	resp, _ := agent.Health(ctx, &v1.HealthRequest{})
+	for _, check := range resp.Checks {
+		if check.Name == "lifecycle" && (check.Status != "degraded" || !strings.Contains(check.Message, "no peer")) {
+			t.Fatalf("Expected the lifecycle check to be degraded without peers, got %+v", check)
+		}
+	}
+}
+
+// traceCollector is an OTLP/gRPC collector stub that keeps the spans it receives.
+type traceCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+	spans []*tracepb.Span
+	mutex sync.Mutex
+}
+
+func (c *traceCollector) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, resource := range req.ResourceSpans {
+		for _, scope := range resource.ScopeSpans {
+			c.spans = append(c.spans, scope.Spans...)
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func TestAgent_Tracing(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	collector := &traceCollector{}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	go server.Serve(listener)
+	defer server.Stop()
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	agent, err := NewAgent(Config{
+		Host:    "127.0.0.1",
+		WALPath: filepath.Join(tempDir, "test.log"),
+		Tracing: tracing.Config{Endpoint: listener.Addr().String(), Insecure: true},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	// Shutting down flushes the spans to the collector
+	agent.Shutdown()
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	spans := make(map[string]*tracepb.Span)
+	for _, span := range collector.spans {
+		spans[span.Name] = span
+	}
+	ingest := spans["Agent.Ingest"]
+	if ingest == nil {
+		t.Fatalf("Expected an ingest span, got %v", collector.spans)
+	}
+	for _, name := range []string{"Agent.applyClaim", "Engine.Reconcile", "WAL.Append", "Manager.BroadcastKpak"} {
+		span := spans[name]
+		if span == nil {
+			t.Fatalf("Expected a %s span, got %v", name, collector.spans)
+		}
+		if string(span.TraceId) != string(ingest.TraceId) {
+			t.Fatalf("Expected %s in the ingest's trace", name)
+		}
+	}
+}
+
+func TestAgent_SetLogLevel(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	initial := logging.Level()
+	defer logging.SetLevel(initial)
+
+	resp, err := agent.SetLogLevel(context.Background(), &v1.SetLogLevelRequest{Level: "debug"})
+	if err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	if resp.Level != "debug" || resp.Previous != initial {
+		t.Fatalf("Expected %s -> debug, got %s -> %s", initial, resp.Previous, resp.Level)
+	}
+
+	resp, err = agent.SetLogLevel(context.Background(), &v1.SetLogLevelRequest{})
+	if err != nil {
+		t.Fatalf("SetLogLevel failed: %v", err)
+	}
+	if resp.Level != "debug" {
+		t.Fatalf("Expected an empty request to report debug, got %s", resp.Level)
+	}
+
+	_, err = agent.SetLogLevel(context.Background(), &v1.SetLogLevelRequest{Level: "loud"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected InvalidArgument for an unknown level, got %v", err)
+	}
+	if logging.Level() != "debug" {
+		t.Fatalf("Expected a rejected level to leave debug in place, got %s", logging.Level())
+	}
+}
+
+func TestAgent_IngestStampsOrigin(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", GossipPort: 7946, WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	before := time.Now().UnixNano()
+	stream := &fakeIngestStream{kpaks: []*v1.Kpak{
+		{Subject: "server1", Predicate: "cpu", Object: "10", Source: "scout", Confidence: 0.9},
+	}}
+	if err := agent.Ingest(stream); err != nil {
+		t.Fatalf("Ingest failed: %v", err)
+	}
+
+	// Propagation is timed from here, however long the claim waits before it is sent
+	truth := agent.engine.QueryBySubjectPredicate("server1", "cpu")
+	if truth.Origin != gossip.NodeName("127.0.0.1", 7946) || truth.OriginatedAt < before {
+		t.Fatalf("Expected the claim to be stamped at ingest, got %q at %d", truth.Origin, truth.OriginatedAt)
+	}
+}
+
+func TestAgent_GossipPropagationMetrics(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	agent, err := NewAgent(Config{Host: "127.0.0.1", WALPath: filepath.Join(tempDir, "test.log")})
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	defer agent.wal.Close()
+
+	peer := "synapse-10.0.0.2-9091"
+	ctx := gossip.WithOrigin(context.Background(), gossip.Origin{Node: peer, At: time.Now().Add(-40 * time.Millisecond)})
+	kpak := core.NewKpak("server1", "cpu", "10", "scout", 0.9)
+	if !agent.handleGossipKpak(ctx, kpak) {
+		t.Fatal("Expected the first delivery to be accepted")
+	}
+	if agent.handleGossipKpak(ctx, kpak) {
+		t.Fatal("Expected the second delivery to change nothing")
+	}
+	// Unstamped k-paks from older agents are not timed
+	agent.handleGossipKpak(context.Background(), core.NewKpak("server2", "cpu", "10", "scout", 0.9))
+
+	var out strings.Builder
+	if err := agent.metrics.WritePrometheus(&out, monitoring.Gauges{}); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	text := out.String()
+	for _, want := range []string{
+		`sutra_gossip_propagation_seconds_bucket{peer="synapse-10.0.0.2-9091",le="0.025"} 0`,
+		`sutra_gossip_propagation_seconds_bucket{peer="synapse-10.0.0.2-9091",le="+Inf"} 1`,
+		`sutra_gossip_propagation_seconds_count{peer="synapse-10.0.0.2-9091"} 1`,
+		`sutra_gossip_duplicate_kpaks_total{peer="synapse-10.0.0.2-9091"} 1`,
+		`sutra_ingest_kpaks_total{source="scout",result="accepted"} 2`,
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Fatalf("Expected %q in metrics:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,463 @@
+// HTTP/JSON gateway in front of the gRPC API
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/logging"
+)
+
+// maxIngestBody bounds the body of one HTTP ingest request.
+const maxIngestBody = 32 << 20
+
+// watchKeepAlive is how often an idle event stream gets a comment, so proxies keep it open.
+const watchKeepAlive = 15 * time.Second
+
+// Metadata the gateway adds to its calls so the server sees the HTTP client
+// rather than the gateway's own loopback connection.
+const (
+	forwardedForHeader = "x-forwarded-for" // The HTTP client's address
+	gatewayTokenHeader = "sutra-gateway"   // Proves the call came from this agent's gateway
+)
+
+// gatewayJSON writes responses with the proto field names used in the OpenAPI spec.
+var gatewayJSON = protojson.MarshalOptions{UseProtoNames: true, EmitUnpopulated: true}
+
+// gateway serves the API as JSON over HTTP. Every request is made to the agent's
+// own gRPC server, so it is authenticated, gated and reconciled like any other.
+type gateway struct {
+	client v1.SynapseServiceClient
+	token  string // Sent with every call; see forwardedClient
+}
+
+// startGateway serves the HTTP/JSON gateway when an address is configured.
+func (a *Agent) startGateway() error {
+	if a.config.GatewayAddr == "" {
+		return nil
+	}
+
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return err
+	}
+	conn, err := grpc.NewClient(loopback(a.grpcAddr), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	listen, err := net.Listen("tcp", a.config.GatewayAddr)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	a.gatewayConn = conn
+	a.gatewayToken = hex.EncodeToString(token)
+	g := &gateway{client: v1.NewSynapseServiceClient(conn), token: a.gatewayToken}
+	a.gateway = &http.Server{Addr: listen.Addr().String(), Handler: g.handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		if err := a.gateway.Serve(listen); err != nil && err != http.ErrServerClosed {
+			logger.Error("HTTP gateway failed", logging.KeyError, err)
+		}
+	}()
+
+	logger.Info("HTTP gateway available", "url", "http://"+a.gateway.Addr+"/v1")
+	return nil
+}
+
+// stopGateway stops the gateway before the gRPC server, which waits for its connection.
+func (a *Agent) stopGateway() {
+	if a.gateway != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		a.gateway.Shutdown(ctx)
+		cancel()
+	}
+	if a.gatewayConn != nil {
+		a.gatewayConn.Close()
+	}
+}
+
+// loopback returns an address the gateway can dial for a listener bound to addr.
+func loopback(addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+func (g *gateway) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/kpaks", g.ingest)
+	mux.HandleFunc("GET /v1/query", g.query)
+	mux.HandleFunc("GET /v1/health", g.health)
+	mux.HandleFunc("GET /v1/peers", g.peers)
+	mux.HandleFunc("GET /v1/metrics", g.metrics)
+	mux.HandleFunc("GET /v1/watch", g.watch)
+	mux.HandleFunc("GET /v1/openapi.yaml", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(v1.OpenAPI)
+	})
+	return mux
+}
+
+// forwardedClient returns the HTTP client's address the gateway forwarded with a
+// call. It is only trusted on a connection from this host carrying the gateway's
+// token, so other clients can't pass themselves off as someone else.
+func (a *Agent) forwardedClient(ctx context.Context) (string, bool) {
+	if a.gatewayToken == "" {
+		return "", false
+	}
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil || !sameHost(p.Addr, p.LocalAddr) {
+		return "", false
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	token, forwarded := md.Get(gatewayTokenHeader), md.Get(forwardedForHeader)
+	if len(token) != 1 || len(forwarded) != 1 || subtle.ConstantTimeCompare([]byte(token[0]), []byte(a.gatewayToken)) != 1 {
+		return "", false
+	}
+	return forwarded[0], true
+}
+
+// sameHost reports whether a connection from remote to local stays on this host.
+func sameHost(remote, local net.Addr) bool {
+	host, _, err := net.SplitHostPort(remote.String())
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	if ip.IsLoopback() {
+		return true
+	}
+	if local == nil {
+		return false
+	}
+	localHost, _, err := net.SplitHostPort(local.String())
+	return err == nil && ip.Equal(net.ParseIP(localHost))
+}
+
+// outgoing forwards the caller's credentials and address to the gRPC server.
+func (g *gateway) outgoing(r *http.Request) context.Context {
+	ctx := r.Context()
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", authorization)
+	}
+	client := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(client); err == nil {
+		client = host
+	}
+	return metadata.AppendToOutgoingContext(ctx, forwardedForHeader, client, gatewayTokenHeader, g.token)
+}
+
+// ingest takes one k-pak, a JSON array of them, or newline-delimited JSON.
+func (g *gateway) ingest(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxIngestBody))
+	if err != nil {
+		writeGatewayError(w, status.Errorf(codes.InvalidArgument, "failed to read body: %v", err))
+		return
+	}
+	kpaks, err := decodeKpaks(body)
+	if err != nil {
+		writeGatewayError(w, status.Error(codes.InvalidArgument, err.Error()))
+		return
+	}
+
+	stream, err := g.client.Ingest(g.outgoing(r))
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	for _, kpak := range kpaks {
+		// The server ended the stream; CloseAndRecv returns why
+		if err := stream.Send(kpak); err != nil {
+			break
+		}
+	}
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeProto(w, http.StatusOK, resp)
+}
+
+// decodeKpaks reads the k-paks of an ingest request body.
+func decodeKpaks(body []byte) ([]*v1.Kpak, error) {
+	body = bytes.TrimSpace(body)
+	var raw []json.RawMessage
+	if len(body) > 0 && body[0] == '[' {
+		if err := json.Unmarshal(body, &raw); err != nil {
+			return nil, fmt.Errorf("invalid JSON array: %v", err)
+		}
+	} else {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		for {
+			var item json.RawMessage
+			if err := decoder.Decode(&item); err == io.EOF {
+				break
+			} else if err != nil {
+				return nil, fmt.Errorf("invalid JSON after %d k-pak(s): %v", len(raw), err)
+			}
+			raw = append(raw, item)
+		}
+	}
+	if len(raw) == 0 {
+		return nil, errors.New("no k-paks in request body")
+	}
+
+	kpaks := make([]*v1.Kpak, len(raw))
+	for i, item := range raw {
+		kpaks[i] = &v1.Kpak{}
+		if err := protojson.Unmarshal(item, kpaks[i]); err != nil {
+			return nil, fmt.Errorf("k-pak %d: %v", i+1, err)
+		}
+	}
+	return kpaks, nil
+}
+
+// query answers ?subject=&predicate=&min_confidence= with {"kpaks": [...]}.
+func (g *gateway) query(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	req := &v1.QueryRequest{Subject: params.Get("subject")}
+	if params.Has("predicate") {
+		predicate := params.Get("predicate")
+		req.Predicate = &predicate
+	}
+	if s := params.Get("min_confidence"); s != "" {
+		minConfidence, err := strconv.ParseFloat(s, 32)
+		if err != nil {
+			writeGatewayError(w, status.Errorf(codes.InvalidArgument, "invalid min_confidence %q", s))
+			return
+		}
+		value := float32(minConfidence)
+		req.MinConfidence = &value
+	}
+
+	stream, err := g.client.Query(g.outgoing(r), req)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	header, err := stream.Header()
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+
+	items := []json.RawMessage{}
+	for {
+		kpak, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		item, err := gatewayJSON.Marshal(kpak)
+		if err != nil {
+			writeGatewayError(w, err)
+			return
+		}
+		items = append(items, item)
+	}
+
+	// Answers from an agent that is not ready yet are flagged as on gRPC
+	for _, key := range []string{StaleHeader, StateHeader} {
+		if values := header.Get(key); len(values) > 0 {
+			w.Header().Set(key, values[0])
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string][]json.RawMessage{"kpaks": items})
+}
+
+// health answers 503 when the agent is unhealthy, so probes can use the status code alone.
+func (g *gateway) health(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.Health(g.outgoing(r), &v1.HealthRequest{})
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	code := http.StatusOK
+	if resp.Status == "unhealthy" {
+		code = http.StatusServiceUnavailable
+	}
+	writeProto(w, code, resp)
+}
+
+func (g *gateway) peers(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.GetPeers(g.outgoing(r), &v1.PeersRequest{})
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeProto(w, http.StatusOK, resp)
+}
+
+func (g *gateway) metrics(w http.ResponseWriter, r *http.Request) {
+	resp, err := g.client.GetMetrics(g.outgoing(r), &v1.MetricsRequest{})
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeProto(w, http.StatusOK, resp)
+}
+
+// watch streams truth changes for ?subject=&predicate= as Server-Sent Events,
+// one event per change named after its type.
+func (g *gateway) watch(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeGatewayError(w, status.Error(codes.Internal, "streaming is not supported"))
+		return
+	}
+
+	params := r.URL.Query()
+	req := &v1.WatchRequest{Subject: params.Get("subject")}
+	if params.Has("predicate") {
+		predicate := params.Get("predicate")
+		req.Predicate = &predicate
+	}
+
+	ctx, cancel := context.WithCancel(g.outgoing(r))
+	defer cancel()
+	stream, err := g.client.Watch(ctx, req)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	// A refused watch ends without headers; Recv returns why
+	if header, _ := stream.Header(); header == nil {
+		_, err := stream.Recv()
+		writeGatewayError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	changes := make(chan *v1.TruthChange)
+	ended := make(chan error, 1)
+	go func() {
+		for {
+			change, err := stream.Recv()
+			if err != nil {
+				ended <- err
+				return
+			}
+			select {
+			case changes <- change:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	keepAlive := time.NewTicker(watchKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case change := <-changes:
+			data, err := gatewayJSON.Marshal(change)
+			if err != nil {
+				logger.Warn("Failed to encode truth change", logging.KeySPID, change.Spid, logging.KeyError, err)
+				continue
+			}
+			fmt.Fprintf(w, "event: %s\ndata: %s\n\n", change.Type, data)
+		case err := <-ended:
+			// The status is already sent, so the reason goes out as a last event
+			st := status.Convert(err)
+			data, _ := json.Marshal(map[string]string{"code": st.Code().String(), "message": st.Message()})
+			fmt.Fprintf(w, "event: error\ndata: %s\n\n", data)
+			flusher.Flush()
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case <-r.Context().Done():
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func writeProto(w http.ResponseWriter, code int, message proto.Message) {
+	data, err := gatewayJSON.Marshal(message)
+	if err != nil {
+		writeGatewayError(w, err)
+		return
+	}
+	writeJSON(w, code, json.RawMessage(data))
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeGatewayError writes a gRPC error as {"code": ..., "message": ...} with the matching HTTP status.
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeJSON(w, httpStatus(st.Code()), map[string]string{"code": st.Code().String(), "message": st.Message()})
+}
+
+// httpStatus maps a gRPC status code to the HTTP status with the same meaning.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499 // Client closed the request
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
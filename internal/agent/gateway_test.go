@@ -0,0 +1,420 @@
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"gopkg.in/yaml.v3"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/auth"
+)
+
+// startGatewayAgent starts an agent with the gateway on a free port and returns its base URL.
+func startGatewayAgent(t *testing.T, config Config) (*Agent, string) {
+	t.Helper()
+	tempDir, err := os.MkdirTemp("", "agent_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(tempDir) })
+
+	config.Host = "127.0.0.1"
+	config.WALPath = filepath.Join(tempDir, "test.log")
+	config.GatewayAddr = "127.0.0.1:0"
+	agent, err := NewAgent(config)
+	if err != nil {
+		t.Fatalf("Failed to create agent: %v", err)
+	}
+	if err := agent.Start(); err != nil {
+		t.Fatalf("Failed to start agent: %v", err)
+	}
+	t.Cleanup(func() { agent.Shutdown() })
+	return agent, "http://" + agent.gateway.Addr
+}
+
+// call makes a gateway request and decodes the JSON response into out.
+func call(t *testing.T, method, url, token, contentType, body string, out interface{}) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, _ := io.ReadAll(resp.Body)
+	if out != nil {
+		if err := json.Unmarshal(data, out); err != nil {
+			t.Fatalf("Invalid JSON from %s %s: %v\n%s", method, url, err, data)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestGateway(t *testing.T) {
+	_, base := startGatewayAgent(t, Config{})
+
+	var ingest struct {
+		Accepted int      `json:"accepted"`
+		Rejected int      `json:"rejected"`
+		Errors   []string `json:"errors"`
+	}
+
+	// One k-pak
+	code := call(t, "POST", base+"/v1/kpaks", "", "application/json",
+		`{"subject": "server1", "predicate": "cpu", "object": "10", "source": "scout", "confidence": 0.9}`, &ingest)
+	if code != http.StatusOK || ingest.Accepted != 1 {
+		t.Fatalf("Expected one accepted k-pak, got %d %+v", code, ingest)
+	}
+
+	// A JSON array, typed values included
+	code = call(t, "POST", base+"/v1/kpaks", "", "application/json", `[
+		{"subject": "server1", "predicate": "cores", "value": {"int_value": 16}, "source": "inventory", "confidence": 0.9},
+		{"subject": "server1", "predicate": "load", "value": {"float_value": 0.5}, "source": "inventory", "confidence": 0.9}
+	]`, &ingest)
+	if code != http.StatusOK || ingest.Accepted != 2 {
+		t.Fatalf("Expected two accepted k-paks, got %d %+v", code, ingest)
+	}
+
+	// Newline-delimited JSON goes through the same validation as gRPC
+	code = call(t, "POST", base+"/v1/kpaks", "", "application/x-ndjson",
+		`{"subject": "server2", "predicate": "cpu", "object": "20", "source": "scout", "confidence": 0.9}
+{"subject": "server2", "predicate": "load", "value": {"float_value": "NaN"}, "source": "scout", "confidence": 0.9}
+`, &ingest)
+	if code != http.StatusOK || ingest.Accepted != 1 || ingest.Rejected != 1 || len(ingest.Errors) != 1 {
+		t.Fatalf("Expected one accepted and one rejected k-pak, got %d %+v", code, ingest)
+	}
+
+	var failure struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	code = call(t, "POST", base+"/v1/kpaks", "", "application/json", `{"subject": "server3", "confidence": "high"}`, &failure)
+	if code != http.StatusBadRequest || failure.Code != "InvalidArgument" {
+		t.Fatalf("Expected 400 InvalidArgument for a malformed k-pak, got %d %+v", code, failure)
+	}
+	code = call(t, "POST", base+"/v1/kpaks", "", "application/json", "  ", &failure)
+	if code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an empty body, got %d", code)
+	}
+
+	var query struct {
+		Kpaks []struct {
+			Subject   string `json:"subject"`
+			Predicate string `json:"predicate"`
+			Object    string `json:"object"`
+			Timestamp string `json:"timestamp"`
+			Value     struct {
+				IntValue string `json:"int_value"`
+			} `json:"value"`
+		} `json:"kpaks"`
+	}
+	code = call(t, "GET", base+"/v1/query?subject=server1&predicate=cores", "", "", "", &query)
+	if code != http.StatusOK || len(query.Kpaks) != 1 || query.Kpaks[0].Value.IntValue != "16" || query.Kpaks[0].Timestamp == "" {
+		t.Fatalf("Expected the typed cores fact, got %d %+v", code, query)
+	}
+	code = call(t, "GET", base+"/v1/query?subject=server1", "", "", "", &query)
+	if code != http.StatusOK || len(query.Kpaks) != 3 {
+		t.Fatalf("Expected three facts about server1, got %d %+v", code, query)
+	}
+	code = call(t, "GET", base+"/v1/query?subject=nobody", "", "", "", &query)
+	if code != http.StatusOK || query.Kpaks == nil || len(query.Kpaks) != 0 {
+		t.Fatalf("Expected an empty list for an unknown subject, got %d %+v", code, query)
+	}
+	code = call(t, "GET", base+"/v1/query?subject=server1&min_confidence=high", "", "", "", &failure)
+	if code != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an invalid min_confidence, got %d", code)
+	}
+
+	var health struct {
+		Status string `json:"status"`
+		State  string `json:"state"`
+		Checks []struct {
+			Name string `json:"name"`
+		} `json:"checks"`
+	}
+	code = call(t, "GET", base+"/v1/health", "", "", "", &health)
+	if code != http.StatusOK || health.State != string(StateReady) || len(health.Checks) == 0 {
+		t.Fatalf("Expected a ready agent with its checks, got %d %+v", code, health)
+	}
+
+	var peers struct {
+		Peers []struct {
+			Name        string `json:"name"`
+			GRPCAddress string `json:"grpc_address"`
+		} `json:"peers"`
+	}
+	code = call(t, "GET", base+"/v1/peers", "", "", "", &peers)
+	if code != http.StatusOK || len(peers.Peers) != 1 || peers.Peers[0].GRPCAddress == "" {
+		t.Fatalf("Expected the agent itself as the only peer, got %d %+v", code, peers)
+	}
+
+	var metrics struct {
+		TotalKpaks int `json:"total_kpaks"`
+	}
+	code = call(t, "GET", base+"/v1/metrics", "", "", "", &metrics)
+	if code != http.StatusOK || metrics.TotalKpaks != 4 {
+		t.Fatalf("Expected 4 k-paks in the metrics, got %d %+v", code, metrics)
+	}
+
+	resp, err := http.Get(base + "/v1/openapi.yaml")
+	if err != nil {
+		t.Fatalf("Failed to fetch the OpenAPI spec: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "application/yaml" {
+		t.Fatalf("Expected the OpenAPI spec, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+}
+
+func TestGateway_Auth(t *testing.T) {
+	_, base := startGatewayAgent(t, Config{
+		Auth: auth.Config{
+			Enabled: true,
+			Tokens: []auth.Token{
+				{Name: "dashboard", Token: "read-secret", Role: auth.RoleReader},
+				{Name: "scout", Token: "write-secret", Role: auth.RoleWriter, Sources: []string{"scout-*"}},
+			},
+		},
+	})
+	kpak := `{"subject": "server1", "predicate": "cpu", "object": "10", "source": "scout-1", "confidence": 0.9}`
+
+	var failure struct {
+		Code string `json:"code"`
+	}
+	if code := call(t, "GET", base+"/v1/query?subject=server1", "", "", "", &failure); code != http.StatusUnauthorized || failure.Code != "Unauthenticated" {
+		t.Fatalf("Expected 401 without a token, got %d %+v", code, failure)
+	}
+	if code := call(t, "POST", base+"/v1/kpaks", "read-secret", "application/json", kpak, &failure); code != http.StatusForbidden {
+		t.Fatalf("Expected 403 for a reader ingesting, got %d", code)
+	}
+
+	var ingest struct {
+		Accepted int `json:"accepted"`
+	}
+	if code := call(t, "POST", base+"/v1/kpaks", "write-secret", "application/json", kpak, &ingest); code != http.StatusOK || ingest.Accepted != 1 {
+		t.Fatalf("Expected the writer's k-pak to be accepted, got %d %+v", code, ingest)
+	}
+	if code := call(t, "GET", base+"/v1/query?subject=server1", "read-secret", "", "", nil); code != http.StatusOK {
+		t.Fatalf("Expected the reader to query, got %d", code)
+	}
+	if code := call(t, "GET", base+"/v1/watch?subject=server1", "", "", "", &failure); code != http.StatusUnauthorized || failure.Code != "Unauthenticated" {
+		t.Fatalf("Expected 401 for a watch without a token, got %d %+v", code, failure)
+	}
+	if code := call(t, "GET", base+"/v1/openapi.yaml", "", "", "", nil); code != http.StatusOK {
+		t.Fatalf("Expected the spec to be public, got %d", code)
+	}
+}
+
+// readEvent reads the next Server-Sent Event, skipping comments.
+func readEvent(t *testing.T, reader *bufio.Reader) (string, string) {
+	t.Helper()
+	var event, data string
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Event stream ended: %v", err)
+		}
+		line = strings.TrimSuffix(line, "\n")
+		switch {
+		case line == "" && event != "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestGateway_ForwardsClientAddress(t *testing.T) {
+	agent, _ := startGatewayAgent(t, Config{})
+	g := &gateway{token: agent.gatewayToken}
+
+	r := httptest.NewRequest(http.MethodPost, "/v1/kpaks", nil)
+	r.RemoteAddr = "203.0.113.7:51234"
+	forwarded, _ := metadata.FromOutgoingContext(g.outgoing(r))
+
+	// incoming is a call carrying md over a connection from remote to local
+	incoming := func(remote, local string, md metadata.MD) context.Context {
+		ctx := peer.NewContext(context.Background(), &peer.Peer{
+			Addr:      &net.TCPAddr{IP: net.ParseIP(remote), Port: 40000},
+			LocalAddr: &net.TCPAddr{IP: net.ParseIP(local), Port: 9090},
+		})
+		return metadata.NewIncomingContext(ctx, md)
+	}
+
+	if client := agent.clientID(incoming("127.0.0.1", "127.0.0.1", forwarded)); client != "203.0.113.7" {
+		t.Fatalf("Expected the HTTP client's address, got %q", client)
+	}
+	if client := agent.clientID(incoming("10.0.0.1", "10.0.0.1", forwarded)); client != "203.0.113.7" {
+		t.Fatalf("Expected the gateway to be trusted when dialing the host's own address, got %q", client)
+	}
+
+	// Anyone else claiming to forward for a client is identified by their own address
+	spoofed := metadata.Pairs(forwardedForHeader, "203.0.113.7", gatewayTokenHeader, "guess")
+	if client := agent.clientID(incoming("127.0.0.1", "127.0.0.1", spoofed)); client != "127.0.0.1" {
+		t.Fatalf("Expected a wrong gateway token to be ignored, got %q", client)
+	}
+	if client := agent.clientID(incoming("10.0.0.5", "10.0.0.1", forwarded)); client != "10.0.0.5" {
+		t.Fatalf("Expected a remote connection not to be trusted, got %q", client)
+	}
+}
+
+func TestGateway_Watch(t *testing.T) {
+	agent, base := startGatewayAgent(t, Config{})
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Get(base + "/v1/watch?subject=server1&predicate=cpu")
+	if err != nil {
+		t.Fatalf("Failed to watch: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %s", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	reader := bufio.NewReader(resp.Body)
+
+	// Only the first and last k-paks match the filter
+	for _, kpak := range []string{
+		`{"subject": "server1", "predicate": "cpu", "object": "10", "source": "scout", "confidence": 0.8}`,
+		`{"subject": "server1", "predicate": "memory", "object": "4096", "source": "scout", "confidence": 0.8}`,
+		`{"subject": "server2", "predicate": "cpu", "object": "20", "source": "scout", "confidence": 0.8}`,
+		`{"subject": "server1", "predicate": "cpu", "object": "30", "source": "monitor", "confidence": 0.95}`,
+	} {
+		if code := call(t, "POST", base+"/v1/kpaks", "", "application/json", kpak, nil); code != http.StatusOK {
+			t.Fatalf("Failed to ingest %s: %d", kpak, code)
+		}
+	}
+
+	var change struct {
+		Type      string `json:"type"`
+		Subject   string `json:"subject"`
+		Predicate string `json:"predicate"`
+		Previous  *struct {
+			Object string `json:"object"`
+		} `json:"previous"`
+		Current *struct {
+			Object string `json:"object"`
+		} `json:"current"`
+	}
+	for _, want := range []struct{ previous, current string }{{"", "10"}, {"10", "30"}} {
+		event, data := readEvent(t, reader)
+		if err := json.Unmarshal([]byte(data), &change); err != nil {
+			t.Fatalf("Invalid change %q: %v", data, err)
+		}
+		if event != "accepted" || change.Type != "accepted" || change.Subject != "server1" || change.Predicate != "cpu" {
+			t.Fatalf("Expected an accepted change to server1/cpu, got %s %s", event, data)
+		}
+		if (want.previous == "") != (change.Previous == nil) || (change.Previous != nil && change.Previous.Object != want.previous) {
+			t.Fatalf("Expected previous truth %q, got %s", want.previous, data)
+		}
+		if change.Current == nil || change.Current.Object != want.current {
+			t.Fatalf("Expected current truth %q, got %s", want.current, data)
+		}
+	}
+
+	// Shutting down ends the stream with the reason
+	agent.Shutdown()
+	if event, data := readEvent(t, reader); event != "error" || !strings.Contains(data, "Unavailable") {
+		t.Fatalf("Expected an Unavailable error event on shutdown, got %s %s", event, data)
+	}
+}
+
+func TestWatchers_DropLaggingWatcher(t *testing.T) {
+	w := newWatchers()
+	predicate := "cpu"
+	slow, _ := w.add("server1", &predicate)
+	other, _ := w.add("server2", nil)
+
+	for i := 0; i <= watchBuffer; i++ {
+		w.publish(&v1.TruthChange{Subject: "server1", Predicate: "cpu"})
+	}
+	if !slow.lagged || !w.active() {
+		t.Fatal("Expected only the watcher that fell behind to be dropped")
+	}
+	for range slow.changes {
+	}
+	if len(other.changes) != 0 {
+		t.Fatal("Expected no changes for a watcher of another subject")
+	}
+
+	w.remove(slow) // Already dropped
+	w.close()
+	if _, ok := <-other.changes; ok {
+		t.Fatal("Expected close to end every watch")
+	}
+	if _, err := w.add("", nil); err != errWatchClosed {
+		t.Fatalf("Expected new watches to be refused after close, got %v", err)
+	}
+}
+
+// TestOpenAPISpec keeps the checked-in spec in line with the gateway and the proto.
+func TestOpenAPISpec(t *testing.T) {
+	var spec struct {
+		Paths      map[string]map[string]interface{} `yaml:"paths"`
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]interface{} `yaml:"properties"`
+			} `yaml:"schemas"`
+		} `yaml:"components"`
+	}
+	if err := yaml.Unmarshal(v1.OpenAPI, &spec); err != nil {
+		t.Fatalf("Invalid OpenAPI spec: %v", err)
+	}
+
+	for _, route := range []string{"POST /v1/kpaks", "GET /v1/query", "GET /v1/health", "GET /v1/peers", "GET /v1/metrics", "GET /v1/watch", "GET /v1/openapi.yaml"} {
+		method, path, _ := strings.Cut(route, " ")
+		if _, ok := spec.Paths[path][strings.ToLower(method)]; !ok {
+			t.Errorf("Route %s is not in the spec", route)
+		}
+	}
+
+	for _, message := range []protoreflect.ProtoMessage{
+		&v1.Kpak{}, &v1.Value{}, &v1.IngestResponse{}, &v1.HealthResponse{}, &v1.HealthCheck{},
+		&v1.PeersResponse{}, &v1.PeerInfo{}, &v1.MetricsResponse{}, &v1.TruthChange{},
+	} {
+		descriptor := message.ProtoReflect().Descriptor()
+		name := string(descriptor.Name())
+		schema, ok := spec.Components.Schemas[name]
+		if !ok {
+			t.Errorf("Schema %s is not in the spec", name)
+			continue
+		}
+		var want, got []string
+		for i := 0; i < descriptor.Fields().Len(); i++ {
+			want = append(want, string(descriptor.Fields().Get(i).Name()))
+		}
+		for property := range schema.Properties {
+			got = append(got, property)
+		}
+		sort.Strings(want)
+		sort.Strings(got)
+		if strings.Join(want, ",") != strings.Join(got, ",") {
+			t.Errorf("Schema %s has properties %v, proto has fields %v", name, got, want)
+		}
+	}
+}
@@ -3,13 +3,17 @@
 package agent
 
 import (
-	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Pew-X/sutra/internal/logging"
 	"github.com/Pew-X/sutra/internal/reconciliation"
 )
 
+// gcLogger is the garbage collector's logger.
+var gcLogger = logging.For("gc")
+
 // GarbageCollector manages automatic cleanup of expired k-paks.
 type GarbageCollector struct {
 	engine          *reconciliation.Engine
@@ -20,6 +24,8 @@ type GarbageCollector struct {
 	wg              sync.WaitGroup
 	mutex           sync.Mutex
 	running         bool
+	onCollect       func(removed int) // Called after every run (nil = none)
+	lastRun         atomic.Int64      // UnixNano end of the last run, or when the collector started
 }
 
 // NewGarbageCollector creates a new garbage collector.
@@ -46,6 +52,7 @@ func (gc *GarbageCollector) Start() {
 	}
 
 	gc.running = true
+	gc.lastRun.Store(time.Now().UnixNano())
 	// Reinitialize stopChan if it was closed from a previous Stop()
 	gc.stopChan = make(chan struct{})
 	gc.ticker = time.NewTicker(time.Duration(gc.intervalSeconds) * time.Second)
@@ -53,7 +60,7 @@ func (gc *GarbageCollector) Start() {
 	gc.wg.Add(1)
 	go gc.run()
 
-	log.Printf("Garbage collector started with interval %d seconds", gc.intervalSeconds)
+	gcLogger.Info("Garbage collector started", "interval_seconds", gc.intervalSeconds)
 }
 
 // Stop gracefully stops the garbage collection process.
@@ -73,7 +80,7 @@ func (gc *GarbageCollector) Stop() {
 	}
 
 	gc.wg.Wait()
-	log.Println("Garbage collector stopped")
+	gcLogger.Info("Garbage collector stopped")
 }
 
 // run is the main garbage collection loop.
@@ -95,10 +102,26 @@ func (gc *GarbageCollector) collectGarbage() {
 	start := time.Now()
 	removed := gc.engine.RemoveExpiredKpaks()
 	duration := time.Since(start)
+	if gc.onCollect != nil {
+		gc.onCollect(removed)
+	}
+	gc.lastRun.Store(time.Now().UnixNano())
 
 	if removed > 0 {
-		log.Printf("Garbage collection completed: removed %d expired k-paks in %v", removed, duration)
+		gcLogger.Info("Garbage collection completed", "removed", removed, "duration", duration)
+	}
+}
+
+// Lag returns how many collection intervals have passed since the last run; above
+// one means runs are late. It is zero while the collector is stopped.
+func (gc *GarbageCollector) Lag() float64 {
+	gc.mutex.Lock()
+	defer gc.mutex.Unlock()
+
+	if !gc.running {
+		return 0
 	}
+	return time.Since(time.Unix(0, gc.lastRun.Load())).Seconds() / float64(gc.intervalSeconds)
 }
 
 // GetStats returns garbage collection statistics.
@@ -200,3 +200,23 @@ func TestGarbageCollectorConcurrency(t *testing.T) {
 		}
 	})
 }
+
+func TestGarbageCollectorLag(t *testing.T) {
+	gc := NewGarbageCollector(reconciliation.NewEngine(), 1, true)
+	if gc.Lag() != 0 {
+		t.Fatal("Expected no lag while stopped")
+	}
+
+	gc.Start()
+	defer gc.Stop()
+
+	gc.lastRun.Store(time.Now().Add(-3 * time.Second).UnixNano())
+	if lag := gc.Lag(); lag < 2.9 || lag > 3.5 {
+		t.Fatalf("Expected about three intervals of lag, got %v", lag)
+	}
+
+	gc.collectGarbage()
+	if lag := gc.Lag(); lag > 0.5 {
+		t.Fatalf("Expected a run to reset the lag, got %v", lag)
+	}
+}
@@ -0,0 +1,149 @@
+// Health checks and the standard gRPC health service
+
+package agent
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/health"
+)
+
+// healthInterval is how often the gRPC health service is brought up to date.
+const healthInterval = 10 * time.Second
+
+// ingestErrorWindow is how far back the ingest error ratio looks.
+const ingestErrorWindow = 5 * time.Minute
+
+// registerHealthChecks adds the built-in checks to the agent's registry.
+func (a *Agent) registerHealthChecks(config health.Config) {
+	a.health.Register("process", func() (health.Status, string) {
+		status, message := a.metrics.ProcessHealth()
+		return health.Status(status), message
+	})
+
+	a.health.Register("lifecycle", func() (health.Status, string) {
+		state, since, unsynced := a.lifecycleStatus()
+		message := fmt.Sprintf("%s for %v", state, since.Round(time.Second))
+		switch {
+		case state == StateDraining:
+			return health.StatusUnhealthy, message
+		case state != StateReady:
+			return health.StatusDegraded, message
+		case unsynced:
+			return health.StatusDegraded, message + ", but no peer has been reached; answers may be stale"
+		}
+		return health.StatusHealthy, message
+	})
+
+	a.health.Register("wal", func() (health.Status, string) {
+		lastSync, err := a.wal.Health()
+		if err != nil {
+			return health.StatusUnhealthy, err.Error()
+		}
+		ms := float64(lastSync) / float64(time.Millisecond)
+		return health.Above(ms, config.WALFsyncDegradedMs, config.WALFsyncUnhealthyMs), fmt.Sprintf("writable, last fsync took %.1fms", ms)
+	})
+
+	a.health.Register("gossip", func() (health.Status, string) {
+		expected := config.ExpectedMembers
+		if expected == 0 {
+			expected = len(a.config.JoinPeers) + 1
+		}
+		members := a.gossip.GetMembers()
+		if members == nil {
+			return health.StatusUnhealthy, "gossip is not running"
+		}
+		alive := 0
+		for _, member := range members {
+			if member.Alive() {
+				alive++
+			}
+		}
+		message := fmt.Sprintf("%d of %d expected members alive", alive, expected)
+		switch {
+		case alive >= expected:
+			return health.StatusHealthy, message
+		case alive <= 1:
+			return health.StatusUnhealthy, message + ", cut off from the mesh"
+		}
+		return health.StatusDegraded, message
+	})
+
+	a.health.Register("gc", func() (health.Status, string) {
+		if !a.config.GCEnabled {
+			return health.StatusHealthy, "garbage collection is disabled"
+		}
+		lag := a.gc.Lag()
+		return health.Above(lag, config.GCLagDegraded, config.GCLagUnhealthy), fmt.Sprintf("last run %.1f intervals ago", lag)
+	})
+
+	a.health.Register("ingest", func() (health.Status, string) {
+		ratio, total := a.metrics.IngestErrorRatio(ingestErrorWindow)
+		message := fmt.Sprintf("%.0f%% of %d k-paks failed in the last %v", ratio*100, total, ingestErrorWindow)
+		if total < config.IngestErrorMinSamples {
+			return health.StatusHealthy, message
+		}
+		return health.Above(ratio, config.IngestErrorRatioDegraded, config.IngestErrorRatioUnhealthy), message
+	})
+
+	a.health.Register("snapshot", func() (health.Status, string) {
+		age := time.Since(a.wal.LastSave())
+		return health.Above(age.Minutes(), config.SnapshotAgeDegradedMinutes, config.SnapshotAgeUnhealthyMinutes),
+			fmt.Sprintf("last record saved to the WAL %v ago", age.Round(time.Second))
+	})
+
+	a.health.Register("disk", func() (health.Status, string) {
+		free, total, err := health.DiskUsage(filepath.Dir(a.wal.Path()))
+		if errors.Is(err, health.ErrDiskUsageUnsupported) {
+			return health.StatusHealthy, err.Error()
+		}
+		if err != nil || total == 0 {
+			return health.StatusDegraded, fmt.Sprintf("cannot read free space: %v", err)
+		}
+		percent := float64(free) / float64(total) * 100
+		return health.Below(percent, config.DiskFreeDegradedPercent, config.DiskFreeUnhealthyPercent),
+			fmt.Sprintf("%.1f%% free (%d MB) on the WAL's disk", percent, free/(1024*1024))
+	})
+}
+
+// watchHealth keeps the gRPC health service in line with the checks until stop is closed.
+func (a *Agent) watchHealth(stop chan struct{}) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+
+	for {
+		a.updateServingStatus()
+		select {
+		case <-ticker.C:
+		case <-stop:
+			return
+		}
+	}
+}
+
+// updateServingStatus runs the checks and tells load balancers whether to send traffic.
+// Degraded agents keep serving once ready; unhealthy ones do not.
+func (a *Agent) updateServingStatus() {
+	report := a.health.Run()
+	serving := healthpb.HealthCheckResponse_SERVING
+	if report.Status == health.StatusUnhealthy || a.State() != StateReady {
+		serving = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	a.grpcHealth.SetServingStatus("", serving)
+	a.grpcHealth.SetServingStatus(v1.SynapseService_ServiceDesc.ServiceName, serving)
+}
+
+// newGRPCHealthServer creates the grpc.health.v1 service, not serving until the first update.
+func newGRPCHealthServer() *grpchealth.Server {
+	server := grpchealth.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	server.SetServingStatus(v1.SynapseService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	return server
+}
@@ -0,0 +1,193 @@
+// Lifecycle states and readiness gating
+
+package agent
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+)
+
+// State is where an agent is in its lifecycle.
+type State string
+
+const (
+	StateStarting  State = "starting"  // Created, not started yet
+	StateReplaying State = "replaying" // Loading knowledge from the WAL
+	StateSyncing   State = "syncing"   // Serving, but not yet caught up on the mesh's claims
+	StateReady     State = "ready"
+	StateDraining  State = "draining" // Shutting down
+)
+
+// How queries are answered before the agent is ready.
+const (
+	UnreadyQueriesFlag   = "flag"   // Answer, marking the response stale (default)
+	UnreadyQueriesRefuse = "refuse" // Fail with Unavailable
+	UnreadyQueriesServe  = "serve"  // Answer as if ready
+)
+
+// Response headers set on queries answered before the agent is ready.
+const (
+	StaleHeader = "sutra-stale" // "true"
+	StateHeader = "sutra-state" // The agent's state
+)
+
+// defaultSyncTimeout is how long an agent waits for the mesh before becoming ready anyway.
+const defaultSyncTimeout = 30 * time.Second
+
+// syncPollInterval is how often a syncing agent checks whether it has reached the mesh.
+const syncPollInterval = 100 * time.Millisecond
+
+// lifecycle holds the agent's state and when it was entered.
+type lifecycle struct {
+	state    State
+	since    time.Time
+	timedOut bool // Became ready without reaching the mesh
+	mutex    sync.RWMutex
+}
+
+// State returns the agent's lifecycle state.
+func (a *Agent) State() State {
+	a.lifecycle.mutex.RLock()
+	defer a.lifecycle.mutex.RUnlock()
+	return a.lifecycle.state
+}
+
+// setState moves the agent to a new state and tells load balancers at once.
+func (a *Agent) setState(state State) {
+	a.lifecycle.mutex.Lock()
+	previous := a.lifecycle.state
+	a.lifecycle.state = state
+	a.lifecycle.since = time.Now()
+	a.lifecycle.mutex.Unlock()
+
+	if previous != state {
+		logger.Info("Agent state changed", "from", previous, "to", state)
+	}
+	a.updateServingStatus()
+}
+
+// syncTimeout returns how long to wait for the mesh before becoming ready.
+func (a *Agent) syncTimeout() time.Duration {
+	if a.config.SyncTimeoutSeconds > 0 {
+		return time.Duration(a.config.SyncTimeoutSeconds) * time.Second
+	}
+	return defaultSyncTimeout
+}
+
+// awaitSync waits for the mesh, or for the sync timeout, then makes the agent ready.
+func (a *Agent) awaitSync(stop chan struct{}) {
+	deadline := time.NewTimer(a.syncTimeout())
+	defer deadline.Stop()
+	ticker := time.NewTicker(syncPollInterval)
+	defer ticker.Stop()
+
+	for {
+		if a.gossip.Synced() {
+			a.setState(StateReady)
+			return
+		}
+		select {
+		case <-ticker.C:
+		case <-deadline.C:
+			logger.Warn("No peer reached; answering queries without the mesh", "timeout", a.syncTimeout())
+			a.lifecycle.mutex.Lock()
+			a.lifecycle.timedOut = true
+			a.lifecycle.mutex.Unlock()
+			a.setState(StateReady)
+			return
+		case <-stop:
+			return
+		}
+	}
+}
+
+// lifecycleStatus returns the state, how long the agent has been in it, and whether
+// it became ready without reaching the mesh and still has not.
+func (a *Agent) lifecycleStatus() (State, time.Duration, bool) {
+	a.lifecycle.mutex.RLock()
+	defer a.lifecycle.mutex.RUnlock()
+	return a.lifecycle.state, time.Since(a.lifecycle.since), a.lifecycle.timedOut && !a.gossip.Synced()
+}
+
+// serveReadiness answers HTTP readiness probes: 200 once ready, 503 before and while draining.
+func (a *Agent) serveReadiness(w http.ResponseWriter, r *http.Request) {
+	state := a.State()
+	if state != StateReady {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	fmt.Fprintln(w, state)
+}
+
+// readinessGated reports whether an RPC reads knowledge that may be stale before the agent is ready.
+func readinessGated(method string) bool {
+	switch method {
+	case v1.SynapseService_Query_FullMethodName,
+		v1.SynapseService_ListSchemas_FullMethodName,
+		v1.SynapseService_Conflicts_FullMethodName,
+		v1.SynapseService_Anomalies_FullMethodName,
+		v1.SynapseService_ListReview_FullMethodName:
+		return true
+	}
+	return false
+}
+
+// gateQuery refuses or flags a query received before the agent is ready.
+func (a *Agent) gateQuery(method string, setHeader func(metadata.MD) error) error {
+	if !readinessGated(method) {
+		return nil
+	}
+	state := a.State()
+	if state == StateReady {
+		return nil
+	}
+
+	switch a.config.UnreadyQueries {
+	case UnreadyQueriesServe:
+		return nil
+	case UnreadyQueriesRefuse:
+		return status.Errorf(codes.Unavailable, "agent is %s and not ready to answer queries", state)
+	}
+	return setHeader(metadata.Pairs(StaleHeader, "true", StateHeader, string(state)))
+}
+
+// readinessUnaryInterceptor applies gateQuery to unary RPCs.
+func (a *Agent) readinessUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		err := a.gateQuery(info.FullMethod, func(md metadata.MD) error {
+			return grpc.SetHeader(ctx, md)
+		})
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// readinessStreamInterceptor applies gateQuery to streaming RPCs.
+func (a *Agent) readinessStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.gateQuery(info.FullMethod, ss.SetHeader); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+// validateUnreadyQueries checks the unready_queries setting.
+func validateUnreadyQueries(mode string) error {
+	switch mode {
+	case "", UnreadyQueriesFlag, UnreadyQueriesRefuse, UnreadyQueriesServe:
+		return nil
+	}
+	return fmt.Errorf("unknown unready_queries mode %q (want flag, refuse or serve)", mode)
+}
@@ -0,0 +1,166 @@
+// Live truth changes for Watch streams
+
+package agent
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Pew-X/sutra/api/v1"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+)
+
+// watchBuffer is how many changes a watcher may fall behind before it is dropped.
+const watchBuffer = 256
+
+// errWatchClosed is returned for watches started while the agent shuts down.
+var errWatchClosed = errors.New("agent is shutting down")
+
+// watcher receives the changes matching its filter until its channel is closed.
+type watcher struct {
+	subject   string  // Empty matches every subject
+	predicate *string // Nil matches every predicate
+	changes   chan *v1.TruthChange
+	lagged    bool // Dropped for falling behind; guarded by watchers.mutex
+}
+
+func (w *watcher) matches(change *v1.TruthChange) bool {
+	if w.subject != "" && w.subject != change.Subject {
+		return false
+	}
+	return w.predicate == nil || *w.predicate == change.Predicate
+}
+
+// watchers fans the engine's truth changes out to Watch streams. A watcher that
+// falls behind is dropped rather than slowing down reconciliation.
+type watchers struct {
+	mutex  sync.Mutex
+	set    map[*watcher]struct{}
+	closed bool
+}
+
+func newWatchers() *watchers {
+	return &watchers{set: make(map[*watcher]struct{})}
+}
+
+// add registers a watcher for changes about subject and, if set, predicate.
+func (w *watchers) add(subject string, predicate *string) (*watcher, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.closed {
+		return nil, errWatchClosed
+	}
+	watcher := &watcher{subject: subject, predicate: predicate, changes: make(chan *v1.TruthChange, watchBuffer)}
+	w.set[watcher] = struct{}{}
+	return watcher, nil
+}
+
+// remove unregisters a watcher. It is safe to call after the watcher was dropped.
+func (w *watchers) remove(watcher *watcher) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if _, ok := w.set[watcher]; ok {
+		delete(w.set, watcher)
+		close(watcher.changes)
+	}
+}
+
+// active reports whether anyone is watching, so changes are only converted when needed.
+func (w *watchers) active() bool {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return len(w.set) > 0
+}
+
+// publish hands a change to every matching watcher without blocking.
+func (w *watchers) publish(change *v1.TruthChange) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for watcher := range w.set {
+		if !watcher.matches(change) {
+			continue
+		}
+		select {
+		case watcher.changes <- change:
+		default:
+			watcher.lagged = true
+			delete(w.set, watcher)
+			close(watcher.changes)
+		}
+	}
+}
+
+// close ends every watch and refuses new ones.
+func (w *watchers) close() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.closed = true
+	for watcher := range w.set {
+		delete(w.set, watcher)
+		close(watcher.changes)
+	}
+}
+
+// publishChange passes a truth change from the engine on to watchers.
+func (a *Agent) publishChange(change reconciliation.TruthChange) {
+	if !a.watchers.active() {
+		return
+	}
+
+	event := &v1.TruthChange{
+		Type:      string(change.Type),
+		Spid:      change.SPID,
+		Timestamp: time.Now().Unix(),
+	}
+	if change.Previous != nil {
+		event.Previous = a.kpakToProto(change.Previous)
+		event.Subject, event.Predicate = change.Previous.Subject, change.Previous.Predicate
+	}
+	if change.Current != nil {
+		event.Current = a.kpakToProto(change.Current)
+		event.Subject, event.Predicate = change.Current.Subject, change.Current.Predicate
+	}
+	a.watchers.publish(event)
+}
+
+// Watch streams the truth changes matching the request until the client goes away.
+func (a *Agent) Watch(req *v1.WatchRequest, stream v1.SynapseService_WatchServer) error {
+	watcher, err := a.watchers.add(req.Subject, req.Predicate)
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer a.watchers.remove(watcher)
+
+	// Send the headers now so clients know the watch is in place
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case change, ok := <-watcher.changes:
+			if !ok {
+				a.watchers.mutex.Lock()
+				lagged := watcher.lagged
+				a.watchers.mutex.Unlock()
+				if lagged {
+					return status.Errorf(codes.ResourceExhausted, "watcher fell more than %d changes behind", watchBuffer)
+				}
+				return status.Error(codes.Unavailable, errWatchClosed.Error())
+			}
+			if err := stream.Send(change); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+}
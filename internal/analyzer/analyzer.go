@@ -0,0 +1,303 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+)
+
+// Namespace prefixes the subject of every finding k-pak. Subjects under it are
+// reserved for analyzers and cannot be ingested by clients.
+const Namespace = "_sutra/anomaly/"
+
+// Defaults used for unset config values.
+const (
+	DefaultFlapThreshold       = 5
+	DefaultFlapWindowSeconds   = 300
+	DefaultContradictionMargin = 0.05
+	DefaultFindingTTLSeconds   = 3600
+)
+
+// Kind names a type of anomaly.
+type Kind string
+
+const (
+	// KindFlapping means the winning value of a fact changed too often within the window.
+	KindFlapping Kind = "flapping"
+	// KindContradiction means a losing claim for another value is nearly as trusted as the winner.
+	KindContradiction Kind = "contradiction"
+)
+
+// Config holds analyzer settings.
+type Config struct {
+	Enabled             bool    `yaml:"enabled"`
+	FlapThreshold       int     `yaml:"flap_threshold"`       // Winner changes tolerated per window (0 = DefaultFlapThreshold)
+	FlapWindowSeconds   int64   `yaml:"flap_window_seconds"`  // Window for counting winner changes (0 = DefaultFlapWindowSeconds)
+	ContradictionMargin float64 `yaml:"contradiction_margin"` // Confidence gap below which a rival contradicts the winner (0 = DefaultContradictionMargin)
+	FindingTTLSeconds   int64   `yaml:"finding_ttl_seconds"`  // Lifetime of published findings (0 = DefaultFindingTTLSeconds)
+}
+
+// Finding describes one detected anomaly. It is published as the JSON object of a
+// k-pak under Namespace, so findings reach every agent like any other fact.
+type Finding struct {
+	Kind          Kind       `json:"kind"`
+	Subject       string     `json:"subject"`
+	Predicate     string     `json:"predicate"`
+	Changes       int        `json:"changes,omitempty"`        // Flapping: winner changes within the window
+	WindowSeconds int64      `json:"window_seconds,omitempty"` // Flapping: length of the window
+	Values        []string   `json:"values,omitempty"`         // Values involved, most recent winner first
+	Winner        *core.Kpak `json:"winner,omitempty"`         // Truth when the anomaly was detected
+	Rival         *core.Kpak `json:"rival,omitempty"`          // Contradiction: the losing claim
+	DetectedAt    int64      `json:"detected_at"`
+}
+
+// Kpak returns the finding as a k-pak published by source.
+func (f *Finding) Kpak(source string, ttlSeconds int64) (*core.Kpak, error) {
+	data, err := json.Marshal(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode finding: %w", err)
+	}
+	return core.NewKpakWithTTL(Namespace+f.Subject, string(f.Kind)+"/"+f.Predicate, core.JSON(data), source, 1.0, ttlSeconds), nil
+}
+
+// FromKpak decodes a finding published as a k-pak.
+func FromKpak(kpak *core.Kpak) (*Finding, error) {
+	if !IsReserved(kpak.Subject) {
+		return nil, fmt.Errorf("k-pak %s is not a finding", kpak.ID)
+	}
+	data, ok := kpak.Object.(core.JSON)
+	if !ok {
+		return nil, fmt.Errorf("finding %s is not a JSON object", kpak.ID)
+	}
+	var finding Finding
+	if err := json.Unmarshal(data, &finding); err != nil {
+		return nil, fmt.Errorf("failed to decode finding %s: %w", kpak.ID, err)
+	}
+	return &finding, nil
+}
+
+// IsReserved reports whether a subject lies in the findings namespace.
+func IsReserved(subject string) bool {
+	return strings.HasPrefix(subject, Namespace)
+}
+
+// Facts gives the analyzer read access to reconciled truth.
+type Facts interface {
+	QueryBySubjectPredicate(subject, predicate string) *core.Kpak
+	GetCandidates(subject, predicate string) []*core.Kpak
+}
+
+// Analyzer watches truth changes for facts that flap between values and for
+// winners that are contested by a nearly as trusted claim for another value.
+type Analyzer struct {
+	config  Config
+	facts   Facts
+	publish func(*Finding)
+	now     func() time.Time
+
+	mutex     sync.Mutex
+	flips     map[string][]flip    // Recent winner changes per subject+predicate
+	reported  map[string]time.Time // When each finding was last published, by kind+subject+predicate
+	lastPrune time.Time
+}
+
+// flip is one change of a fact's winning value.
+type flip struct {
+	at    time.Time
+	value string
+}
+
+// New creates an analyzer that reads candidates from facts and hands findings to publish.
+func New(config Config, facts Facts, publish func(*Finding)) *Analyzer {
+	if config.FlapThreshold <= 0 {
+		config.FlapThreshold = DefaultFlapThreshold
+	}
+	if config.FlapWindowSeconds <= 0 {
+		config.FlapWindowSeconds = DefaultFlapWindowSeconds
+	}
+	if config.ContradictionMargin <= 0 {
+		config.ContradictionMargin = DefaultContradictionMargin
+	}
+	if config.FindingTTLSeconds <= 0 {
+		config.FindingTTLSeconds = DefaultFindingTTLSeconds
+	}
+
+	return &Analyzer{
+		config:   config,
+		facts:    facts,
+		publish:  publish,
+		now:      time.Now,
+		flips:    make(map[string][]flip),
+		reported: make(map[string]time.Time),
+	}
+}
+
+// Config returns the analyzer's settings with defaults applied.
+func (a *Analyzer) Config() Config {
+	return a.config
+}
+
+// ObserveChange inspects a change of accepted truth.
+func (a *Analyzer) ObserveChange(change reconciliation.TruthChange) {
+	current := change.Current
+	if current == nil || IsReserved(current.Subject) {
+		return
+	}
+	if change.Type == reconciliation.ChangePinned || change.Type == reconciliation.ChangeUnpinned {
+		// Operator overrides are deliberate, not flapping
+		return
+	}
+
+	var findings []*Finding
+	if change.Previous != nil && change.Previous.ObjectKey() != current.ObjectKey() {
+		if finding := a.recordFlip(current); finding != nil {
+			findings = append(findings, finding)
+		}
+	}
+	if finding := a.checkContradiction(current.Subject, current.Predicate); finding != nil {
+		findings = append(findings, finding)
+	}
+
+	for _, finding := range findings {
+		a.publish(finding)
+	}
+}
+
+// ObserveClaim inspects a claim that was kept as a runner-up, which changes no
+// truth but may contest it.
+func (a *Analyzer) ObserveClaim(kpak *core.Kpak) {
+	if IsReserved(kpak.Subject) {
+		return
+	}
+	if finding := a.checkContradiction(kpak.Subject, kpak.Predicate); finding != nil {
+		a.publish(finding)
+	}
+}
+
+// recordFlip counts a change of winning value and reports flapping once the
+// changes within the window exceed the threshold.
+func (a *Analyzer) recordFlip(current *core.Kpak) *Finding {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	window := time.Duration(a.config.FlapWindowSeconds) * time.Second
+	a.prune(now, window)
+
+	key := current.Subject + "|" + current.Predicate
+	flips := append(recent(a.flips[key], now.Add(-window)), flip{at: now, value: core.FormatObject(current.Object)})
+	a.flips[key] = flips
+	if len(flips) <= a.config.FlapThreshold || !a.shouldReport(KindFlapping, key, now, window) {
+		return nil
+	}
+
+	// List the values that took turns, most recent first
+	var values []string
+	seen := make(map[string]bool)
+	for i := len(flips) - 1; i >= 0; i-- {
+		if !seen[flips[i].value] {
+			seen[flips[i].value] = true
+			values = append(values, flips[i].value)
+		}
+	}
+
+	return &Finding{
+		Kind:          KindFlapping,
+		Subject:       current.Subject,
+		Predicate:     current.Predicate,
+		Changes:       len(flips),
+		WindowSeconds: a.config.FlapWindowSeconds,
+		Values:        values,
+		Winner:        current,
+		DetectedAt:    now.Unix(),
+	}
+}
+
+// checkContradiction reports the best claim for another value when its
+// confidence is within the margin of the winner's.
+func (a *Analyzer) checkContradiction(subject, predicate string) *Finding {
+	winner := a.facts.QueryBySubjectPredicate(subject, predicate)
+	if winner == nil {
+		return nil
+	}
+
+	var rival *core.Kpak
+	for _, candidate := range a.facts.GetCandidates(subject, predicate) {
+		if candidate.ObjectKey() != winner.ObjectKey() {
+			rival = candidate
+			break
+		}
+	}
+	if rival == nil || math.Abs(float64(winner.Confidence-rival.Confidence)) > a.config.ContradictionMargin {
+		return nil
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	window := time.Duration(a.config.FlapWindowSeconds) * time.Second
+	if !a.shouldReport(KindContradiction, subject+"|"+predicate, now, window) {
+		return nil
+	}
+
+	return &Finding{
+		Kind:       KindContradiction,
+		Subject:    subject,
+		Predicate:  predicate,
+		Values:     []string{core.FormatObject(winner.Object), core.FormatObject(rival.Object)},
+		Winner:     winner,
+		Rival:      rival,
+		DetectedAt: now.Unix(),
+	}
+}
+
+// shouldReport reports whether a finding is due, publishing each kind of finding
+// for a fact at most once per window. Must be called with the mutex held.
+func (a *Analyzer) shouldReport(kind Kind, key string, now time.Time, window time.Duration) bool {
+	key = string(kind) + "|" + key
+	if last, exists := a.reported[key]; exists && now.Sub(last) < window {
+		return false
+	}
+	a.reported[key] = now
+	return true
+}
+
+// prune drops state older than the window, at most once per window.
+// Must be called with the mutex held.
+func (a *Analyzer) prune(now time.Time, window time.Duration) {
+	if now.Sub(a.lastPrune) < window {
+		return
+	}
+	a.lastPrune = now
+
+	cutoff := now.Add(-window)
+	for key, flips := range a.flips {
+		if remaining := recent(flips, cutoff); len(remaining) > 0 {
+			a.flips[key] = remaining
+		} else {
+			delete(a.flips, key)
+		}
+	}
+	for key, at := range a.reported {
+		if at.Before(cutoff) {
+			delete(a.reported, key)
+		}
+	}
+}
+
+// recent returns the flips made after the cutoff.
+func recent(flips []flip, cutoff time.Time) []flip {
+	for i, f := range flips {
+		if f.at.After(cutoff) {
+			return flips[i:]
+		}
+	}
+	return nil
+}
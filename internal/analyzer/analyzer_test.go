@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+)
+
+// newTestAnalyzer wires an analyzer to an engine and collects its findings.
+func newTestAnalyzer(config Config) (*Analyzer, *reconciliation.Engine, *[]*Finding) {
+	engine := reconciliation.NewEngine()
+	var findings []*Finding
+	a := New(config, engine, func(f *Finding) {
+		findings = append(findings, f)
+	})
+	engine.SetChangeHandler(a.ObserveChange)
+	return a, engine, &findings
+}
+
+func TestAnalyzer_DetectsFlapping(t *testing.T) {
+	a, engine, findings := newTestAnalyzer(Config{FlapThreshold: 2, FlapWindowSeconds: 60, ContradictionMargin: 0.001})
+
+	now := time.Unix(1000, 0)
+	a.now = func() time.Time { return now }
+
+	for i, value := range []string{"up", "down", "up"} {
+		engine.Reconcile(core.NewKpak("server1", "status", value, "probe", 0.5+0.1*float32(i)))
+	}
+	if len(*findings) != 0 {
+		t.Fatalf("Expected no finding at the threshold, got %d", len(*findings))
+	}
+
+	engine.Reconcile(core.NewKpak("server1", "status", "down", "probe-2", 0.9))
+	if len(*findings) != 1 {
+		t.Fatalf("Expected a flapping finding, got %d", len(*findings))
+	}
+	finding := (*findings)[0]
+	if finding.Kind != KindFlapping || finding.Changes != 3 || len(finding.Values) != 2 || finding.Values[0] != "down" {
+		t.Fatalf("Unexpected finding %+v", finding)
+	}
+
+	// Reported once per window
+	engine.Reconcile(core.NewKpak("server1", "status", "up", "probe-3", 0.95))
+	if len(*findings) != 1 {
+		t.Fatalf("Expected the finding to be reported once per window, got %d", len(*findings))
+	}
+
+	// Changes outside the window no longer count
+	now = now.Add(2 * time.Minute)
+	engine.Reconcile(core.NewKpak("server1", "status", "down", "probe-4", 0.96))
+	if len(*findings) != 1 {
+		t.Fatalf("Expected old changes to age out, got %d findings", len(*findings))
+	}
+}
+
+func TestAnalyzer_DetectsContradiction(t *testing.T) {
+	a, engine, findings := newTestAnalyzer(Config{ContradictionMargin: 0.05})
+
+	engine.Reconcile(core.NewKpak("svc-a", "owner", "alice", "hr", 0.9))
+	if engine.ReconcileOutcome(core.NewKpak("svc-a", "owner", "carol", "wiki", 0.5)) != reconciliation.OutcomeCandidate {
+		t.Fatal("Expected the weak claim to be kept as a runner-up")
+	}
+	a.ObserveClaim(core.NewKpak("svc-a", "owner", "carol", "wiki", 0.5))
+	if len(*findings) != 0 {
+		t.Fatal("A clearly weaker rival is not a contradiction")
+	}
+
+	rival := core.NewKpak("svc-a", "owner", "bob", "directory", 0.88)
+	engine.Reconcile(rival)
+	a.ObserveClaim(rival)
+	if len(*findings) != 1 {
+		t.Fatalf("Expected a contradiction finding, got %d", len(*findings))
+	}
+	finding := (*findings)[0]
+	if finding.Kind != KindContradiction || finding.Winner.Object != "alice" || finding.Rival.Object != "bob" {
+		t.Fatalf("Unexpected finding %+v", finding)
+	}
+}
+
+func TestAnalyzer_IgnoresFindings(t *testing.T) {
+	_, engine, findings := newTestAnalyzer(Config{FlapThreshold: 1})
+
+	for i, value := range []string{"a", "b", "c"} {
+		engine.Reconcile(core.NewKpak(Namespace+"server1", "flapping/status", value, "analyzer", 0.5+0.1*float32(i)))
+	}
+	if len(*findings) != 0 {
+		t.Fatalf("Findings should not be analyzed, got %d", len(*findings))
+	}
+}
+
+func TestFinding_KpakRoundTrip(t *testing.T) {
+	finding := &Finding{
+		Kind:       KindContradiction,
+		Subject:    "svc-a",
+		Predicate:  "owner",
+		Values:     []string{"alice", "bob"},
+		Winner:     core.NewKpak("svc-a", "owner", "alice", "hr", 0.9),
+		Rival:      core.NewKpak("svc-a", "owner", "bob", "directory", 0.88),
+		DetectedAt: 1000,
+	}
+
+	kpak, err := finding.Kpak("analyzer@agent-a", 60)
+	if err != nil {
+		t.Fatalf("Failed to build k-pak: %v", err)
+	}
+	if kpak.Subject != Namespace+"svc-a" || kpak.Predicate != "contradiction/owner" || kpak.ExpiresAt == 0 {
+		t.Fatalf("Unexpected finding k-pak %+v", kpak)
+	}
+
+	data, err := kpak.ToJSON()
+	if err != nil {
+		t.Fatalf("Failed to encode k-pak: %v", err)
+	}
+	decodedKpak, err := core.FromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to decode k-pak: %v", err)
+	}
+	decoded, err := FromKpak(decodedKpak)
+	if err != nil {
+		t.Fatalf("Failed to decode finding: %v", err)
+	}
+	if decoded.Kind != KindContradiction || decoded.Rival.Object != "bob" || decoded.Winner.Confidence != 0.9 {
+		t.Fatalf("Unexpected decoded finding %+v", decoded)
+	}
+
+	if _, err := FromKpak(core.NewKpak("svc-a", "owner", "alice", "hr", 0.9)); err == nil {
+		t.Fatal("Expected a k-pak outside the namespace to be rejected")
+	}
+}
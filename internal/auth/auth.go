@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// Role is what an authenticated client may do. Each role includes the ones below it.
+type Role string
+
+const (
+	RoleReader Role = "reader" // Query the mesh
+	RoleWriter Role = "writer" // Also ingest and retract claims for its sources
+	RoleAdmin  Role = "admin"  // Also define schemas, pin facts and review claims
+)
+
+func (r Role) rank() int {
+	switch r {
+	case RoleReader:
+		return 1
+	case RoleWriter:
+		return 2
+	case RoleAdmin:
+		return 3
+	}
+	return 0
+}
+
+// Includes reports whether the role grants everything required allows.
+func (r Role) Includes(required Role) bool {
+	return r.rank() > 0 && r.rank() >= required.rank()
+}
+
+// ParseRole checks a role name.
+func ParseRole(s string) (Role, error) {
+	if role := Role(s); role.rank() > 0 {
+		return role, nil
+	}
+	return "", fmt.Errorf("unknown role %q (want reader, writer or admin)", s)
+}
+
+// Identity is an authenticated client.
+type Identity struct {
+	Name    string
+	Role    Role
+	Sources []string // Source names or path.Match patterns a writer may use ("*" = any)
+}
+
+// MayWriteAs reports whether the client may ingest or retract claims for source.
+func (id *Identity) MayWriteAs(source string) bool {
+	if id.Role == RoleAdmin {
+		return true
+	}
+	if !id.Role.Includes(RoleWriter) {
+		return false
+	}
+	for _, pattern := range id.Sources {
+		if matched, _ := path.Match(pattern, source); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Token is a static bearer token.
+type Token struct {
+	Name    string   `yaml:"name"`    // Identity reported for requests made with the token
+	Token   string   `yaml:"token"`   // The secret itself
+	Role    Role     `yaml:"role"`    // reader, writer or admin
+	Sources []string `yaml:"sources"` // Writers: sources the token may write as (at least one; "*" = any)
+}
+
+// Config holds authentication settings. When disabled every request is allowed.
+type Config struct {
+	Enabled bool      `yaml:"enabled"`
+	Tokens  []Token   `yaml:"tokens"`
+	JWT     JWTConfig `yaml:"jwt"`
+}
+
+// Validate checks that enabled authentication has credentials and that every role is known.
+func (c Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Tokens) == 0 && c.JWT.Secret == "" {
+		return fmt.Errorf("auth is enabled but no tokens or JWT secret are configured")
+	}
+
+	seen := make(map[string]bool)
+	for _, token := range c.Tokens {
+		if token.Name == "" || token.Token == "" {
+			return fmt.Errorf("auth tokens need a name and a token")
+		}
+		if seen[token.Token] {
+			return fmt.Errorf("auth token %q is configured twice", token.Name)
+		}
+		seen[token.Token] = true
+		if _, err := ParseRole(string(token.Role)); err != nil {
+			return fmt.Errorf("auth token %q: %w", token.Name, err)
+		}
+		if err := checkSources(token.Role, token.Sources); err != nil {
+			return fmt.Errorf("auth token %q: %w", token.Name, err)
+		}
+	}
+	return nil
+}
+
+// checkSources requires writers to be bound to at least one valid source pattern,
+// so a missing list never silently grants every source.
+func checkSources(role Role, sources []string) error {
+	if role == RoleWriter && len(sources) == 0 {
+		return fmt.Errorf("writers need at least one source (use \"*\" for any)")
+	}
+	for _, pattern := range sources {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid source pattern %q", pattern)
+		}
+	}
+	return nil
+}
+
+// Authenticator verifies bearer tokens and enforces the role each method requires.
+type Authenticator struct {
+	tokens   map[[sha256.Size]byte]*Identity // Keyed by token hash so lookups do not leak the secret
+	jwt      JWTConfig
+	required func(method string) Role
+	now      func() time.Time
+}
+
+// New creates an authenticator. required returns the role needed to call a gRPC
+// method, given its full name; an empty role makes the method public.
+func New(config Config, required func(method string) Role) (*Authenticator, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	a := &Authenticator{
+		tokens:   make(map[[sha256.Size]byte]*Identity),
+		jwt:      config.JWT,
+		required: required,
+		now:      time.Now,
+	}
+	for _, token := range config.Tokens {
+		a.tokens[sha256.Sum256([]byte(token.Token))] = &Identity{Name: token.Name, Role: token.Role, Sources: token.Sources}
+	}
+	return a, nil
+}
+
+// Authenticate returns the identity behind the bearer token in the request metadata.
+func (a *Authenticator) Authenticate(ctx context.Context) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token")
+	}
+	scheme, token, found := strings.Cut(values[0], " ")
+	if !found || !strings.EqualFold(scheme, "bearer") || token == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+	}
+
+	if id, exists := a.tokens[sha256.Sum256([]byte(token))]; exists {
+		return id, nil
+	}
+	if a.jwt.Secret != "" && strings.Count(token, ".") == 2 {
+		id, err := a.jwt.verify(token, a.now())
+		if err != nil {
+			return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		}
+		return id, nil
+	}
+	return nil, status.Error(codes.Unauthenticated, "invalid token")
+}
+
+// authorize authenticates a call and checks the caller's role against the method.
+func (a *Authenticator) authorize(ctx context.Context, method string) (context.Context, error) {
+	required := a.required(method)
+	if required == "" {
+		return ctx, nil
+	}
+	id, err := a.Authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !id.Role.Includes(required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s requires the %s role, %q is a %s", method, required, id.Name, id.Role)
+	}
+	return NewContext(ctx, id), nil
+}
+
+// UnaryInterceptor authenticates and authorizes unary calls.
+func (a *Authenticator) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authorize(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor authenticates and authorizes streaming calls.
+func (a *Authenticator) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authorize(stream.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &identityStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// identityStream carries the caller's identity in its context.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+type identityKey struct{}
+
+// NewContext returns a context carrying the caller's identity.
+func NewContext(ctx context.Context, id *Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+// FromContext returns the caller's identity, or nil when authentication is disabled.
+func FromContext(ctx context.Context) *Identity {
+	id, _ := ctx.Value(identityKey{}).(*Identity)
+	return id
+}
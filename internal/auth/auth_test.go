@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+var testConfig = Config{
+	Enabled: true,
+	Tokens: []Token{
+		{Name: "dashboard", Token: "read-secret", Role: RoleReader},
+		{Name: "prometheus-scout", Token: "write-secret", Role: RoleWriter, Sources: []string{"prometheus-*"}},
+		{Name: "ops", Token: "admin-secret", Role: RoleAdmin},
+	},
+	JWT: JWTConfig{Secret: "jwt-secret"},
+}
+
+func testRequired(method string) Role {
+	switch method {
+	case "/read":
+		return RoleReader
+	case "/write":
+		return RoleWriter
+	}
+	return RoleAdmin
+}
+
+func withToken(token string) context.Context {
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+}
+
+func TestRole_Includes(t *testing.T) {
+	if !RoleAdmin.Includes(RoleWriter) || !RoleWriter.Includes(RoleReader) || !RoleReader.Includes(RoleReader) {
+		t.Fatal("Expected higher roles to include lower ones")
+	}
+	if RoleReader.Includes(RoleWriter) || RoleWriter.Includes(RoleAdmin) || Role("guest").Includes(RoleReader) {
+		t.Fatal("Expected lower or unknown roles not to include higher ones")
+	}
+	if _, err := ParseRole("root"); err == nil {
+		t.Fatal("Expected an unknown role to be refused")
+	}
+}
+
+func TestIdentity_MayWriteAs(t *testing.T) {
+	writer := &Identity{Name: "scout", Role: RoleWriter, Sources: []string{"prometheus-*", "cmdb"}}
+	if !writer.MayWriteAs("prometheus-eu") || !writer.MayWriteAs("cmdb") || writer.MayWriteAs("ai-scout") {
+		t.Fatal("Expected the writer to be bound to its sources")
+	}
+	if (&Identity{Role: RoleWriter}).MayWriteAs("anything") {
+		t.Fatal("Expected a writer without sources not to write")
+	}
+	if !(&Identity{Role: RoleWriter, Sources: []string{"*"}}).MayWriteAs("anything") {
+		t.Fatal("Expected a wildcard writer to write as any source")
+	}
+	if (&Identity{Role: RoleReader}).MayWriteAs("anything") {
+		t.Fatal("Expected a reader not to write")
+	}
+	if !(&Identity{Role: RoleAdmin, Sources: []string{"x"}}).MayWriteAs("anything") {
+		t.Fatal("Expected an admin to write as any source")
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	if err := (Config{}).Validate(); err != nil {
+		t.Fatalf("Expected disabled auth to be valid, got %v", err)
+	}
+	if err := testConfig.Validate(); err != nil {
+		t.Fatalf("Expected the test config to be valid, got %v", err)
+	}
+
+	invalid := []Config{
+		{Enabled: true},
+		{Enabled: true, Tokens: []Token{{Name: "a", Token: "x", Role: "root"}}},
+		{Enabled: true, Tokens: []Token{{Name: "a", Role: RoleReader}}},
+		{Enabled: true, Tokens: []Token{{Name: "a", Token: "x", Role: RoleReader}, {Name: "b", Token: "x", Role: RoleAdmin}}},
+		{Enabled: true, Tokens: []Token{{Name: "a", Token: "x", Role: RoleWriter, Sources: []string{"[bad"}}}},
+		{Enabled: true, Tokens: []Token{{Name: "a", Token: "x", Role: RoleWriter}}},
+	}
+	for _, config := range invalid {
+		if err := config.Validate(); err == nil {
+			t.Fatalf("Expected config %+v to be invalid", config)
+		}
+	}
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	a, err := New(testConfig, testRequired)
+	if err != nil {
+		t.Fatalf("Failed to create authenticator: %v", err)
+	}
+
+	id, err := a.Authenticate(withToken("write-secret"))
+	if err != nil || id.Name != "prometheus-scout" || id.Role != RoleWriter {
+		t.Fatalf("Expected the writer identity, got %+v, %v", id, err)
+	}
+
+	for _, ctx := range []context.Context{
+		context.Background(),
+		withToken("wrong"),
+		metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic cmVhZC1zZWNyZXQ=")),
+	} {
+		if _, err := a.Authenticate(ctx); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Expected Unauthenticated, got %v", err)
+		}
+	}
+
+	jwt, err := IssueJWT("jwt-secret", Claims{Subject: "ci", Role: RoleAdmin})
+	if err != nil {
+		t.Fatalf("IssueJWT failed: %v", err)
+	}
+	if id, err := a.Authenticate(withToken(jwt)); err != nil || id.Name != "ci" || id.Role != RoleAdmin {
+		t.Fatalf("Expected the JWT identity, got %+v, %v", id, err)
+	}
+}
+
+func TestAuthenticator_UnaryInterceptor(t *testing.T) {
+	a, _ := New(testConfig, testRequired)
+	interceptor := a.UnaryInterceptor()
+
+	var seen *Identity
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		seen = FromContext(ctx)
+		return "ok", nil
+	}
+
+	if _, err := interceptor(withToken("read-secret"), nil, &grpc.UnaryServerInfo{FullMethod: "/read"}, handler); err != nil {
+		t.Fatalf("Expected a reader to read, got %v", err)
+	}
+	if seen == nil || seen.Name != "dashboard" {
+		t.Fatalf("Expected the handler to see the caller, got %+v", seen)
+	}
+	if _, err := interceptor(withToken("read-secret"), nil, &grpc.UnaryServerInfo{FullMethod: "/write"}, handler); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied for a reader writing, got %v", err)
+	}
+	if _, err := interceptor(withToken("write-secret"), nil, &grpc.UnaryServerInfo{FullMethod: "/admin"}, handler); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Expected PermissionDenied for a writer on an admin method, got %v", err)
+	}
+	if _, err := interceptor(withToken("admin-secret"), nil, &grpc.UnaryServerInfo{FullMethod: "/admin"}, handler); err != nil {
+		t.Fatalf("Expected an admin to pass, got %v", err)
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestAuthenticator_StreamInterceptor(t *testing.T) {
+	a, _ := New(testConfig, testRequired)
+	interceptor := a.StreamInterceptor()
+
+	var seen *Identity
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		seen = FromContext(stream.Context())
+		return nil
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/write"}
+	if err := interceptor(nil, &fakeServerStream{ctx: withToken("write-secret")}, info, handler); err != nil {
+		t.Fatalf("Expected a writer to stream, got %v", err)
+	}
+	if seen == nil || seen.Name != "prometheus-scout" {
+		t.Fatalf("Expected the handler to see the caller, got %+v", seen)
+	}
+	if err := interceptor(nil, &fakeServerStream{ctx: context.Background()}, info, handler); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("Expected Unauthenticated, got %v", err)
+	}
+}
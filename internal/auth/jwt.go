@@ -0,0 +1,150 @@
+package auth
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// JWTConfig enables HS256-signed JWTs, verified locally with a shared secret.
+type JWTConfig struct {
+	Secret   string `yaml:"secret"`   // HMAC key (empty = JWTs are not accepted)
+	Issuer   string `yaml:"issuer"`   // Required "iss" claim (empty = not checked)
+	Audience string `yaml:"audience"` // Required "aud" claim (empty = not checked)
+}
+
+// Claims are the JWT claims the agent reads.
+type Claims struct {
+	Subject   string   `json:"sub"`
+	Role      Role     `json:"role"`
+	Sources   []string `json:"sources,omitempty"`
+	Issuer    string   `json:"iss,omitempty"`
+	Audience  Audience `json:"aud,omitempty"`
+	ExpiresAt int64    `json:"exp,omitempty"` // Unix timestamp (0 = never)
+	NotBefore int64    `json:"nbf,omitempty"`
+}
+
+// Audience is the "aud" claim, which RFC 7519 allows to be a single string or an array.
+type Audience []string
+
+// UnmarshalJSON accepts a string or an array of strings.
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+	var many []string
+	if err := json.Unmarshal(data, &many); err != nil {
+		return fmt.Errorf("aud must be a string or an array of strings")
+	}
+	*a = many
+	return nil
+}
+
+// MarshalJSON writes a single audience as a plain string.
+func (a Audience) MarshalJSON() ([]byte, error) {
+	if len(a) == 1 {
+		return json.Marshal(a[0])
+	}
+	return json.Marshal([]string(a))
+}
+
+// Contains reports whether the token is meant for audience.
+func (a Audience) Contains(audience string) bool {
+	for _, candidate := range a {
+		if candidate == audience {
+			return true
+		}
+	}
+	return false
+}
+
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// IssueJWT signs claims with an HS256 secret.
+func IssueJWT(secret string, claims Claims) (string, error) {
+	header, err := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(sign(secret, signed)), nil
+}
+
+// verify checks a JWT's signature and claims and returns the identity it asserts.
+func (c JWTConfig) verify(token string, now time.Time) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("malformed JWT")
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+	if header.Alg != "HS256" {
+		return nil, fmt.Errorf("unsupported JWT algorithm %q", header.Alg)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || !hmac.Equal(signature, sign(c.Secret, parts[0]+"."+parts[1])) {
+		return nil, fmt.Errorf("bad JWT signature")
+	}
+
+	var claims Claims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+	if claims.ExpiresAt != 0 && now.Unix() >= claims.ExpiresAt {
+		return nil, fmt.Errorf("JWT expired")
+	}
+	if claims.NotBefore != 0 && now.Unix() < claims.NotBefore {
+		return nil, fmt.Errorf("JWT not valid yet")
+	}
+	if c.Issuer != "" && claims.Issuer != c.Issuer {
+		return nil, fmt.Errorf("JWT issuer %q is not trusted", claims.Issuer)
+	}
+	if c.Audience != "" && !claims.Audience.Contains(c.Audience) {
+		return nil, fmt.Errorf("JWT audience %q does not match", strings.Join(claims.Audience, ","))
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("JWT has no subject")
+	}
+	if _, err := ParseRole(string(claims.Role)); err != nil {
+		return nil, err
+	}
+	if err := checkSources(claims.Role, claims.Sources); err != nil {
+		return nil, fmt.Errorf("JWT %w", err)
+	}
+
+	return &Identity{Name: claims.Subject, Role: claims.Role, Sources: claims.Sources}, nil
+}
+
+func sign(secret, signed string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, v interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("malformed JWT: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("malformed JWT: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestJWT_Verify(t *testing.T) {
+	config := JWTConfig{Secret: "jwt-secret", Issuer: "sutra-ops", Audience: "mesh"}
+	now := time.Unix(1700000000, 0)
+
+	token, err := IssueJWT("jwt-secret", Claims{
+		Subject:   "scout-7",
+		Role:      RoleWriter,
+		Sources:   []string{"scout-7"},
+		Issuer:    "sutra-ops",
+		Audience:  Audience{"mesh"},
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("IssueJWT failed: %v", err)
+	}
+	id, err := config.verify(token, now)
+	if err != nil {
+		t.Fatalf("verify failed: %v", err)
+	}
+	if id.Name != "scout-7" || id.Role != RoleWriter || len(id.Sources) != 1 {
+		t.Fatalf("Unexpected identity %+v", id)
+	}
+
+	if _, err := config.verify(token, now.Add(2*time.Hour)); err == nil {
+		t.Fatal("Expected an expired JWT to be refused")
+	}
+	if _, err := (JWTConfig{Secret: "other"}).verify(token, now); err == nil {
+		t.Fatal("Expected a JWT signed with another secret to be refused")
+	}
+	if _, err := (JWTConfig{Secret: "jwt-secret", Issuer: "someone-else"}).verify(token, now); err == nil {
+		t.Fatal("Expected a JWT from another issuer to be refused")
+	}
+
+	unbound, _ := IssueJWT("jwt-secret", Claims{Subject: "scout-7", Role: RoleWriter, Issuer: "sutra-ops", Audience: Audience{"mesh"}})
+	if _, err := config.verify(unbound, now); err == nil {
+		t.Fatal("Expected a writer JWT without sources to be refused")
+	}
+
+	parts := strings.Split(token, ".")
+	tampered, _ := IssueJWT("jwt-secret", Claims{Subject: "scout-7", Role: RoleAdmin})
+	forged := parts[0] + "." + strings.Split(tampered, ".")[1] + "." + parts[2]
+	if _, err := config.verify(forged, now); err == nil {
+		t.Fatal("Expected a JWT with altered claims to be refused")
+	}
+}
+
+func TestJWT_AudienceArray(t *testing.T) {
+	config := JWTConfig{Secret: "jwt-secret", Audience: "mesh"}
+	now := time.Unix(1700000000, 0)
+
+	// Identity providers often send "aud" as an array
+	token, _ := IssueJWT("jwt-secret", Claims{Subject: "scout-7", Role: RoleReader, Audience: Audience{"dashboard", "mesh"}})
+	if _, err := config.verify(token, now); err != nil {
+		t.Fatalf("Expected a JWT listing the audience to be accepted, got %v", err)
+	}
+	token, _ = IssueJWT("jwt-secret", Claims{Subject: "scout-7", Role: RoleReader, Audience: Audience{"dashboard", "billing"}})
+	if _, err := config.verify(token, now); err == nil {
+		t.Fatal("Expected a JWT for other audiences to be refused")
+	}
+}
+
+func TestJWT_RejectsBadClaims(t *testing.T) {
+	config := JWTConfig{Secret: "jwt-secret"}
+	now := time.Unix(1700000000, 0)
+
+	for _, claims := range []Claims{
+		{Role: RoleReader},
+		{Subject: "x", Role: "root"},
+		{Subject: "x", Role: RoleReader, NotBefore: now.Add(time.Minute).Unix()},
+	} {
+		token, _ := IssueJWT("jwt-secret", claims)
+		if _, err := config.verify(token, now); err == nil {
+			t.Fatalf("Expected claims %+v to be refused", claims)
+		}
+	}
+
+	if _, err := config.verify("not.a.jwt", now); err == nil {
+		t.Fatal("Expected a malformed JWT to be refused")
+	}
+	none := "eyJhbGciOiJub25lIn0.eyJzdWIiOiJ4Iiwicm9sZSI6ImFkbWluIn0."
+	if _, err := config.verify(none, now); err == nil {
+		t.Fatal("Expected an unsigned JWT to be refused")
+	}
+}
@@ -14,7 +14,7 @@ type Kpak struct {
 	// Core triple
 	Subject   string      `json:"subject"`   // Who/what this is about
 	Predicate string      `json:"predicate"` // The relationship/property
-	Object    interface{} `json:"object"`    // The value/target
+	Object    interface{} `json:"object"`    // The value/target (see TypeOf for the supported types)
 
 	// Metadata for reconciliation
 	Source     string  `json:"source"`     // Origin of this knowledge
@@ -22,6 +22,15 @@ type Kpak struct {
 	Timestamp  int64   `json:"timestamp"`  // When this was created
 	ExpiresAt  int64   `json:"expires_at"` // Unix timestamp when this k-pak expires (0 = never expires)
 
+	// Version is the fact's version vector when the claim was accepted by the agent
+	// it was made on; unset until then. It is not part of the content hash.
+	Version VersionVector `json:"version,omitempty"`
+
+	// Origin and OriginatedAt record the agent that took the claim in and when, so
+	// peers can time its propagation. They are not part of the content hash.
+	Origin       string `json:"origin,omitempty"`
+	OriginatedAt int64  `json:"originated_at,omitempty"` // Unix nanoseconds
+
 	// Computed fields for performance
 	ID   string `json:"id"`   // Content hash for uniqueness
 	SPID string `json:"spid"` // Subject+Predicate hash for indexing
@@ -58,9 +67,16 @@ func NewKpakWithTTL(subject, predicate string, object interface{}, source string
 }
 
 // generateID creates a unique hash of the k-pak's content.
+// Plain strings are hashed as they always were, so agents that predate typed
+// objects agree on their IDs; other values are hashed through their canonical
+// encoding so the ID does not depend on how Go happens to format them.
 func (k *Kpak) generateID() string {
-	data := fmt.Sprintf("%s|%s|%v|%s|%f|%d",
-		k.Subject, k.Predicate, k.Object, k.Source, k.Confidence, k.Timestamp)
+	object, isString := k.Object.(string)
+	if !isString {
+		object = CanonicalObject(k.Object)
+	}
+	data := fmt.Sprintf("%s|%s|%s|%s|%f|%d",
+		k.Subject, k.Predicate, object, k.Source, k.Confidence, k.Timestamp)
 	hash := sha256.Sum256([]byte(data))
 	return fmt.Sprintf("%x", hash)[:16] // First 16 chars for readability
 }
@@ -92,6 +108,12 @@ func (k *Kpak) IsMoreTrustedThan(other *Kpak) bool {
 	return false
 }
 
+// ObjectKey returns a comparable form of the object, used to tell whether two
+// claims agree on the value.
+func (k *Kpak) ObjectKey() string {
+	return CanonicalObject(k.Object)
+}
+
 // IsExpired checks if this k-pak has expired (past its ExpiresAt time).
 func (k *Kpak) IsExpired() bool {
 	if k.ExpiresAt == 0 {
@@ -120,6 +142,43 @@ func (k *Kpak) RegenerateComputedFields() {
 	k.SPID = k.GenerateSPID()
 }
 
+// kpakAlias has the fields of Kpak without its JSON methods.
+type kpakAlias Kpak
+
+// kpakJSON is the wire form of a k-pak: the object is written in its typed JSON
+// form alongside its value type, so it survives the WAL and gossip unchanged.
+type kpakJSON struct {
+	kpakAlias
+	Object     json.RawMessage `json:"object"`
+	ObjectType ValueType       `json:"object_type,omitempty"`
+}
+
+// MarshalJSON encodes the k-pak with a typed object.
+func (k Kpak) MarshalJSON() ([]byte, error) {
+	object, objectType, err := encodeObject(k.Object)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode object: %w", err)
+	}
+	return json.Marshal(kpakJSON{kpakAlias: kpakAlias(k), Object: object, ObjectType: objectType})
+}
+
+// UnmarshalJSON decodes a k-pak, restoring the object's type when it was recorded.
+func (k *Kpak) UnmarshalJSON(data []byte) error {
+	var aux kpakJSON
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	object, err := decodeObject(aux.Object, aux.ObjectType)
+	if err != nil {
+		return fmt.Errorf("failed to decode object: %w", err)
+	}
+
+	*k = Kpak(aux.kpakAlias)
+	k.Object = object
+	return nil
+}
+
 // ToJSON serializes the k-pak for persistence or network transfer.
 func (k *Kpak) ToJSON() ([]byte, error) {
 	return json.Marshal(k)
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// ValueType names the type of a k-pak's object.
+type ValueType string
+
+const (
+	ValueString    ValueType = "string"
+	ValueInt       ValueType = "int"
+	ValueFloat     ValueType = "float"
+	ValueBool      ValueType = "bool"
+	ValueBytes     ValueType = "bytes"
+	ValueTimestamp ValueType = "timestamp"
+	ValueJSON      ValueType = "json"
+	ValueRef       ValueType = "ref"
+)
+
+// JSON is an object holding a structured JSON document.
+type JSON []byte
+
+// Ref is an object that refers to another subject in the mesh.
+type Ref string
+
+// TypeOf returns the value type a k-pak object is stored and compared as.
+// All Go integer kinds are ints and both float kinds are floats; anything that
+// is not one of the scalar types is treated as a JSON document.
+func TypeOf(object interface{}) ValueType {
+	switch object.(type) {
+	case string:
+		return ValueString
+	case bool:
+		return ValueBool
+	case []byte:
+		return ValueBytes
+	case time.Time:
+		return ValueTimestamp
+	case JSON, json.RawMessage:
+		return ValueJSON
+	case Ref:
+		return ValueRef
+	}
+
+	switch reflect.ValueOf(object).Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return ValueInt
+	case reflect.Float32, reflect.Float64:
+		return ValueFloat
+	}
+	return ValueJSON
+}
+
+// ValidateObject reports whether an object can be stored and compared reliably.
+func ValidateObject(object interface{}) error {
+	switch TypeOf(object) {
+	case ValueFloat:
+		f := reflect.ValueOf(object).Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("float object must be finite, got %v", f)
+		}
+	case ValueInt:
+		rv := reflect.ValueOf(object)
+		if rv.CanUint() && rv.Uint() > math.MaxInt64 {
+			return fmt.Errorf("int object %d overflows int64", rv.Uint())
+		}
+	case ValueJSON:
+		if _, err := canonicalJSON(object); err != nil {
+			return fmt.Errorf("invalid JSON object: %w", err)
+		}
+	}
+	return nil
+}
+
+// CanonicalObject returns a stable, type-tagged encoding of an object. Two objects
+// have the same encoding exactly when they are the same typed value, so it is used
+// for content hashing and for deciding whether two claims agree.
+func CanonicalObject(object interface{}) string {
+	valueType := TypeOf(object)
+	var payload string
+
+	switch valueType {
+	case ValueJSON:
+		data, err := canonicalJSON(object)
+		if err != nil {
+			// Unencodable values still need a deterministic key
+			payload = fmt.Sprintf("%#v", object)
+		} else {
+			payload = string(data)
+		}
+	default:
+		payload = FormatObject(object)
+	}
+
+	return string(valueType) + ":" + payload
+}
+
+// FormatObject renders an object for display and for clients that only read
+// the plain string form.
+func FormatObject(object interface{}) string {
+	switch v := object.(type) {
+	case string:
+		return v
+	case Ref:
+		return string(v)
+	case []byte:
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	case JSON:
+		return string(v)
+	case json.RawMessage:
+		return string(v)
+	}
+
+	rv := reflect.ValueOf(object)
+	switch TypeOf(object) {
+	case ValueInt:
+		if rv.CanInt() {
+			return strconv.FormatInt(rv.Int(), 10)
+		}
+		return strconv.FormatUint(rv.Uint(), 10)
+	case ValueFloat:
+		return strconv.FormatFloat(rv.Float(), 'g', -1, 64)
+	case ValueBool:
+		return strconv.FormatBool(rv.Bool())
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Sprintf("%v", object)
+	}
+	return string(data)
+}
+
+// canonicalJSON re-encodes a JSON document (or any JSON-encodable Go value)
+// with sorted object keys and no insignificant whitespace.
+func canonicalJSON(object interface{}) ([]byte, error) {
+	var raw []byte
+	switch v := object.(type) {
+	case JSON:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		raw = data
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var doc interface{}
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, fmt.Errorf("trailing data after JSON document")
+	}
+	return json.Marshal(doc)
+}
+
+// encodeObject converts an object to its JSON form for persistence and gossip.
+func encodeObject(object interface{}) (json.RawMessage, ValueType, error) {
+	if object == nil {
+		return json.RawMessage("null"), "", nil
+	}
+
+	valueType := TypeOf(object)
+	switch valueType {
+	case ValueJSON:
+		data, err := canonicalJSON(object)
+		return data, valueType, err
+	case ValueTimestamp:
+		data, err := json.Marshal(FormatObject(object))
+		return data, valueType, err
+	}
+
+	data, err := json.Marshal(object)
+	return data, valueType, err
+}
+
+// decodeObject restores a typed object from its JSON form. An empty value type
+// means the record predates typed values, and the object is decoded generically.
+func decodeObject(data json.RawMessage, valueType ValueType) (interface{}, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	switch valueType {
+	case "":
+		var object interface{}
+		err := json.Unmarshal(data, &object)
+		return object, err
+	case ValueString:
+		var s string
+		err := json.Unmarshal(data, &s)
+		return s, err
+	case ValueInt:
+		var i int64
+		err := json.Unmarshal(data, &i)
+		return i, err
+	case ValueFloat:
+		var f float64
+		err := json.Unmarshal(data, &f)
+		return f, err
+	case ValueBool:
+		var b bool
+		err := json.Unmarshal(data, &b)
+		return b, err
+	case ValueBytes:
+		var b []byte
+		err := json.Unmarshal(data, &b)
+		return b, err
+	case ValueTimestamp:
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, s)
+	case ValueJSON:
+		doc, err := canonicalJSON(json.RawMessage(data))
+		return JSON(doc), err
+	case ValueRef:
+		var s string
+		err := json.Unmarshal(data, &s)
+		return Ref(s), err
+	default:
+		return nil, fmt.Errorf("unknown object type %q", valueType)
+	}
+}
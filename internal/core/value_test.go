@@ -0,0 +1,122 @@
+package core
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestCanonicalObject_DistinguishesTypes(t *testing.T) {
+	if CanonicalObject("5") == CanonicalObject(5) {
+		t.Fatal("Expected string \"5\" and int 5 to have different encodings")
+	}
+	if CanonicalObject(int32(5)) != CanonicalObject(int64(5)) {
+		t.Fatal("Expected all integer kinds to share an encoding")
+	}
+	if CanonicalObject(Ref("alice")) == CanonicalObject("alice") {
+		t.Fatal("Expected a ref and a string to have different encodings")
+	}
+}
+
+func TestCanonicalObject_JSONKeyOrder(t *testing.T) {
+	a := CanonicalObject(JSON(`{"b": 1, "a": [1, 2]}`))
+	b := CanonicalObject(JSON(`{"a":[1,2],"b":1}`))
+	if a != b {
+		t.Fatalf("Expected equal encodings, got %s and %s", a, b)
+	}
+	if a != `json:{"a":[1,2],"b":1}` {
+		t.Fatalf("Unexpected canonical encoding %s", a)
+	}
+}
+
+func TestKpak_IDDependsOnObjectType(t *testing.T) {
+	k1 := NewKpak("sensor", "reading", "5", "src", 0.9)
+	k2 := NewKpak("sensor", "reading", 5, "src", 0.9)
+	k2.Timestamp = k1.Timestamp
+	k2.RegenerateComputedFields()
+	k1.RegenerateComputedFields()
+
+	if k1.ID == k2.ID {
+		t.Fatal("Expected string and int objects to produce different IDs")
+	}
+	if k1.SPID != k2.SPID {
+		t.Fatal("Expected the SPID to ignore the object")
+	}
+}
+
+func TestKpak_StringIDsMatchOlderAgents(t *testing.T) {
+	k := &Kpak{Subject: "server1", Predicate: "status", Object: "up", Source: "monitor", Confidence: 0.9, Timestamp: 1700000000000000000}
+	k.RegenerateComputedFields()
+
+	// The ID an agent without typed objects computes for the same claim
+	data := fmt.Sprintf("%s|%s|%v|%s|%f|%d", k.Subject, k.Predicate, k.Object, k.Source, k.Confidence, k.Timestamp)
+	if want := fmt.Sprintf("%x", sha256.Sum256([]byte(data)))[:16]; k.ID != want {
+		t.Fatalf("Expected the pre-typed ID %s, got %s", want, k.ID)
+	}
+}
+
+func TestValidateObject(t *testing.T) {
+	valid := []interface{}{"s", 1, int64(-3), 2.5, true, []byte{1}, time.Now(), JSON(`{"a":1}`), Ref("x")}
+	for _, object := range valid {
+		if err := ValidateObject(object); err != nil {
+			t.Fatalf("Expected %v to be valid, got %v", object, err)
+		}
+	}
+
+	invalid := []interface{}{math.NaN(), math.Inf(1), uint64(math.MaxUint64), JSON(`{"a":`), JSON(`1 2`)}
+	for _, object := range invalid {
+		if err := ValidateObject(object); err == nil {
+			t.Fatalf("Expected %v to be rejected", object)
+		}
+	}
+}
+
+func TestKpak_JSONRoundTripTypedObjects(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 0, 500, time.UTC)
+	objects := []interface{}{
+		"hello",
+		int64(42),
+		3.25,
+		true,
+		[]byte{0, 1, 2},
+		ts,
+		JSON(`{"a":1,"b":[true,null]}`),
+		Ref("bob"),
+	}
+
+	for _, object := range objects {
+		original := NewKpak("subject", "predicate", object, "src", 0.7)
+		data, err := original.ToJSON()
+		if err != nil {
+			t.Fatalf("Failed to encode %v: %v", object, err)
+		}
+
+		restored, err := FromJSON(data)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", data, err)
+		}
+		if TypeOf(restored.Object) != TypeOf(object) {
+			t.Fatalf("Expected type %s, got %s", TypeOf(object), TypeOf(restored.Object))
+		}
+		if restored.ObjectKey() != original.ObjectKey() {
+			t.Fatalf("Expected object %s, got %s", original.ObjectKey(), restored.ObjectKey())
+		}
+		if restored.ID != original.ID {
+			t.Fatalf("Expected ID to survive the round trip for %v", object)
+		}
+	}
+}
+
+func TestKpak_UnmarshalUntypedRecord(t *testing.T) {
+	// Records written before typed values carry no object_type
+	data := []byte(`{"subject":"s","predicate":"p","object":"v","source":"src","confidence":0.5,"timestamp":1700000000}`)
+	kpak, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to decode legacy record: %v", err)
+	}
+	if kpak.Object != "v" {
+		t.Fatalf("Expected object 'v', got %v", kpak.Object)
+	}
+}
@@ -0,0 +1,108 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// VersionVector counts, per agent, the updates to a fact that an agent had seen.
+// Comparing the vectors of two claims tells whether one was made with knowledge
+// of the other or whether they were made concurrently.
+type VersionVector map[string]uint64
+
+// Ordering is the causal relation between two version vectors.
+type Ordering int
+
+const (
+	// Equal means both vectors describe the same history.
+	Equal Ordering = iota
+	// Before means the vector happened before the other one (the other has seen it).
+	Before
+	// After means the vector has seen everything the other one describes.
+	After
+	// Concurrent means neither vector has seen the other.
+	Concurrent
+)
+
+// String returns the ordering's name.
+func (o Ordering) String() string {
+	switch o {
+	case Equal:
+		return "equal"
+	case Before:
+		return "before"
+	case After:
+		return "after"
+	default:
+		return "concurrent"
+	}
+}
+
+// Clone returns a copy of the vector.
+func (v VersionVector) Clone() VersionVector {
+	clone := make(VersionVector, len(v))
+	for actor, n := range v {
+		clone[actor] = n
+	}
+	return clone
+}
+
+// Increment returns a copy of the vector with the actor's counter advanced by one.
+func (v VersionVector) Increment(actor string) VersionVector {
+	next := v.Clone()
+	next[actor]++
+	return next
+}
+
+// Merge returns the element-wise maximum of both vectors.
+func (v VersionVector) Merge(other VersionVector) VersionVector {
+	merged := v.Clone()
+	for actor, n := range other {
+		if n > merged[actor] {
+			merged[actor] = n
+		}
+	}
+	return merged
+}
+
+// Compare reports how the vector relates to another one.
+func (v VersionVector) Compare(other VersionVector) Ordering {
+	ahead, behind := false, false
+	for actor, n := range v {
+		if n > other[actor] {
+			ahead = true
+		}
+	}
+	for actor, n := range other {
+		if n > v[actor] {
+			behind = true
+		}
+	}
+
+	switch {
+	case ahead && behind:
+		return Concurrent
+	case ahead:
+		return After
+	case behind:
+		return Before
+	default:
+		return Equal
+	}
+}
+
+// String formats the vector as "actor:count" pairs in actor order.
+func (v VersionVector) String() string {
+	actors := make([]string, 0, len(v))
+	for actor := range v {
+		actors = append(actors, actor)
+	}
+	sort.Strings(actors)
+
+	parts := make([]string, len(actors))
+	for i, actor := range actors {
+		parts[i] = fmt.Sprintf("%s:%d", actor, v[actor])
+	}
+	return "{" + strings.Join(parts, ", ") + "}"
+}
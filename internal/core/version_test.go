@@ -0,0 +1,66 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVersionVector_Compare(t *testing.T) {
+	base := VersionVector{"a": 1}
+	tests := []struct {
+		name     string
+		v, other VersionVector
+		want     Ordering
+	}{
+		{"empty", nil, nil, Equal},
+		{"equal", VersionVector{"a": 1}, VersionVector{"a": 1}, Equal},
+		{"after", base.Increment("a"), base, After},
+		{"before", base, base.Increment("b"), Before},
+		{"concurrent", base.Increment("a"), base.Increment("b"), Concurrent},
+		{"unseen actor", VersionVector{"b": 1}, nil, After},
+	}
+	for _, tt := range tests {
+		if got := tt.v.Compare(tt.other); got != tt.want {
+			t.Fatalf("%s: expected %s, got %s", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestVersionVector_IncrementAndMerge(t *testing.T) {
+	base := VersionVector{"a": 2}
+	next := base.Increment("b")
+	if base["b"] != 0 {
+		t.Fatal("Increment should not modify the original vector")
+	}
+
+	merged := next.Merge(VersionVector{"a": 5, "c": 1})
+	if merged.String() != "{a:5, b:1, c:1}" {
+		t.Fatalf("Unexpected merge result %s", merged)
+	}
+	if merged.Compare(next) != After {
+		t.Fatal("A merged vector should have seen both inputs")
+	}
+}
+
+func TestKpak_VersionSurvivesJSON(t *testing.T) {
+	kpak := NewKpak("svc-a", "owner", "alice", "hr", 0.9)
+	id := kpak.ID
+	kpak.Version = VersionVector{"synapse-a": 3}
+
+	data, err := json.Marshal(kpak)
+	if err != nil {
+		t.Fatalf("Failed to encode k-pak: %v", err)
+	}
+	decoded, err := FromJSON(data)
+	if err != nil {
+		t.Fatalf("Failed to decode k-pak: %v", err)
+	}
+	if decoded.Version["synapse-a"] != 3 {
+		t.Fatalf("Expected version to survive, got %v", decoded.Version)
+	}
+
+	decoded.RegenerateComputedFields()
+	if decoded.ID != id {
+		t.Fatal("The version should not be part of the content hash")
+	}
+}
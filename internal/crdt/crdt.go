@@ -0,0 +1,464 @@
+package crdt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// Type names the conflict-free replicated data type a predicate is declared as.
+type Type string
+
+const (
+	GCounter    Type = "g_counter"    // Grow-only counter; objects are non-negative increments
+	PNCounter   Type = "pn_counter"   // Counter; objects are signed increments
+	ORSet       Type = "or_set"       // Observed-remove set; objects are added elements
+	LWWRegister Type = "lww_register" // Last-writer-wins register; objects are the new value
+	LWWMap      Type = "lww_map"      // Last-writer-wins map; objects are JSON objects of fields to set (null deletes)
+)
+
+// ParseType validates a configured CRDT name. An empty name means the predicate is not a CRDT.
+func ParseType(name string) (Type, error) {
+	switch Type(name) {
+	case "", GCounter, PNCounter, ORSet, LWWRegister, LWWMap:
+		return Type(name), nil
+	default:
+		return "", fmt.Errorf("unknown CRDT type %q", name)
+	}
+}
+
+// IsCounter reports whether the type counts increments.
+func (t Type) IsCounter() bool {
+	return t == GCounter || t == PNCounter
+}
+
+// Field is one entry of an LWW map, stamped with the claim that wrote it.
+type Field struct {
+	Value     json.RawMessage `json:"value,omitempty"`
+	Deleted   bool            `json:"deleted,omitempty"`
+	Timestamp int64           `json:"timestamp"`
+	Source    string          `json:"source"`
+	ID        string          `json:"id"`
+}
+
+// State is the replicated state of one CRDT predicate for a subject. States are
+// merged element-wise, so merging is commutative, associative and idempotent and
+// agents converge no matter how often or in which order they exchange states.
+type State struct {
+	Type      Type   `json:"type"`
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+
+	// Counters: increments and decrements per actor (agent); each actor only grows its own slot.
+	// Applied holds the IDs of the k-paks counted, so a retried update counts once.
+	Inc     map[string]int64 `json:"inc,omitempty"`
+	Dec     map[string]int64 `json:"dec,omitempty"`
+	Applied map[string]bool  `json:"applied,omitempty"`
+
+	// OR-set: claims that added an element, by tag (k-pak ID), and the tags observed
+	// removed. A removed tag keeps no claim, only the tombstone.
+	Adds    map[string]*core.Kpak `json:"adds,omitempty"`
+	Removed map[string]bool       `json:"removed,omitempty"`
+
+	// LWW register and map
+	Register *core.Kpak        `json:"register,omitempty"`
+	Fields   map[string]*Field `json:"fields,omitempty"`
+
+	// Last is the most recent claim applied to the state, kept for provenance
+	Last *core.Kpak `json:"last,omitempty"`
+}
+
+// NewState creates an empty state for a subject+predicate.
+func NewState(t Type, subject, predicate string) *State {
+	return &State{
+		Type:      t,
+		Subject:   subject,
+		Predicate: predicate,
+		Inc:       make(map[string]int64),
+		Dec:       make(map[string]int64),
+		Applied:   make(map[string]bool),
+		Adds:      make(map[string]*core.Kpak),
+		Removed:   make(map[string]bool),
+		Fields:    make(map[string]*Field),
+	}
+}
+
+// Apply records a local update carried by a k-pak. Counter increments are credited
+// to actor, the agent applying them, once per k-pak ID. Returns false if the update
+// changed nothing.
+func (s *State) Apply(kpak *core.Kpak, actor string) (bool, error) {
+	s.ensureMaps()
+
+	switch s.Type {
+	case GCounter, PNCounter:
+		if s.Applied[kpak.ID] {
+			return false, nil
+		}
+		delta, err := increment(kpak.Object)
+		if err != nil {
+			return false, err
+		}
+		if delta < 0 && s.Type == GCounter {
+			return false, fmt.Errorf("g_counter increments must not be negative, got %d", delta)
+		}
+		if delta == 0 {
+			return false, nil
+		}
+		if delta > 0 {
+			s.Inc[actor] += delta
+		} else {
+			s.Dec[actor] -= delta
+		}
+		s.Applied[kpak.ID] = true
+
+	case ORSet:
+		if _, exists := s.Adds[kpak.ID]; exists || s.Removed[kpak.ID] {
+			return false, nil
+		}
+		s.Adds[kpak.ID] = kpak
+
+	case LWWRegister:
+		if s.Register != nil && !later(kpak.Timestamp, kpak.Source, kpak.ID, s.Register.Timestamp, s.Register.Source, s.Register.ID) {
+			return false, nil
+		}
+		s.Register = kpak
+
+	case LWWMap:
+		fields, err := mapFields(kpak.Object)
+		if err != nil {
+			return false, err
+		}
+		changed := false
+		for name, value := range fields {
+			field := &Field{Value: value, Timestamp: kpak.Timestamp, Source: kpak.Source, ID: kpak.ID}
+			if bytes.Equal(value, []byte("null")) {
+				field.Value, field.Deleted = nil, true
+			}
+			if s.setField(name, field) {
+				changed = true
+			}
+		}
+		if !changed {
+			return false, nil
+		}
+
+	default:
+		return false, fmt.Errorf("unknown CRDT type %q", s.Type)
+	}
+
+	s.touch(kpak)
+	return true, nil
+}
+
+// Merge folds another replica's state into this one and reports whether anything changed.
+func (s *State) Merge(other *State) (bool, error) {
+	if other.Type != s.Type {
+		return false, fmt.Errorf("cannot merge %s state into %s state", other.Type, s.Type)
+	}
+	s.ensureMaps()
+
+	changed := false
+	for actor, n := range other.Inc {
+		if n > s.Inc[actor] {
+			s.Inc[actor] = n
+			changed = true
+		}
+	}
+	for actor, n := range other.Dec {
+		if n > s.Dec[actor] {
+			s.Dec[actor] = n
+			changed = true
+		}
+	}
+	for id := range other.Applied {
+		if !s.Applied[id] {
+			s.Applied[id] = true
+			changed = true
+		}
+	}
+	for tag := range other.Removed {
+		if !s.Removed[tag] {
+			s.Removed[tag] = true
+			delete(s.Adds, tag)
+			changed = true
+		}
+	}
+	for tag, kpak := range other.Adds {
+		if _, exists := s.Adds[tag]; !exists && !s.Removed[tag] {
+			s.Adds[tag] = kpak
+			changed = true
+		}
+	}
+	if r := other.Register; r != nil {
+		if s.Register == nil || later(r.Timestamp, r.Source, r.ID, s.Register.Timestamp, s.Register.Source, s.Register.ID) {
+			s.Register = r
+			changed = true
+		}
+	}
+	for name, field := range other.Fields {
+		if s.setField(name, field) {
+			changed = true
+		}
+	}
+	if other.Last != nil {
+		s.touch(other.Last)
+	}
+
+	return changed, nil
+}
+
+// Retract removes the elements a source added to an OR-set, or only the given element
+// when objectKey is set. Only adds this replica has observed are removed, so a
+// concurrent add elsewhere survives. Other CRDT types cannot be retracted.
+// Returns the number of adds removed.
+func (s *State) Retract(source, objectKey string) int {
+	if s.Type != ORSet {
+		return 0
+	}
+	s.ensureMaps()
+
+	removed := 0
+	for tag, kpak := range s.Adds {
+		if kpak.Source != source {
+			continue
+		}
+		if objectKey != "" && kpak.ObjectKey() != objectKey {
+			continue
+		}
+		s.Removed[tag] = true
+		delete(s.Adds, tag)
+		removed++
+	}
+	return removed
+}
+
+// Diff returns the part of the state that is newer than before, such that merging
+// it into before gives this state. Persisting diffs keeps each log entry as small
+// as the update it records. A nil before returns the whole state.
+func (s *State) Diff(before *State) *State {
+	if before == nil {
+		return s.Clone()
+	}
+
+	diff := NewState(s.Type, s.Subject, s.Predicate)
+	for actor, n := range s.Inc {
+		if n > before.Inc[actor] {
+			diff.Inc[actor] = n
+		}
+	}
+	for actor, n := range s.Dec {
+		if n > before.Dec[actor] {
+			diff.Dec[actor] = n
+		}
+	}
+	for id := range s.Applied {
+		if !before.Applied[id] {
+			diff.Applied[id] = true
+		}
+	}
+	for tag, kpak := range s.Adds {
+		if _, exists := before.Adds[tag]; !exists {
+			diff.Adds[tag] = kpak
+		}
+	}
+	for tag := range s.Removed {
+		if !before.Removed[tag] {
+			diff.Removed[tag] = true
+		}
+	}
+	if r := s.Register; r != nil && (before.Register == nil || r.ID != before.Register.ID) {
+		diff.Register = r
+	}
+	for name, field := range s.Fields {
+		if old, exists := before.Fields[name]; !exists || old.ID != field.ID || old.Deleted != field.Deleted {
+			diff.Fields[name] = field
+		}
+	}
+	diff.Last = s.Last
+	return diff
+}
+
+// Value returns the current value: the counter total, the set's elements as a JSON
+// array, the register's object or the map's live fields as a JSON object. It returns
+// nil when there is no value (an empty set or map, or an unwritten register).
+func (s *State) Value() interface{} {
+	switch s.Type {
+	case GCounter, PNCounter:
+		var total int64
+		for _, n := range s.Inc {
+			total += n
+		}
+		for _, n := range s.Dec {
+			total -= n
+		}
+		return total
+
+	case ORSet:
+		elements := make(map[string]interface{})
+		for tag, kpak := range s.Adds {
+			if !s.Removed[tag] {
+				elements[kpak.ObjectKey()] = kpak.Object
+			}
+		}
+		if len(elements) == 0 {
+			return nil
+		}
+		keys := make([]string, 0, len(elements))
+		for key := range elements {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = elements[key]
+			if doc, isJSON := values[i].(core.JSON); isJSON {
+				values[i] = json.RawMessage(doc)
+			}
+		}
+		data, err := json.Marshal(values)
+		if err != nil {
+			return nil
+		}
+		return core.JSON(data)
+
+	case LWWRegister:
+		if s.Register == nil {
+			return nil
+		}
+		return s.Register.Object
+
+	case LWWMap:
+		fields := make(map[string]json.RawMessage)
+		for name, field := range s.Fields {
+			if !field.Deleted {
+				fields[name] = field.Value
+			}
+		}
+		if len(fields) == 0 {
+			return nil
+		}
+		data, err := json.Marshal(fields)
+		if err != nil {
+			return nil
+		}
+		return core.JSON(data)
+	}
+	return nil
+}
+
+// Materialize returns the state's value as a k-pak attributed to the most recent
+// update, or nil when there is no value.
+func (s *State) Materialize() *core.Kpak {
+	value := s.Value()
+	if value == nil || s.Last == nil {
+		return nil
+	}
+
+	confidence := float32(1.0)
+	if s.Type == LWWRegister {
+		confidence = s.Register.Confidence
+	}
+
+	kpak := core.NewKpak(s.Subject, s.Predicate, value, s.Last.Source, confidence)
+	kpak.Timestamp = s.Last.Timestamp
+	kpak.RegenerateComputedFields()
+	return kpak
+}
+
+// Clone returns a deep copy of the state's maps; the k-paks they hold are shared.
+func (s *State) Clone() *State {
+	clone := NewState(s.Type, s.Subject, s.Predicate)
+	clone.Merge(s)
+	return clone
+}
+
+// setField stores an LWW map field if it is newer than the current one.
+func (s *State) setField(name string, field *Field) bool {
+	current, exists := s.Fields[name]
+	if exists && !later(field.Timestamp, field.Source, field.ID, current.Timestamp, current.Source, current.ID) {
+		return false
+	}
+	s.Fields[name] = field
+	return true
+}
+
+// touch records a claim as the latest update if it is newer than the current one.
+func (s *State) touch(kpak *core.Kpak) {
+	if s.Last == nil || later(kpak.Timestamp, kpak.Source, kpak.ID, s.Last.Timestamp, s.Last.Source, s.Last.ID) {
+		s.Last = kpak
+	}
+}
+
+// ensureMaps initializes maps left nil by JSON decoding.
+func (s *State) ensureMaps() {
+	if s.Inc == nil {
+		s.Inc = make(map[string]int64)
+	}
+	if s.Dec == nil {
+		s.Dec = make(map[string]int64)
+	}
+	if s.Applied == nil {
+		s.Applied = make(map[string]bool)
+	}
+	if s.Adds == nil {
+		s.Adds = make(map[string]*core.Kpak)
+	}
+	if s.Removed == nil {
+		s.Removed = make(map[string]bool)
+	}
+	if s.Fields == nil {
+		s.Fields = make(map[string]*Field)
+	}
+}
+
+// later orders writes by timestamp, breaking ties by source and then ID so every
+// replica picks the same winner.
+func later(ts int64, source, id string, otherTS int64, otherSource, otherID string) bool {
+	if ts != otherTS {
+		return ts > otherTS
+	}
+	if source != otherSource {
+		return source > otherSource
+	}
+	return id > otherID
+}
+
+// increment reads a counter update from a k-pak object.
+func increment(object interface{}) (int64, error) {
+	if core.TypeOf(object) != core.ValueInt {
+		return 0, fmt.Errorf("counter updates must be integers, got %s", core.TypeOf(object))
+	}
+	if err := core.ValidateObject(object); err != nil {
+		return 0, err
+	}
+	value := reflect.ValueOf(object)
+	if value.CanInt() {
+		return value.Int(), nil
+	}
+	return int64(value.Uint()), nil
+}
+
+// mapFields reads the fields of an LWW map update from a k-pak object.
+func mapFields(object interface{}) (map[string]json.RawMessage, error) {
+	var raw []byte
+	switch v := object.(type) {
+	case core.JSON:
+		raw = v
+	case json.RawMessage:
+		raw = v
+	case string:
+		raw = []byte(v)
+	default:
+		return nil, fmt.Errorf("lww_map updates must be JSON objects, got %s", core.TypeOf(object))
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return nil, fmt.Errorf("lww_map updates must be JSON objects")
+	}
+	return fields, nil
+}
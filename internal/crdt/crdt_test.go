@@ -0,0 +1,217 @@
+package crdt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func update(object interface{}, source string, timestamp int64) *core.Kpak {
+	kpak := core.NewKpak("svc-a", "incidents", object, source, 0.9)
+	kpak.Timestamp = timestamp
+	kpak.RegenerateComputedFields()
+	return kpak
+}
+
+func TestParseType(t *testing.T) {
+	for _, name := range []string{"", "g_counter", "pn_counter", "or_set", "lww_register", "lww_map"} {
+		if _, err := ParseType(name); err != nil {
+			t.Fatalf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+	if _, err := ParseType("mv_register"); err == nil {
+		t.Fatal("Expected unknown type to be rejected")
+	}
+}
+
+func TestGCounter_MergeIsCommutativeAndIdempotent(t *testing.T) {
+	a := NewState(GCounter, "svc-a", "incidents")
+	b := NewState(GCounter, "svc-a", "incidents")
+
+	a.Apply(update(int64(2), "scout", 1), "agent-a")
+	a.Apply(update(int64(1), "scout", 2), "agent-a")
+	b.Apply(update(int64(5), "scout", 3), "agent-b")
+
+	ab := a.Clone()
+	ab.Merge(b)
+	ba := b.Clone()
+	ba.Merge(a)
+	if ab.Value() != int64(8) || ba.Value() != int64(8) {
+		t.Fatalf("Expected both merge orders to count 8, got %v and %v", ab.Value(), ba.Value())
+	}
+
+	if changed, _ := ab.Merge(b); changed {
+		t.Fatal("Merging the same state twice should change nothing")
+	}
+	if ab.Value() != int64(8) {
+		t.Fatalf("Expected idempotent merge to keep 8, got %v", ab.Value())
+	}
+
+	if _, err := a.Apply(update(int64(-1), "scout", 4), "agent-a"); err == nil {
+		t.Fatal("Expected a negative g_counter increment to be rejected")
+	}
+	if _, err := a.Apply(update("one", "scout", 4), "agent-a"); err == nil {
+		t.Fatal("Expected a non-integer increment to be rejected")
+	}
+}
+
+func TestPNCounter(t *testing.T) {
+	a := NewState(PNCounter, "svc-a", "open_incidents")
+	b := NewState(PNCounter, "svc-a", "open_incidents")
+
+	a.Apply(update(int64(3), "scout", 1), "agent-a")
+	b.Apply(update(int64(-1), "scout", 2), "agent-b")
+	a.Merge(b)
+
+	if a.Value() != int64(2) {
+		t.Fatalf("Expected 2, got %v", a.Value())
+	}
+}
+
+func TestCounter_CountsEachUpdateOnce(t *testing.T) {
+	a := NewState(PNCounter, "svc-a", "open_incidents")
+	retried := update(int64(3), "scout", 1)
+	a.Apply(retried, "agent-a")
+	if changed, _ := a.Apply(retried, "agent-a"); changed {
+		t.Fatal("A retried update should change nothing")
+	}
+
+	// The retry reaching another agent after the states merged is not counted either
+	b := NewState(PNCounter, "svc-a", "open_incidents")
+	b.Merge(a)
+	if changed, _ := b.Apply(retried, "agent-b"); changed || b.Value() != int64(3) {
+		t.Fatalf("Expected the retry to be ignored on another replica, got %v", b.Value())
+	}
+}
+
+func TestORSet_AddWinsOverConcurrentRemove(t *testing.T) {
+	a := NewState(ORSet, "host-1", "tags")
+	a.Apply(update("web", "inventory", 1), "agent-a")
+	a.Apply(update("prod", "inventory", 1), "agent-a")
+
+	b := a.Clone()
+
+	// Agent A removes "web" while agent B concurrently re-adds it
+	if removed := a.Retract("inventory", core.CanonicalObject("web")); removed != 1 {
+		t.Fatalf("Expected 1 add removed, got %d", removed)
+	}
+	b.Apply(update("web", "inventory", 2), "agent-b")
+
+	a.Merge(b)
+	b.Merge(a)
+
+	var va, vb []string
+	json.Unmarshal(a.Value().(core.JSON), &va)
+	json.Unmarshal(b.Value().(core.JSON), &vb)
+	if len(va) != 2 || len(vb) != 2 || va[0] != vb[0] {
+		t.Fatalf("Expected both replicas to keep web and prod, got %v and %v", va, vb)
+	}
+
+	// Removing every observed add empties the set
+	a.Retract("inventory", "")
+	if a.Value() != nil {
+		t.Fatalf("Expected empty set, got %s", a.Value())
+	}
+
+	// Removed adds keep only their tombstone, and merging them again does not bring them back
+	if len(a.Adds) != 0 {
+		t.Fatalf("Expected removed adds to be dropped, got %d", len(a.Adds))
+	}
+	if a.Merge(b); a.Value() != nil {
+		t.Fatalf("Expected removed adds to stay removed, got %s", a.Value())
+	}
+}
+
+func TestState_Diff(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		t       Type
+		updates []*core.Kpak
+	}{
+		{"counter", PNCounter, []*core.Kpak{update(int64(2), "scout", 1), update(int64(-1), "scout", 2)}},
+		{"set", ORSet, []*core.Kpak{update("web", "inventory", 1), update("prod", "inventory", 2)}},
+		{"register", LWWRegister, []*core.Kpak{update("node-1", "election", 1), update("node-2", "election", 2)}},
+		{"map", LWWMap, []*core.Kpak{update(core.JSON(`{"a": 1, "b": 2}`), "scout", 1), update(core.JSON(`{"b": null}`), "scout", 2)}},
+	} {
+		state := NewState(tc.t, "svc-a", "incidents")
+		state.Apply(tc.updates[0], "agent-a")
+		before := state.Clone()
+		state.Apply(tc.updates[1], "agent-a")
+		if tc.t == ORSet {
+			state.Retract("inventory", core.CanonicalObject("web"))
+		}
+
+		diff := state.Diff(before)
+		if tc.t == ORSet && len(diff.Adds) != 1 {
+			t.Fatalf("%s: expected only the new add in the diff, got %d", tc.name, len(diff.Adds))
+		}
+		replayed := NewState(tc.t, "svc-a", "incidents")
+		replayed.Merge(before)
+		replayed.Merge(diff)
+		got, _ := json.Marshal(replayed.Value())
+		want, _ := json.Marshal(state.Value())
+		if string(got) != string(want) {
+			t.Fatalf("%s: expected the diff to restore %s, got %s", tc.name, want, got)
+		}
+	}
+}
+
+func TestLWWRegister(t *testing.T) {
+	a := NewState(LWWRegister, "svc-a", "leader")
+	b := NewState(LWWRegister, "svc-a", "leader")
+
+	a.Apply(update("node-1", "election", 10), "agent-a")
+	b.Apply(update("node-2", "election", 20), "agent-b")
+	if changed, _ := b.Apply(update("node-0", "election", 5), "agent-b"); changed {
+		t.Fatal("An older write should not replace the register")
+	}
+
+	a.Merge(b)
+	if a.Value() != "node-2" {
+		t.Fatalf("Expected the latest write to win, got %v", a.Value())
+	}
+	if kpak := a.Materialize(); kpak == nil || kpak.Object != "node-2" || kpak.Timestamp != 20 {
+		t.Fatalf("Unexpected materialized register %+v", kpak)
+	}
+}
+
+func TestLWWMap(t *testing.T) {
+	a := NewState(LWWMap, "cluster", "node_load")
+	b := NewState(LWWMap, "cluster", "node_load")
+
+	a.Apply(update(core.JSON(`{"node-1": 0.5, "node-2": 0.7}`), "node-1", 10), "agent-a")
+	b.Apply(update(core.JSON(`{"node-2": 0.9, "node-3": 0.1}`), "node-2", 20), "agent-b")
+	b.Apply(update(core.JSON(`{"node-3": null}`), "node-3", 30), "agent-b")
+
+	a.Merge(b)
+	if got := string(a.Value().(core.JSON)); got != `{"node-1":0.5,"node-2":0.9}` {
+		t.Fatalf("Unexpected map value %s", got)
+	}
+
+	if _, err := a.Apply(update(core.JSON(`[1, 2]`), "node-1", 40), "agent-a"); err == nil {
+		t.Fatal("Expected a non-object update to be rejected")
+	}
+}
+
+func TestState_JSONRoundTrip(t *testing.T) {
+	state := NewState(ORSet, "host-1", "tags")
+	state.Apply(update("web", "inventory", 1), "agent-a")
+	state.Apply(update(int64(7), "inventory", 2), "agent-a")
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		t.Fatalf("Failed to encode state: %v", err)
+	}
+	var restored State
+	if err := json.Unmarshal(data, &restored); err != nil {
+		t.Fatalf("Failed to decode state: %v", err)
+	}
+
+	if string(restored.Value().(core.JSON)) != string(state.Value().(core.JSON)) {
+		t.Fatalf("Expected %s, got %s", state.Value(), restored.Value())
+	}
+	if changed, _ := restored.Merge(state); changed {
+		t.Fatal("A restored state should already contain everything")
+	}
+}
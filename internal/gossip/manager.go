@@ -1,15 +1,23 @@
 package gossip
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
-	"log"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/memberlist"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/logging"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
+	"github.com/Pew-X/sutra/internal/tracing"
 )
 
 // Manager handles peer-to-peer gossip networking for the Synapse mesh. based on gossip protocol
@@ -20,10 +28,22 @@ type Manager struct {
 	eventHandler *synapseEventDelegate
 
 	// Callbacks
-	onKpakReceived func(*core.Kpak) bool // Returns true if k-pak was accepted
+	onKpakReceived    func(context.Context, *core.Kpak) bool // Returns true if k-pak was accepted; ctx carries the sender's trace
+	onRetractReceived func(*Retraction)
+	onSchemaReceived  func(*schema.Schema)
+	onCRDTReceived    func(*crdt.State)
+	onPinReceived     func(*reconciliation.Pin)
+	onReviewReceived  func(*review.Item)
+	kpakSource        func() []*core.Kpak          // Claims shared with peers joining or being joined
+	schemaSource      func() []*schema.Schema      // Schemas shared with peers during state sync
+	crdtSource        func() []*crdt.State         // CRDT states shared with peers during state sync
+	pinSource         func() []*reconciliation.Pin // Operator pins shared with peers during state sync
+	reviewSource      func() []*review.Item        // Held claims and decisions shared with peers during state sync
+	onMessage         func(direction, msgType string)
 
 	mutex   sync.RWMutex
 	running bool
+	synced  atomic.Bool // Set once a peer's state, claims included, has been merged
 }
 
 // Config holds gossip networking configuration.
@@ -32,6 +52,7 @@ type Config struct {
 	BindPort    int      // Local gossip port
 	JoinPeers   []string // List of peers to join
 	ClusterName string   // Cluster identifier
+	GRPCPort    int      // API port advertised to other members (0 = not advertised)
 }
 
 // synapseDelegate handles memberlist delegation callbacks.
@@ -57,6 +78,25 @@ func NewManager(config *Config) (*Manager, error) {
 	return manager, nil
 }
 
+// logger is the gossip layer's logger.
+var logger = logging.For("gossip")
+
+// tracerScope names the gossip layer's spans.
+const tracerScope = "github.com/Pew-X/sutra/internal/gossip"
+
+// maxBestEffortBytes is the largest message sent as a single UDP packet, memberlist's
+// default UDP buffer size. Larger ones go over TCP so they aren't lost to the limit.
+const maxBestEffortBytes = 1400
+
+// maxSyncStateBytes keeps the state sent on join under memberlist's 20 MiB limit,
+// past which it refuses the exchange altogether.
+const maxSyncStateBytes = 16 << 20
+
+// NodeName returns the name an agent bound to the given gossip address has in the mesh.
+func NodeName(bindAddr string, bindPort int) string {
+	return fmt.Sprintf("synapse-%s-%d", bindAddr, bindPort)
+}
+
 // Start initializes and starts the gossip protocol.
 func (m *Manager) Start() error {
 	m.mutex.Lock()
@@ -68,7 +108,7 @@ func (m *Manager) Start() error {
 
 	// Configure memberlist
 	mlConfig := memberlist.DefaultLANConfig()
-	mlConfig.Name = fmt.Sprintf("synapse-%s-%d", m.config.BindAddr, m.config.BindPort)
+	mlConfig.Name = NodeName(m.config.BindAddr, m.config.BindPort)
 	mlConfig.BindAddr = m.config.BindAddr
 	mlConfig.BindPort = m.config.BindPort
 	mlConfig.AdvertiseAddr = m.config.BindAddr
@@ -76,8 +116,8 @@ func (m *Manager) Start() error {
 	mlConfig.Delegate = m.delegate
 	mlConfig.Events = m.eventHandler
 
-	// Reduce log verbose
-	mlConfig.Logger = log.New(log.Writer(), "[GOSSIP] ", log.LstdFlags)
+	// Memberlist tags its lines with a level, so they follow log_level
+	mlConfig.Logger = logging.StdLogger(logging.For("memberlist"))
 
 	// Create memberlist
 	ml, err := memberlist.Create(mlConfig)
@@ -89,16 +129,19 @@ func (m *Manager) Start() error {
 
 	// Join existing cluster if peers specified
 	if len(m.config.JoinPeers) > 0 {
-		log.Printf("Joining gossip mesh with peers: %v", m.config.JoinPeers)
+		logger.Info("Joining gossip mesh", "peers", m.config.JoinPeers)
 		joined, err := m.memberlist.Join(m.config.JoinPeers)
 		if err != nil {
-			log.Printf("Warning: failed to join some peers: %v", err)
+			logger.Warn("Failed to join some peers", logging.KeyError, err)
+		}
+		logger.Info("Joined gossip mesh", "joined", joined)
+		if joined > 0 {
+			m.synced.Store(true)
 		}
-		log.Printf("Successfully joined %d peers", joined)
 	}
 
 	m.running = true
-	log.Printf("Gossip manager started on %s:%d", m.config.BindAddr, m.config.BindPort)
+	logger.Info("Gossip manager started", "addr", fmt.Sprintf("%s:%d", m.config.BindAddr, m.config.BindPort))
 
 	return nil
 }
@@ -114,21 +157,36 @@ func (m *Manager) Stop() error {
 
 	if m.memberlist != nil {
 		if err := m.memberlist.Leave(time.Second * 5); err != nil {
-			log.Printf("Warning: error leaving cluster: %v", err)
+			logger.Warn("Failed to leave cluster", logging.KeyError, err)
 		}
 		if err := m.memberlist.Shutdown(); err != nil {
-			log.Printf("Warning: error shutting down memberlist: %v", err)
+			logger.Warn("Failed to shut down memberlist", logging.KeyError, err)
 		}
 	}
 
 	m.running = false
-	log.Printf("Gossip manager stopped")
+	logger.Info("Gossip manager stopped")
 
 	return nil
 }
 
 // BroadcastKpak broadcasts a k-pak to all peers in the mesh.
 func (m *Manager) BroadcastKpak(kpak *core.Kpak) error {
+	return m.broadcastKpak(kpak, nil)
+}
+
+// BroadcastKpakContext is BroadcastKpak traced as a span under ctx. The span's
+// context travels with the message, so peers continue the same trace.
+func (m *Manager) BroadcastKpakContext(ctx context.Context, kpak *core.Kpak) error {
+	ctx, span := tracing.Start(ctx, tracerScope, "Manager.BroadcastKpak", tracing.KpakAttributes(kpak)...)
+	defer span.End()
+
+	err := m.broadcastKpak(kpak, tracing.Inject(ctx))
+	tracing.RecordError(span, err)
+	return err
+}
+
+func (m *Manager) broadcastKpak(kpak *core.Kpak, traceContext map[string]string) error {
 	if !m.running || m.memberlist == nil {
 		return fmt.Errorf("gossip manager not running")
 	}
@@ -139,34 +197,219 @@ func (m *Manager) BroadcastKpak(kpak *core.Kpak) error {
 		return fmt.Errorf("failed to serialize k-pak: %w", err)
 	}
 
-	// Create gossip message
-	msg := &GossipMessage{
-		Type:    "kpak",
-		Payload: data,
+	// Claims stamped at ingest keep their origin however often they are re-sent
+	origin, originatedAt := kpak.Origin, kpak.OriginatedAt
+	if origin == "" {
+		origin, originatedAt = m.localName(), time.Now().UnixNano()
+	}
+
+	return m.broadcast(&GossipMessage{
+		Type:         "kpak",
+		Payload:      data,
+		TraceContext: traceContext,
+		Origin:       origin,
+		OriginatedAt: originatedAt,
+	})
+}
+
+// StampOrigin records this agent and the current time as where and when a claim
+// entered the mesh. Call it when the claim is taken in, before it is shared.
+func (m *Manager) StampOrigin(kpak *core.Kpak) {
+	kpak.Origin = m.localName()
+	kpak.OriginatedAt = time.Now().UnixNano()
+}
+
+// localName returns this agent's name in the mesh.
+func (m *Manager) localName() string {
+	if m.memberlist != nil {
+		return m.memberlist.LocalNode().Name
+	}
+	return NodeName(m.config.BindAddr, m.config.BindPort)
+}
+
+// BroadcastRetract tells all peers that a source withdrew its claims for a subject+predicate.
+func (m *Manager) BroadcastRetract(retraction *Retraction) error {
+	if !m.running || m.memberlist == nil {
+		return fmt.Errorf("gossip manager not running")
+	}
+
+	data, err := json.Marshal(retraction)
+	if err != nil {
+		return fmt.Errorf("failed to serialize retraction: %w", err)
+	}
+
+	return m.broadcast(&GossipMessage{Type: "retract", Payload: data})
+}
+
+// BroadcastSchema shares a predicate schema with all peers in the mesh.
+func (m *Manager) BroadcastSchema(s *schema.Schema) error {
+	if !m.running || m.memberlist == nil {
+		return fmt.Errorf("gossip manager not running")
 	}
 
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to serialize schema: %w", err)
+	}
+
+	return m.broadcast(&GossipMessage{Type: "schema", Payload: data})
+}
+
+// BroadcastCRDT shares the replicated state of a CRDT predicate with all peers.
+// A delta merges like the full state, so callers send only what changed.
+func (m *Manager) BroadcastCRDT(state *crdt.State) error {
+	if !m.running || m.memberlist == nil {
+		return fmt.Errorf("gossip manager not running")
+	}
+
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to serialize CRDT state: %w", err)
+	}
+
+	return m.broadcast(&GossipMessage{Type: "crdt", Payload: data})
+}
+
+// BroadcastPin shares an operator pin, or the lifting of one, with all peers.
+func (m *Manager) BroadcastPin(pin *reconciliation.Pin) error {
+	if !m.running || m.memberlist == nil {
+		return fmt.Errorf("gossip manager not running")
+	}
+
+	data, err := json.Marshal(pin)
+	if err != nil {
+		return fmt.Errorf("failed to serialize pin: %w", err)
+	}
+
+	return m.broadcast(&GossipMessage{Type: "pin", Payload: data})
+}
+
+// BroadcastReview shares a claim held for review, or the decision on it, with all peers.
+func (m *Manager) BroadcastReview(item *review.Item) error {
+	if !m.running || m.memberlist == nil {
+		return fmt.Errorf("gossip manager not running")
+	}
+
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to serialize review item: %w", err)
+	}
+
+	return m.broadcast(&GossipMessage{Type: "review", Payload: data})
+}
+
+// broadcast sends a gossip message to every other member, over UDP unless it is too
+// large for one packet.
+func (m *Manager) broadcast(msg *GossipMessage) error {
 	msgData, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to serialize gossip message: %w", err)
 	}
+	send := m.memberlist.SendBestEffort
+	if len(msgData) > maxBestEffortBytes {
+		send = m.memberlist.SendReliable
+	}
 
 	// Broadcast to cluster
 	for _, member := range m.memberlist.Members() {
 		if member.Name != m.memberlist.LocalNode().Name {
-			if err := m.memberlist.SendBestEffort(member, msgData); err != nil {
-				log.Printf("Warning: failed to send message to %s: %v", member.Name, err)
+			if err := send(member, msgData); err != nil {
+				logger.Warn("Failed to send gossip message", logging.KeyPeer, member.Name, "type", msg.Type, logging.KeyError, err)
+				m.observeMessage("failed", msg.Type)
+				continue
 			}
+			m.observeMessage("sent", msg.Type)
 		}
 	}
 
 	return nil
 }
 
+// AdvertiseGRPCPort sets the API port advertised to other members. Call it before Start.
+func (m *Manager) AdvertiseGRPCPort(port int) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.config.GRPCPort = port
+}
+
+// SetMessageObserver sets a hook called for every message "sent", "received" or
+// "failed" (not delivered, or not understood), with its type.
+func (m *Manager) SetMessageObserver(observe func(direction, msgType string)) {
+	m.onMessage = observe
+}
+
+func (m *Manager) observeMessage(direction, msgType string) {
+	if m.onMessage != nil {
+		m.onMessage(direction, msgType)
+	}
+}
+
 // SetKpakHandler sets the callback for handling received k-paks.
-func (m *Manager) SetKpakHandler(handler func(*core.Kpak) bool) {
+func (m *Manager) SetKpakHandler(handler func(context.Context, *core.Kpak) bool) {
 	m.onKpakReceived = handler
 }
 
+// SetRetractHandler sets the callback for handling received retractions.
+func (m *Manager) SetRetractHandler(handler func(*Retraction)) {
+	m.onRetractReceived = handler
+}
+
+// SetSchemaHandler sets the callback for handling received schemas.
+func (m *Manager) SetSchemaHandler(handler func(*schema.Schema)) {
+	m.onSchemaReceived = handler
+}
+
+// SetKpakSource sets the function that lists the claims exchanged with peers
+// when one joins the other, so an agent catches up on what it missed while away.
+func (m *Manager) SetKpakSource(source func() []*core.Kpak) {
+	m.kpakSource = source
+}
+
+// SetSchemaSource sets the function that lists the schemas exchanged with peers
+// during state sync, so agents that missed a broadcast still converge on them.
+func (m *Manager) SetSchemaSource(source func() []*schema.Schema) {
+	m.schemaSource = source
+}
+
+// SetCRDTHandler sets the callback for handling received CRDT states.
+func (m *Manager) SetCRDTHandler(handler func(*crdt.State)) {
+	m.onCRDTReceived = handler
+}
+
+// SetCRDTSource sets the function that lists the CRDT states exchanged with peers
+// during state sync.
+func (m *Manager) SetCRDTSource(source func() []*crdt.State) {
+	m.crdtSource = source
+}
+
+// SetPinHandler sets the callback for handling received pins.
+func (m *Manager) SetPinHandler(handler func(*reconciliation.Pin)) {
+	m.onPinReceived = handler
+}
+
+// SetPinSource sets the function that lists the pins exchanged with peers during
+// state sync.
+func (m *Manager) SetPinSource(source func() []*reconciliation.Pin) {
+	m.pinSource = source
+}
+
+// SetReviewHandler sets the callback for handling received review items.
+func (m *Manager) SetReviewHandler(handler func(*review.Item)) {
+	m.onReviewReceived = handler
+}
+
+// SetReviewSource sets the function that lists the review items exchanged with
+// peers during state sync.
+func (m *Manager) SetReviewSource(source func() []*review.Item) {
+	m.reviewSource = source
+}
+
+// Synced reports whether this agent has merged a peer's state, claims included,
+// either by joining one or by being joined. It stays true once set.
+func (m *Manager) Synced() bool {
+	return m.synced.Load()
+}
+
 // GetMembers returns information about cluster members.
 func (m *Manager) GetMembers() []MemberInfo {
 	if !m.running || m.memberlist == nil {
@@ -183,6 +426,10 @@ func (m *Manager) GetMembers() []MemberInfo {
 			Port:  member.Port,
 			State: int(member.State),
 		}
+		var meta nodeMeta
+		if json.Unmarshal(member.Meta, &meta) == nil {
+			result[i].GRPCPort = meta.GRPCPort
+		}
 	}
 
 	return result
@@ -194,22 +441,83 @@ type MemberInfo struct {
 	Addr  string `json:"addr"`
 	Port  uint16 `json:"port"`
 	State int    `json:"state"`
+
+	GRPCPort int `json:"grpc_port,omitempty"` // Advertised API port (0 = unknown)
+}
+
+// Alive reports whether memberlist considers the member alive.
+func (m MemberInfo) Alive() bool {
+	return m.State == int(memberlist.StateAlive)
 }
 
 // GossipMessage represents a message sent through the gossip protocol.
 type GossipMessage struct {
-	Type    string `json:"type"`
-	Payload []byte `json:"payload"`
+	Type         string            `json:"type"`
+	Payload      []byte            `json:"payload"`
+	TraceContext map[string]string `json:"trace_context,omitempty"` // W3C trace context of the sending span
+
+	// Stamped on k-paks by the agent that took them in, so receivers can time propagation
+	Origin       string `json:"origin,omitempty"`        // Mesh name of the originating agent
+	OriginatedAt int64  `json:"originated_at,omitempty"` // Unix nanoseconds when it took the k-pak in
+}
+
+// Origin is where and when a gossiped k-pak entered the mesh.
+type Origin struct {
+	Node string
+	At   time.Time
+}
+
+type originKey struct{}
+
+// WithOrigin returns ctx carrying the origin of a gossiped k-pak.
+func WithOrigin(ctx context.Context, origin Origin) context.Context {
+	return context.WithValue(ctx, originKey{}, origin)
+}
+
+// OriginFromContext returns the origin of the k-pak a handler was called with,
+// if the sender stamped one.
+func OriginFromContext(ctx context.Context) (Origin, bool) {
+	origin, ok := ctx.Value(originKey{}).(Origin)
+	return origin, ok
+}
+
+// nodeMeta is the metadata members advertise to each other.
+type nodeMeta struct {
+	Type     string `json:"type"`
+	Version  string `json:"version"`
+	Started  int64  `json:"started"`
+	GRPCPort int    `json:"grpc_port,omitempty"`
+}
+
+// syncState is the state exchanged with peers when they join and periodically after.
+type syncState struct {
+	Kpaks   []*core.Kpak          `json:"kpaks,omitempty"` // Only on join
+	Schemas []*schema.Schema      `json:"schemas,omitempty"`
+	CRDTs   []*crdt.State         `json:"crdts,omitempty"`
+	Pins    []*reconciliation.Pin `json:"pins,omitempty"`
+	Reviews []*review.Item        `json:"reviews,omitempty"`
+}
+
+// Retraction withdraws a source's claims about a subject+predicate across the mesh.
+// ObjectKey limits the retraction to claims of one value, such as a single member
+// of a multi-valued predicate.
+type Retraction struct {
+	Subject   string `json:"subject"`
+	Predicate string `json:"predicate"`
+	Source    string `json:"source"`
+	ObjectKey string `json:"object_key,omitempty"`
+	Timestamp int64  `json:"timestamp"`
 }
 
 // Memberlist delegate implementation
 
 // NodeMeta returns metadata about this node.
 func (d *synapseDelegate) NodeMeta(limit int) []byte {
-	meta := map[string]interface{}{
-		"type":    "synapse-agent",
-		"version": "1.0.0",
-		"started": time.Now().Unix(),
+	meta := nodeMeta{
+		Type:     "synapse-agent",
+		Version:  "1.0.0",
+		Started:  time.Now().Unix(),
+		GRPCPort: d.manager.config.GRPCPort,
 	}
 
 	data, _ := json.Marshal(meta)
@@ -223,35 +531,124 @@ func (d *synapseDelegate) NodeMeta(limit int) []byte {
 func (d *synapseDelegate) NotifyMsg(data []byte) {
 	var msg GossipMessage
 	if err := json.Unmarshal(data, &msg); err != nil {
-		log.Printf("Warning: failed to unmarshal gossip message: %v", err)
+		logger.Warn("Failed to unmarshal gossip message", logging.KeyError, err)
+		d.manager.observeMessage("failed", "invalid")
 		return
 	}
-
 	switch msg.Type {
 	case "kpak":
-		d.handleKpakMessage(msg.Payload)
+		ctx := tracing.Extract(context.Background(), msg.TraceContext)
+		if msg.Origin != "" {
+			ctx = WithOrigin(ctx, Origin{Node: msg.Origin, At: time.Unix(0, msg.OriginatedAt)})
+		}
+		d.handleKpakMessage(ctx, msg.Payload)
+	case "retract":
+		d.handleRetractMessage(msg.Payload)
+	case "schema":
+		d.handleSchemaMessage(msg.Payload)
+	case "crdt":
+		d.handleCRDTMessage(msg.Payload)
+	case "pin":
+		d.handlePinMessage(msg.Payload)
+	case "review":
+		d.handleReviewMessage(msg.Payload)
 	default:
-		log.Printf("Warning: unknown gossip message type: %s", msg.Type)
+		logger.Warn("Unknown gossip message type", "type", msg.Type)
+		d.manager.observeMessage("failed", msg.Type)
+		return
 	}
+	d.manager.observeMessage("received", msg.Type)
 }
 
-// handleKpakMessage processes a received k-pak from the gossip network.
-func (d *synapseDelegate) handleKpakMessage(payload []byte) {
+// handleKpakMessage processes a received k-pak from the gossip network, traced
+// under the sender's span when the message carries one.
+func (d *synapseDelegate) handleKpakMessage(ctx context.Context, payload []byte) {
+	ctx, span := tracing.Start(ctx, tracerScope, "Manager.handleKpakMessage")
+	defer span.End()
+
 	var kpak core.Kpak
 	if err := json.Unmarshal(payload, &kpak); err != nil {
-		log.Printf("Warning: failed to unmarshal k-pak from gossip: %v", err)
+		logger.Warn("Failed to unmarshal k-pak from gossip", logging.KeyError, err)
+		tracing.RecordError(span, err)
 		return
 	}
+	span.SetAttributes(tracing.KpakAttributes(&kpak)...)
 
 	// Call the handler if set
 	if d.manager.onKpakReceived != nil {
-		accepted := d.manager.onKpakReceived(&kpak)
+		accepted := d.manager.onKpakReceived(ctx, &kpak)
+		span.SetAttributes(attribute.Bool("kpak.accepted", accepted))
 		if accepted {
-			log.Printf("Gossip: accepted k-pak %s from network", kpak.ID)
+			logger.Debug("Accepted k-pak from mesh", logging.KpakFields(&kpak)...)
 		}
 	}
 }
 
+// handleRetractMessage processes a received retraction from the gossip network.
+func (d *synapseDelegate) handleRetractMessage(payload []byte) {
+	var retraction Retraction
+	if err := json.Unmarshal(payload, &retraction); err != nil {
+		logger.Warn("Failed to unmarshal retraction from gossip", logging.KeyError, err)
+		return
+	}
+
+	if d.manager.onRetractReceived != nil {
+		d.manager.onRetractReceived(&retraction)
+	}
+}
+
+// handleSchemaMessage processes a received schema from the gossip network.
+func (d *synapseDelegate) handleSchemaMessage(payload []byte) {
+	var s schema.Schema
+	if err := json.Unmarshal(payload, &s); err != nil {
+		logger.Warn("Failed to unmarshal schema from gossip", logging.KeyError, err)
+		return
+	}
+
+	if d.manager.onSchemaReceived != nil {
+		d.manager.onSchemaReceived(&s)
+	}
+}
+
+// handleCRDTMessage processes a received CRDT state from the gossip network.
+func (d *synapseDelegate) handleCRDTMessage(payload []byte) {
+	var state crdt.State
+	if err := json.Unmarshal(payload, &state); err != nil {
+		logger.Warn("Failed to unmarshal CRDT state from gossip", logging.KeyError, err)
+		return
+	}
+
+	if d.manager.onCRDTReceived != nil {
+		d.manager.onCRDTReceived(&state)
+	}
+}
+
+// handlePinMessage processes a received pin from the gossip network.
+func (d *synapseDelegate) handlePinMessage(payload []byte) {
+	var pin reconciliation.Pin
+	if err := json.Unmarshal(payload, &pin); err != nil {
+		logger.Warn("Failed to unmarshal pin from gossip", logging.KeyError, err)
+		return
+	}
+
+	if d.manager.onPinReceived != nil {
+		d.manager.onPinReceived(&pin)
+	}
+}
+
+// handleReviewMessage processes a received review item from the gossip network.
+func (d *synapseDelegate) handleReviewMessage(payload []byte) {
+	var item review.Item
+	if err := json.Unmarshal(payload, &item); err != nil {
+		logger.Warn("Failed to unmarshal review item from gossip", logging.KeyError, err)
+		return
+	}
+
+	if d.manager.onReviewReceived != nil {
+		d.manager.onReviewReceived(&item)
+	}
+}
+
 // GetBroadcasts returns messages to be broadcast.
 func (d *synapseDelegate) GetBroadcasts(overhead, limit int) [][]byte {
 	// We use SendBestEffort for immediate broadcasting
@@ -261,30 +658,105 @@ func (d *synapseDelegate) GetBroadcasts(overhead, limit int) [][]byte {
 
 // LocalState returns the local state to be sent to joining nodes.
 func (d *synapseDelegate) LocalState(join bool) []byte {
-	// For now, we don't send state during joins
-	// In V2, this could include a snapshot of current knowledge. requires more brainstorming
-	return nil
+	// Schemas, CRDT states, pins and review items are exchanged; all merge safely no matter how often they are sent.
+	// Claims are only sent on join, where a peer catches up on what it missed, and are
+	// otherwise left to gossip
+	var state syncState
+	if join && d.manager.kpakSource != nil {
+		state.Kpaks = d.manager.kpakSource()
+	}
+	if d.manager.schemaSource != nil {
+		state.Schemas = d.manager.schemaSource()
+	}
+	if d.manager.crdtSource != nil {
+		state.CRDTs = d.manager.crdtSource()
+	}
+	if d.manager.pinSource != nil {
+		state.Pins = d.manager.pinSource()
+	}
+	if d.manager.reviewSource != nil {
+		state.Reviews = d.manager.reviewSource()
+	}
+	// On join the peer waits for our state before it counts as synced, so send it even when empty
+	if !join && len(state.Schemas) == 0 && len(state.CRDTs) == 0 && len(state.Pins) == 0 && len(state.Reviews) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(&state)
+	if err != nil {
+		logger.Warn("Failed to serialize local state for sync", logging.KeyError, err)
+		return nil
+	}
+	if len(data) > maxSyncStateBytes && len(state.Kpaks) > 0 {
+		// Memberlist refuses the whole exchange past its limit; leave the claims to gossip instead
+		logger.Warn("Too many claims to share on join; the peer catches up through gossip", "kpaks", len(state.Kpaks), "bytes", len(data))
+		state.Kpaks = nil
+		if data, err = json.Marshal(&state); err != nil {
+			logger.Warn("Failed to serialize local state for sync", logging.KeyError, err)
+			return nil
+		}
+	}
+	return data
 }
 
 // MergeRemoteState merges remote state with local state.
 func (d *synapseDelegate) MergeRemoteState(buf []byte, join bool) {
-	// For now, we don't handle state merging during joins
-	// In V2, this could sync knowledge snapshots or merkle truths (I donno if that made sense?). requires more brainstorming
+	// Schemas keep whichever version is newer, CRDT states merge element-wise, pins keep the latest
+	// update and review decisions replace held claims. Claims are reconciled like gossiped ones;
+	// those already held are skipped
+	if len(buf) == 0 {
+		return
+	}
+
+	var state syncState
+	if err := json.Unmarshal(buf, &state); err != nil {
+		logger.Warn("Failed to unmarshal remote state", logging.KeyError, err)
+		return
+	}
+	// Schemas first, so claims are validated against them
+	if d.manager.onSchemaReceived != nil {
+		for _, s := range state.Schemas {
+			d.manager.onSchemaReceived(s)
+		}
+	}
+	if d.manager.onCRDTReceived != nil {
+		for _, c := range state.CRDTs {
+			d.manager.onCRDTReceived(c)
+		}
+	}
+	if d.manager.onPinReceived != nil {
+		for _, pin := range state.Pins {
+			d.manager.onPinReceived(pin)
+		}
+	}
+	if d.manager.onReviewReceived != nil {
+		for _, item := range state.Reviews {
+			d.manager.onReviewReceived(item)
+		}
+	}
+	if d.manager.onKpakReceived != nil {
+		for _, kpak := range state.Kpaks {
+			d.manager.onKpakReceived(context.Background(), kpak)
+		}
+	}
+	if join {
+		d.manager.synced.Store(true)
+	}
 }
 
 // Event delegate implementation
 
 // NotifyJoin is called when a node joins the cluster.
 func (e *synapseEventDelegate) NotifyJoin(node *memberlist.Node) {
-	log.Printf("Gossip: node joined cluster: %s (%s:%d)", node.Name, node.Addr, node.Port)
+	logger.Info("Node joined cluster", logging.KeyPeer, node.Name, "addr", node.Address())
 }
 
 // NotifyLeave is called when a node leaves the cluster.
 func (e *synapseEventDelegate) NotifyLeave(node *memberlist.Node) {
-	log.Printf("Gossip: node left cluster: %s (%s:%d)", node.Name, node.Addr, node.Port)
+	logger.Info("Node left cluster", logging.KeyPeer, node.Name, "addr", node.Address())
 }
 
 // NotifyUpdate is called when a node's metadata is updated.
 func (e *synapseEventDelegate) NotifyUpdate(node *memberlist.Node) {
-	log.Printf("Gossip: node updated: %s (%s:%d)", node.Name, node.Addr, node.Port)
+	logger.Debug("Node updated", logging.KeyPeer, node.Name, "addr", node.Address())
 }
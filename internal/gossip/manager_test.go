@@ -1,12 +1,24 @@
 package gossip
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
 )
 
 func TestNewManager(t *testing.T) {
@@ -192,7 +204,7 @@ func TestManager_SetKpakHandler(t *testing.T) {
 	}
 
 	handlerCalled := false
-	handler := func(kpak *core.Kpak) bool {
+	handler := func(ctx context.Context, kpak *core.Kpak) bool {
 		handlerCalled = true
 		return true
 	}
@@ -206,7 +218,7 @@ func TestManager_SetKpakHandler(t *testing.T) {
 
 	// Simulate handler call
 	kpak := core.NewKpak("Alice", "age", "25", "TestSource", 0.8)
-	result := manager.onKpakReceived(kpak)
+	result := manager.onKpakReceived(context.Background(), kpak)
 
 	if !handlerCalled {
 		t.Fatal("Handler was not called")
@@ -338,7 +350,7 @@ func TestSynapseDelegate_HandleKpakMessage(t *testing.T) {
 	// Set up handler
 	var receivedKpak *core.Kpak
 	handlerCalled := false
-	manager.SetKpakHandler(func(kpak *core.Kpak) bool {
+	manager.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
 		receivedKpak = kpak
 		handlerCalled = true
 		return true
@@ -352,7 +364,7 @@ func TestSynapseDelegate_HandleKpakMessage(t *testing.T) {
 	}
 
 	// Handle the message
-	delegate.handleKpakMessage(payload)
+	delegate.handleKpakMessage(context.Background(), payload)
 
 	if !handlerCalled {
 		t.Fatal("Handler should have been called")
@@ -393,7 +405,7 @@ func TestSynapseDelegate_NotifyMsg(t *testing.T) {
 	// Set up handler
 	var receivedKpak *core.Kpak
 	handlerCalled := false
-	manager.SetKpakHandler(func(kpak *core.Kpak) bool {
+	manager.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
 		receivedKpak = kpak
 		handlerCalled = true
 		return true
@@ -445,7 +457,7 @@ func TestSynapseDelegate_NotifyMsg_UnknownType(t *testing.T) {
 
 	// Set up handler
 	handlerCalled := false
-	manager.SetKpakHandler(func(kpak *core.Kpak) bool {
+	manager.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
 		handlerCalled = true
 		return true
 	})
@@ -485,7 +497,7 @@ func TestSynapseDelegate_NotifyMsg_InvalidJSON(t *testing.T) {
 
 	// Set up handler
 	handlerCalled := false
-	manager.SetKpakHandler(func(kpak *core.Kpak) bool {
+	manager.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
 		handlerCalled = true
 		return true
 	})
@@ -535,10 +547,10 @@ func TestSynapseDelegate_LocalState(t *testing.T) {
 
 	delegate := manager.delegate
 
-	// LocalState should return nil for now
+	// A joining peer waits for our state, so it is sent even when there is none
 	state := delegate.LocalState(true)
-	if state != nil {
-		t.Fatal("LocalState should return nil")
+	if state == nil {
+		t.Fatal("LocalState should be sent on join")
 	}
 
 	state = delegate.LocalState(false)
@@ -547,6 +559,35 @@ func TestSynapseDelegate_LocalState(t *testing.T) {
 	}
 }
 
+func TestSynapseDelegate_KpakSync(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	local := []*core.Kpak{core.NewKpak("server1", "status", "up", "probe", 0.9)}
+	var received []*core.Kpak
+	manager.SetKpakSource(func() []*core.Kpak { return local })
+	manager.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
+		received = append(received, kpak)
+		return true
+	})
+
+	// Claims only travel on join; periodic syncs leave them to gossip
+	manager.delegate.MergeRemoteState(manager.delegate.LocalState(false), false)
+	if len(received) != 0 || manager.Synced() {
+		t.Fatalf("Expected no claims outside a join, got %+v", received)
+	}
+
+	manager.delegate.MergeRemoteState(manager.delegate.LocalState(true), true)
+	if len(received) != 1 || received[0].ID != local[0].ID {
+		t.Fatalf("Unexpected claims merged: %+v", received)
+	}
+	if !manager.Synced() {
+		t.Fatal("Expected the manager to be synced once a peer's claims were merged")
+	}
+}
+
 func TestSynapseDelegate_MergeRemoteState(t *testing.T) {
 	config := &Config{
 		BindAddr:    "127.0.0.1",
@@ -709,3 +750,522 @@ func TestTwoManagersIntegration(t *testing.T) {
 		t.Errorf("Manager2 should see at least 1 member, got %d", len(members2))
 	}
 }
+
+func TestSynapseDelegate_HandleRetractMessage(t *testing.T) {
+	config := &Config{
+		BindAddr:    "127.0.0.1",
+		BindPort:    0,
+		JoinPeers:   []string{},
+		ClusterName: "test-cluster",
+	}
+
+	manager, err := NewManager(config)
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	var received *Retraction
+	manager.SetRetractHandler(func(retraction *Retraction) {
+		received = retraction
+	})
+
+	payload, err := json.Marshal(&Retraction{Subject: "db-1", Predicate: "status", Source: "MonitorA", Timestamp: 42})
+	if err != nil {
+		t.Fatalf("Failed to marshal retraction: %v", err)
+	}
+
+	msgData, err := json.Marshal(&GossipMessage{Type: "retract", Payload: payload})
+	if err != nil {
+		t.Fatalf("Failed to marshal gossip message: %v", err)
+	}
+
+	manager.delegate.NotifyMsg(msgData)
+
+	if received == nil {
+		t.Fatal("Retract handler should have been called")
+	}
+	if received.Subject != "db-1" || received.Predicate != "status" || received.Source != "MonitorA" {
+		t.Fatalf("Unexpected retraction: %+v", received)
+	}
+}
+
+func TestManager_BroadcastRetract_NotRunning(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	if err := manager.BroadcastRetract(&Retraction{Subject: "db-1", Predicate: "status", Source: "MonitorA"}); err == nil {
+		t.Fatal("BroadcastRetract should fail when manager is not running")
+	}
+}
+
+func TestSynapseDelegate_SchemaSync(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	var received []*schema.Schema
+	manager.SetSchemaHandler(func(s *schema.Schema) {
+		received = append(received, s)
+	})
+	local := []*schema.Schema{{Predicate: "status", Enum: []string{"up", "down"}, Version: 2}}
+	manager.SetSchemaSource(func() []*schema.Schema {
+		return local
+	})
+
+	// Broadcast schema messages reach the handler
+	payload, err := json.Marshal(local[0])
+	if err != nil {
+		t.Fatalf("Failed to marshal schema: %v", err)
+	}
+	msgData, err := json.Marshal(&GossipMessage{Type: "schema", Payload: payload})
+	if err != nil {
+		t.Fatalf("Failed to marshal gossip message: %v", err)
+	}
+	manager.delegate.NotifyMsg(msgData)
+
+	if len(received) != 1 || received[0].Predicate != "status" || received[0].Version != 2 {
+		t.Fatalf("Unexpected schemas received: %+v", received)
+	}
+
+	// State sync carries every local schema to the peer
+	state := manager.delegate.LocalState(true)
+	if state == nil {
+		t.Fatal("LocalState should include schemas")
+	}
+	received = nil
+	manager.delegate.MergeRemoteState(state, true)
+	if len(received) != 1 || received[0].Predicate != "status" {
+		t.Fatalf("Unexpected schemas merged: %+v", received)
+	}
+}
+
+func TestManager_BroadcastSchema_NotRunning(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	if err := manager.BroadcastSchema(&schema.Schema{Predicate: "status"}); err == nil {
+		t.Fatal("BroadcastSchema should fail when manager is not running")
+	}
+}
+
+func TestSynapseDelegate_CRDTSync(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	var received []*crdt.State
+	manager.SetCRDTHandler(func(state *crdt.State) {
+		received = append(received, state)
+	})
+	local := crdt.NewState(crdt.GCounter, "svc-a", "incidents")
+	local.Apply(core.NewKpak("svc-a", "incidents", int64(2), "pager", 0.9), "agent-a")
+	manager.SetCRDTSource(func() []*crdt.State {
+		return []*crdt.State{local}
+	})
+
+	payload, err := json.Marshal(local)
+	if err != nil {
+		t.Fatalf("Failed to marshal state: %v", err)
+	}
+	msgData, err := json.Marshal(&GossipMessage{Type: "crdt", Payload: payload})
+	if err != nil {
+		t.Fatalf("Failed to marshal gossip message: %v", err)
+	}
+	manager.delegate.NotifyMsg(msgData)
+
+	if len(received) != 1 || received[0].Value() != int64(2) {
+		t.Fatalf("Unexpected states received: %+v", received)
+	}
+
+	state := manager.delegate.LocalState(true)
+	if state == nil {
+		t.Fatal("LocalState should include CRDT states")
+	}
+	received = nil
+	manager.delegate.MergeRemoteState(state, true)
+	if len(received) != 1 || received[0].Predicate != "incidents" {
+		t.Fatalf("Unexpected states merged: %+v", received)
+	}
+}
+
+func TestSynapseDelegate_PinSync(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	var received []*reconciliation.Pin
+	manager.SetPinHandler(func(pin *reconciliation.Pin) {
+		received = append(received, pin)
+	})
+	local := &reconciliation.Pin{
+		Kpak:      core.NewKpak("server1", "health", "down", "alice", 1.0),
+		Operator:  "alice",
+		Reason:    "maintenance",
+		UpdatedAt: 1,
+	}
+	manager.SetPinSource(func() []*reconciliation.Pin {
+		return []*reconciliation.Pin{local}
+	})
+
+	payload, err := json.Marshal(local)
+	if err != nil {
+		t.Fatalf("Failed to marshal pin: %v", err)
+	}
+	msgData, err := json.Marshal(&GossipMessage{Type: "pin", Payload: payload})
+	if err != nil {
+		t.Fatalf("Failed to marshal gossip message: %v", err)
+	}
+	manager.delegate.NotifyMsg(msgData)
+
+	if len(received) != 1 || received[0].Operator != "alice" || received[0].Kpak.Object != "down" {
+		t.Fatalf("Unexpected pins received: %+v", received)
+	}
+
+	state := manager.delegate.LocalState(true)
+	if state == nil {
+		t.Fatal("LocalState should include pins")
+	}
+	received = nil
+	manager.delegate.MergeRemoteState(state, true)
+	if len(received) != 1 || received[0].Reason != "maintenance" {
+		t.Fatalf("Unexpected pins merged: %+v", received)
+	}
+}
+
+func TestSynapseDelegate_ReviewSync(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	var received []*review.Item
+	manager.SetReviewHandler(func(item *review.Item) {
+		received = append(received, item)
+	})
+	kpak := core.NewKpak("server1", "status", "down", "ai-scout", 0.3)
+	local := &review.Item{ID: kpak.ID, Kpak: kpak, Status: review.StatusPending, HeldAt: time.Now()}
+	manager.SetReviewSource(func() []*review.Item {
+		return []*review.Item{local}
+	})
+
+	payload, err := json.Marshal(local)
+	if err != nil {
+		t.Fatalf("Failed to marshal review item: %v", err)
+	}
+	msgData, err := json.Marshal(&GossipMessage{Type: "review", Payload: payload})
+	if err != nil {
+		t.Fatalf("Failed to marshal gossip message: %v", err)
+	}
+	manager.delegate.NotifyMsg(msgData)
+
+	if len(received) != 1 || received[0].ID != kpak.ID || received[0].Status != review.StatusPending {
+		t.Fatalf("Unexpected review items received: %+v", received)
+	}
+
+	state := manager.delegate.LocalState(true)
+	if state == nil {
+		t.Fatal("LocalState should include review items")
+	}
+	received = nil
+	manager.delegate.MergeRemoteState(state, true)
+	if len(received) != 1 || received[0].Kpak.Object != "down" {
+		t.Fatalf("Unexpected review items merged: %+v", received)
+	}
+}
+
+func TestSynapseDelegate_MessageObserver(t *testing.T) {
+	manager, err := NewManager(&Config{BindAddr: "127.0.0.1", BindPort: 0, ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create gossip manager: %v", err)
+	}
+
+	observed := make(map[string]int)
+	manager.SetMessageObserver(func(direction, msgType string) {
+		observed[direction+"/"+msgType]++
+	})
+
+	payload, _ := json.Marshal(core.NewKpak("Alice", "age", "25", "TestSource", 0.8))
+	msgData, _ := json.Marshal(&GossipMessage{Type: "kpak", Payload: payload})
+	manager.delegate.NotifyMsg(msgData)
+
+	unknown, _ := json.Marshal(&GossipMessage{Type: "unknown", Payload: []byte("test")})
+	manager.delegate.NotifyMsg(unknown)
+	manager.delegate.NotifyMsg([]byte("invalid json"))
+
+	if observed["received/kpak"] != 1 || observed["failed/unknown"] != 1 || observed["failed/invalid"] != 1 || len(observed) != 3 {
+		t.Fatalf("Unexpected observed messages %v", observed)
+	}
+}
+
+// freePort returns a port that was free for both TCP and UDP a moment ago.
+func freePort(t *testing.T) int {
+	for i := 0; i < 10; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("Failed to find a free port: %v", err)
+		}
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+		if conn, err := net.ListenPacket("udp", fmt.Sprintf("127.0.0.1:%d", port)); err == nil {
+			conn.Close()
+			return port
+		}
+	}
+	t.Fatal("Failed to find a port free for TCP and UDP")
+	return 0
+}
+
+func TestManager_Synced(t *testing.T) {
+	manager1, err := NewManager(&Config{BindAddr: "127.0.0.1", BindPort: freePort(t), ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create manager1: %v", err)
+	}
+	if err := manager1.Start(); err != nil {
+		t.Fatalf("Failed to start manager1: %v", err)
+	}
+	defer manager1.Stop()
+	if manager1.Synced() {
+		t.Fatal("A lone agent should not be synced")
+	}
+
+	manager2, err := NewManager(&Config{
+		BindAddr:    "127.0.0.1",
+		BindPort:    freePort(t),
+		JoinPeers:   []string{fmt.Sprintf("127.0.0.1:%d", manager1.config.BindPort)},
+		ClusterName: "test-cluster",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create manager2: %v", err)
+	}
+	if err := manager2.Start(); err != nil {
+		t.Fatalf("Failed to start manager2: %v", err)
+	}
+	defer manager2.Stop()
+
+	if !manager2.Synced() {
+		t.Fatal("Joining a peer should sync")
+	}
+	// The joined side merges the joiner's state after replying
+	for i := 0; i < 50 && !manager1.Synced(); i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !manager1.Synced() {
+		t.Fatal("Being joined by a peer should sync")
+	}
+}
+
+func TestManager_TracePropagation(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	sender, err := NewManager(&Config{BindAddr: "127.0.0.1", BindPort: freePort(t), ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+	if err := sender.Start(); err != nil {
+		t.Fatalf("Failed to start sender: %v", err)
+	}
+	defer sender.Stop()
+
+	receiver, err := NewManager(&Config{
+		BindAddr:    "127.0.0.1",
+		BindPort:    freePort(t),
+		JoinPeers:   []string{fmt.Sprintf("127.0.0.1:%d", sender.config.BindPort)},
+		ClusterName: "test-cluster",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create receiver: %v", err)
+	}
+	received := make(chan trace.SpanContext, 1)
+	receiver.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
+		received <- trace.SpanContextFromContext(ctx)
+		return true
+	})
+	if err := receiver.Start(); err != nil {
+		t.Fatalf("Failed to start receiver: %v", err)
+	}
+	defer receiver.Stop()
+
+	for i := 0; i < 50 && len(sender.GetMembers()) < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	ctx, ingest := provider.Tracer("test").Start(context.Background(), "Agent.Ingest")
+	if err := sender.BroadcastKpakContext(ctx, core.NewKpak("server1", "cpu", "10", "scout", 0.9)); err != nil {
+		t.Fatalf("Broadcast failed: %v", err)
+	}
+	ingest.End()
+
+	select {
+	case remote := <-received:
+		if remote.TraceID() != ingest.SpanContext().TraceID() {
+			t.Fatal("Expected the receiver to continue the sender's trace")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("K-pak was not received")
+	}
+
+	// The receiving span ends after the handler returns
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for i := 0; i < 50 && spans["Manager.handleKpakMessage"] == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+		for _, span := range recorder.Ended() {
+			spans[span.Name()] = span
+		}
+	}
+	broadcast, handle := spans["Manager.BroadcastKpak"], spans["Manager.handleKpakMessage"]
+	if broadcast == nil || handle == nil {
+		t.Fatalf("Expected broadcast and receive spans, got %v", recorder.Ended())
+	}
+	if handle.Parent().SpanID() != broadcast.SpanContext().SpanID() || !handle.Parent().IsRemote() {
+		t.Fatal("Expected the receive span under the remote broadcast span")
+	}
+}
+
+func TestManager_BroadcastLargeCRDT(t *testing.T) {
+	sender, err := NewManager(&Config{BindAddr: "127.0.0.1", BindPort: freePort(t), ClusterName: "test-cluster"})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+	if err := sender.Start(); err != nil {
+		t.Fatalf("Failed to start sender: %v", err)
+	}
+	defer sender.Stop()
+
+	receiver, err := NewManager(&Config{
+		BindAddr:    "127.0.0.1",
+		BindPort:    freePort(t),
+		JoinPeers:   []string{fmt.Sprintf("127.0.0.1:%d", sender.config.BindPort)},
+		ClusterName: "test-cluster",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create receiver: %v", err)
+	}
+	received := make(chan *crdt.State, 1)
+	receiver.SetCRDTHandler(func(state *crdt.State) {
+		received <- state
+	})
+	if err := receiver.Start(); err != nil {
+		t.Fatalf("Failed to start receiver: %v", err)
+	}
+	defer receiver.Stop()
+
+	for i := 0; i < 50 && len(sender.GetMembers()) < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// A set far larger than a UDP packet still arrives whole
+	state := crdt.NewState(crdt.ORSet, "cluster", "members")
+	for i := 0; i < 500; i++ {
+		if _, err := state.Apply(core.NewKpak("cluster", "members", fmt.Sprintf("node-%d", i), "scout", 0.9), "agent-a"); err != nil {
+			t.Fatalf("Apply failed: %v", err)
+		}
+	}
+	if err := sender.BroadcastCRDT(state); err != nil {
+		t.Fatalf("Broadcast failed: %v", err)
+	}
+
+	select {
+	case remote := <-received:
+		if len(remote.Adds) != 500 {
+			t.Fatalf("Expected all 500 members, got %d", len(remote.Adds))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Large CRDT state was not received")
+	}
+}
+
+func TestManager_KpakOrigin(t *testing.T) {
+	sender, err := NewManager(&Config{BindAddr: "127.0.0.1", BindPort: freePort(t), ClusterName: "test-cluster", GRPCPort: 9090})
+	if err != nil {
+		t.Fatalf("Failed to create sender: %v", err)
+	}
+	if err := sender.Start(); err != nil {
+		t.Fatalf("Failed to start sender: %v", err)
+	}
+	defer sender.Stop()
+
+	receiver, err := NewManager(&Config{
+		BindAddr:    "127.0.0.1",
+		BindPort:    freePort(t),
+		JoinPeers:   []string{fmt.Sprintf("127.0.0.1:%d", sender.config.BindPort)},
+		ClusterName: "test-cluster",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create receiver: %v", err)
+	}
+	received := make(chan Origin, 1)
+	receiver.SetKpakHandler(func(ctx context.Context, kpak *core.Kpak) bool {
+		origin, ok := OriginFromContext(ctx)
+		if !ok {
+			t.Error("Expected the k-pak to carry its origin")
+		}
+		received <- origin
+		return true
+	})
+	if err := receiver.Start(); err != nil {
+		t.Fatalf("Failed to start receiver: %v", err)
+	}
+	defer receiver.Stop()
+
+	for i := 0; i < 50 && len(sender.GetMembers()) < 2; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	// Members learn each other's API port from the node metadata
+	senderName := NodeName("127.0.0.1", sender.config.BindPort)
+	for _, member := range receiver.GetMembers() {
+		want := 0
+		if member.Name == senderName {
+			want = 9090
+		}
+		if member.GRPCPort != want {
+			t.Fatalf("Expected %s to advertise API port %d, got %d", member.Name, want, member.GRPCPort)
+		}
+	}
+
+	before := time.Now()
+	if err := sender.BroadcastKpak(core.NewKpak("server1", "cpu", "10", "scout", 0.9)); err != nil {
+		t.Fatalf("Broadcast failed: %v", err)
+	}
+
+	select {
+	case origin := <-received:
+		if origin.Node != senderName {
+			t.Fatalf("Expected origin %s, got %s", senderName, origin.Node)
+		}
+		if origin.At.Before(before) || origin.At.After(time.Now()) {
+			t.Fatalf("Expected the origin time to be the send time, got %v", origin.At)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("K-pak was not received")
+	}
+
+	// A claim stamped when it was taken in keeps that origin when it is sent, or re-sent, later
+	stamped := core.NewKpak("server2", "cpu", "20", "scout", 0.9)
+	sender.StampOrigin(stamped)
+	if stamped.Origin != senderName || stamped.OriginatedAt == 0 {
+		t.Fatalf("Expected the claim to be stamped with %s, got %q at %d", senderName, stamped.Origin, stamped.OriginatedAt)
+	}
+	stamped.Origin, stamped.OriginatedAt = "synapse-10.0.0.9-7946", stamped.OriginatedAt-int64(time.Second)
+	if err := sender.BroadcastKpak(stamped); err != nil {
+		t.Fatalf("Broadcast failed: %v", err)
+	}
+	select {
+	case origin := <-received:
+		if origin.Node != stamped.Origin || origin.At.UnixNano() != stamped.OriginatedAt {
+			t.Fatalf("Expected the ingest origin to be kept, got %+v", origin)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("K-pak was not received")
+	}
+}
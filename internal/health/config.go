@@ -0,0 +1,60 @@
+package health
+
+import "fmt"
+
+// Config holds the thresholds of the built-in checks. Zero values take the
+// defaults; a negative threshold disables that level of the check.
+type Config struct {
+	WALFsyncDegradedMs          float64 `yaml:"wal_fsync_degraded_ms"`          // Last fsync slower than this (default 100)
+	WALFsyncUnhealthyMs         float64 `yaml:"wal_fsync_unhealthy_ms"`         // (default 1000)
+	ExpectedMembers             int     `yaml:"expected_members"`               // Mesh size, this agent included (0 = join_peers + 1)
+	GCLagDegraded               float64 `yaml:"gc_lag_degraded"`                // Garbage collection intervals missed (default 3)
+	GCLagUnhealthy              float64 `yaml:"gc_lag_unhealthy"`               // (default 10)
+	IngestErrorRatioDegraded    float64 `yaml:"ingest_error_ratio_degraded"`    // Share of k-paks invalid, refused by policy or quota, or not persisted in the last 5 minutes (default 0.2)
+	IngestErrorRatioUnhealthy   float64 `yaml:"ingest_error_ratio_unhealthy"`   // (default 0.5)
+	IngestErrorMinSamples       int64   `yaml:"ingest_error_min_samples"`       // K-paks needed before the ratio counts (default 20)
+	SnapshotAgeDegradedMinutes  float64 `yaml:"snapshot_age_degraded_minutes"`  // Since a record last reached the WAL (default 1440)
+	SnapshotAgeUnhealthyMinutes float64 `yaml:"snapshot_age_unhealthy_minutes"` // (default not checked)
+	DiskFreeDegradedPercent     float64 `yaml:"disk_free_degraded_percent"`     // Free space left on the WAL's disk (default 10)
+	DiskFreeUnhealthyPercent    float64 `yaml:"disk_free_unhealthy_percent"`    // (default 2)
+}
+
+// Validate checks that ratios and percentages are in range.
+func (c Config) Validate() error {
+	for _, ratio := range []float64{c.IngestErrorRatioDegraded, c.IngestErrorRatioUnhealthy} {
+		if ratio > 1 {
+			return fmt.Errorf("health: ingest error ratios must not exceed 1")
+		}
+	}
+	for _, percent := range []float64{c.DiskFreeDegradedPercent, c.DiskFreeUnhealthyPercent} {
+		if percent > 100 {
+			return fmt.Errorf("health: disk free percentages must not exceed 100")
+		}
+	}
+	if c.ExpectedMembers < 0 || c.IngestErrorMinSamples < 0 {
+		return fmt.Errorf("health: expected_members and ingest_error_min_samples must not be negative")
+	}
+	return nil
+}
+
+// WithDefaults fills in unset thresholds.
+func (c Config) WithDefaults() Config {
+	set := func(v *float64, def float64) {
+		if *v == 0 {
+			*v = def
+		}
+	}
+	set(&c.WALFsyncDegradedMs, 100)
+	set(&c.WALFsyncUnhealthyMs, 1000)
+	set(&c.GCLagDegraded, 3)
+	set(&c.GCLagUnhealthy, 10)
+	set(&c.IngestErrorRatioDegraded, 0.2)
+	set(&c.IngestErrorRatioUnhealthy, 0.5)
+	set(&c.SnapshotAgeDegradedMinutes, 24*60)
+	set(&c.DiskFreeDegradedPercent, 10)
+	set(&c.DiskFreeUnhealthyPercent, 2)
+	if c.IngestErrorMinSamples == 0 {
+		c.IngestErrorMinSamples = 20
+	}
+	return c
+}
@@ -0,0 +1,155 @@
+package health
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ErrDiskUsageUnsupported is returned by DiskUsage where free disk space cannot be read.
+var ErrDiskUsageUnsupported = errors.New("disk usage is not available on this platform")
+
+// Status is the outcome of a health check, or of all of them.
+type Status string
+
+const (
+	StatusHealthy   Status = "healthy"
+	StatusDegraded  Status = "degraded"  // Working, but needs attention
+	StatusUnhealthy Status = "unhealthy" // Should not receive traffic
+)
+
+func (s Status) rank() int {
+	switch s {
+	case StatusHealthy:
+		return 0
+	case StatusDegraded:
+		return 1
+	}
+	return 2
+}
+
+// Worse returns whichever of two statuses is more severe.
+func Worse(a, b Status) Status {
+	if b.rank() > a.rank() {
+		return b
+	}
+	return a
+}
+
+// Above grades a value that should stay low: unhealthy at or above unhealthy,
+// degraded at or above degraded. A zero threshold is not checked.
+func Above(value, degraded, unhealthy float64) Status {
+	switch {
+	case unhealthy > 0 && value >= unhealthy:
+		return StatusUnhealthy
+	case degraded > 0 && value >= degraded:
+		return StatusDegraded
+	}
+	return StatusHealthy
+}
+
+// Below grades a value that should stay high: unhealthy at or below unhealthy,
+// degraded at or below degraded. A zero threshold is not checked.
+func Below(value, degraded, unhealthy float64) Status {
+	switch {
+	case unhealthy > 0 && value <= unhealthy:
+		return StatusUnhealthy
+	case degraded > 0 && value <= degraded:
+		return StatusDegraded
+	}
+	return StatusHealthy
+}
+
+// Check reports on one aspect of the agent, with a message for operators.
+type Check func() (Status, string)
+
+// Result is the outcome of one check.
+type Result struct {
+	Name    string
+	Status  Status
+	Message string
+}
+
+// Report is the outcome of every check. Its status is the worst of theirs.
+type Report struct {
+	Status  Status
+	Results []Result // Sorted by name
+	At      time.Time
+}
+
+// Message summarizes the report: the checks that are not healthy, or that all are.
+func (r Report) Message() string {
+	var problems []string
+	for _, result := range r.Results {
+		if result.Status != StatusHealthy {
+			problems = append(problems, fmt.Sprintf("%s: %s", result.Name, result.Message))
+		}
+	}
+	if len(problems) == 0 {
+		return "Agent is operating normally"
+	}
+	message := problems[0]
+	for _, p := range problems[1:] {
+		message += "; " + p
+	}
+	return message
+}
+
+// Registry holds the checks that make up the agent's health.
+type Registry struct {
+	checks map[string]Check
+	mutex  sync.RWMutex
+}
+
+// NewRegistry creates an empty registry; with no checks the agent is healthy.
+func NewRegistry() *Registry {
+	return &Registry{checks: make(map[string]Check)}
+}
+
+// Register adds a check, replacing any check with the same name.
+func (r *Registry) Register(name string, check Check) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.checks[name] = check
+}
+
+// Unregister removes a check.
+func (r *Registry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	delete(r.checks, name)
+}
+
+// Run runs every check. A check that panics is reported unhealthy.
+func (r *Registry) Run() Report {
+	r.mutex.RLock()
+	names := make([]string, 0, len(r.checks))
+	for name := range r.checks {
+		names = append(names, name)
+	}
+	checks := make(map[string]Check, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+	r.mutex.RUnlock()
+	sort.Strings(names)
+
+	report := Report{Status: StatusHealthy, At: time.Now()}
+	for _, name := range names {
+		status, message := run(checks[name])
+		report.Results = append(report.Results, Result{Name: name, Status: status, Message: message})
+		report.Status = Worse(report.Status, status)
+	}
+	return report
+}
+
+func run(check Check) (status Status, message string) {
+	defer func() {
+		if r := recover(); r != nil {
+			status, message = StatusUnhealthy, fmt.Sprintf("check failed: %v", r)
+		}
+	}()
+	return check()
+}
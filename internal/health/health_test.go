@@ -0,0 +1,102 @@
+package health
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestWorse(t *testing.T) {
+	if Worse(StatusHealthy, StatusDegraded) != StatusDegraded || Worse(StatusUnhealthy, StatusDegraded) != StatusUnhealthy || Worse(StatusHealthy, StatusHealthy) != StatusHealthy {
+		t.Fatal("Expected the more severe status to win")
+	}
+}
+
+func TestAboveBelow(t *testing.T) {
+	cases := []struct {
+		got, want Status
+	}{
+		{Above(5, 10, 20), StatusHealthy},
+		{Above(10, 10, 20), StatusDegraded},
+		{Above(25, 10, 20), StatusUnhealthy},
+		{Above(25, 10, -1), StatusDegraded}, // Unhealthy level disabled
+		{Above(25, 0, 0), StatusHealthy},
+		{Below(50, 10, 2), StatusHealthy},
+		{Below(8, 10, 2), StatusDegraded},
+		{Below(1, 10, 2), StatusUnhealthy},
+		{Below(1, -1, -1), StatusHealthy},
+	}
+	for i, c := range cases {
+		if c.got != c.want {
+			t.Fatalf("Case %d: expected %s, got %s", i, c.want, c.got)
+		}
+	}
+}
+
+func TestRegistry_Run(t *testing.T) {
+	registry := NewRegistry()
+	if report := registry.Run(); report.Status != StatusHealthy || len(report.Results) != 0 {
+		t.Fatalf("Expected an empty registry to be healthy, got %+v", report)
+	}
+
+	registry.Register("wal", func() (Status, string) { return StatusHealthy, "writable" })
+	registry.Register("disk", func() (Status, string) { return StatusDegraded, "8% free" })
+	registry.Register("broken", func() (Status, string) { panic("boom") })
+
+	report := registry.Run()
+	if report.Status != StatusUnhealthy {
+		t.Fatalf("Expected the panicking check to make the agent unhealthy, got %s", report.Status)
+	}
+	if len(report.Results) != 3 || report.Results[0].Name != "broken" || report.Results[1].Name != "disk" || report.Results[2].Name != "wal" {
+		t.Fatalf("Expected results sorted by name, got %+v", report.Results)
+	}
+	if !strings.Contains(report.Results[0].Message, "boom") {
+		t.Fatalf("Expected the panic in the message, got %q", report.Results[0].Message)
+	}
+	if message := report.Message(); !strings.Contains(message, "disk: 8% free") || strings.Contains(message, "wal") {
+		t.Fatalf("Expected the message to list only failing checks, got %q", message)
+	}
+
+	registry.Unregister("broken")
+	registry.Register("disk", func() (Status, string) { return StatusHealthy, "50% free" })
+	if report := registry.Run(); report.Status != StatusHealthy || report.Message() != "Agent is operating normally" {
+		t.Fatalf("Expected healthy after replacing checks, got %+v", report)
+	}
+}
+
+func TestConfig(t *testing.T) {
+	config := Config{WALFsyncDegradedMs: 50, GCLagUnhealthy: -1}.WithDefaults()
+	if config.WALFsyncDegradedMs != 50 || config.WALFsyncUnhealthyMs != 1000 || config.GCLagUnhealthy != -1 || config.IngestErrorMinSamples != 20 {
+		t.Fatalf("Unexpected defaults %+v", config)
+	}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Expected config to be valid, got %v", err)
+	}
+
+	for _, invalid := range []Config{
+		{IngestErrorRatioDegraded: 1.5},
+		{DiskFreeUnhealthyPercent: 150},
+		{ExpectedMembers: -1},
+	} {
+		if err := invalid.Validate(); err == nil {
+			t.Fatalf("Expected %+v to be invalid", invalid)
+		}
+	}
+}
+
+func TestDiskUsage(t *testing.T) {
+	free, total, err := DiskUsage(os.TempDir())
+	if runtime.GOOS != "linux" && runtime.GOOS != "darwin" && runtime.GOOS != "freebsd" {
+		if err != ErrDiskUsageUnsupported {
+			t.Fatalf("Expected ErrDiskUsageUnsupported, got %v", err)
+		}
+		return
+	}
+	if err != nil {
+		t.Fatalf("DiskUsage failed: %v", err)
+	}
+	if total == 0 || free > total {
+		t.Fatalf("Unexpected disk usage: %d free of %d", free, total)
+	}
+}
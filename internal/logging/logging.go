@@ -0,0 +1,168 @@
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// Output formats.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Field names shared by every component, so log pipelines can index them.
+const (
+	KeyComponent = "component"
+	KeyNode      = "node"   // The agent's mesh name
+	KeySPID      = "spid"   // Subject+predicate hash
+	KeySource    = "source" // Source of a claim
+	KeyPeer      = "peer"   // Mesh member
+	KeyKpak      = "kpak"   // K-pak ID
+	KeyError     = "err"
+)
+
+// KpakFields identify a k-pak in a log record.
+func KpakFields(kpak *core.Kpak) []any {
+	return []any{KeyKpak, kpak.ID, KeySPID, kpak.SPID, KeySource, kpak.Source}
+}
+
+// SPID returns the SPID of a subject and predicate, for records that have no k-pak.
+func SPID(subject, predicate string) string {
+	return (&core.Kpak{Subject: subject, Predicate: predicate}).GenerateSPID()
+}
+
+// level is the threshold of the handler installed by Setup.
+var level = new(slog.LevelVar)
+
+// ParseLevel reads a level name: debug, info (the default), warn or error.
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", name)
+}
+
+// ValidateFormat checks an output format name; empty means text.
+func ValidateFormat(format string) error {
+	switch format {
+	case "", FormatText, FormatJSON:
+		return nil
+	}
+	return fmt.Errorf("unknown log format %q (want text or json)", format)
+}
+
+// Setup sends every log, the standard library's included, to w at the given
+// level and format, tagged with the node.
+func Setup(w io.Writer, levelName, format, node string) error {
+	l, err := ParseLevel(levelName)
+	if err != nil {
+		return err
+	}
+	if err := ValidateFormat(format); err != nil {
+		return err
+	}
+	level.Set(l)
+
+	options := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler = slog.NewTextHandler(w, options)
+	if format == FormatJSON {
+		handler = slog.NewJSONHandler(w, options)
+	}
+	if node != "" {
+		handler = handler.WithAttrs([]slog.Attr{slog.String(KeyNode, node)})
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// Level returns the name of the current level.
+func Level() string {
+	return strings.ToLower(level.Level().String())
+}
+
+// SetLevel changes the level at runtime and returns the previous one.
+func SetLevel(name string) (string, error) {
+	l, err := ParseLevel(name)
+	if err != nil {
+		return "", err
+	}
+	previous := Level()
+	level.Set(l)
+	return previous, nil
+}
+
+// For returns the logger of a component. It follows the default logger, so
+// loggers created before Setup still get its level, format and node.
+func For(component string) *slog.Logger {
+	return slog.New(&componentHandler{attrs: []slog.Attr{slog.String(KeyComponent, component)}})
+}
+
+// componentHandler forwards records to the default handler with its attributes added.
+type componentHandler struct {
+	attrs []slog.Attr
+}
+
+func (h *componentHandler) Enabled(ctx context.Context, l slog.Level) bool {
+	return slog.Default().Handler().Enabled(ctx, l)
+}
+
+func (h *componentHandler) Handle(ctx context.Context, record slog.Record) error {
+	return slog.Default().Handler().WithAttrs(h.attrs).Handle(ctx, record)
+}
+
+func (h *componentHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &componentHandler{attrs: append(h.attrs[:len(h.attrs):len(h.attrs)], attrs...)}
+}
+
+func (h *componentHandler) WithGroup(name string) slog.Handler {
+	return slog.Default().Handler().WithAttrs(h.attrs).WithGroup(name)
+}
+
+// StdLogger adapts logger for libraries that take a *log.Logger and tag their
+// lines with "[DEBUG]", "[INFO]", "[WARN]" or "[ERR]", such as memberlist.
+func StdLogger(logger *slog.Logger) *log.Logger {
+	return log.New(&levelWriter{logger: logger}, "", 0)
+}
+
+type levelWriter struct {
+	logger *slog.Logger
+}
+
+var levelTags = []struct {
+	tag   string
+	level slog.Level
+}{
+	{"[DEBUG]", slog.LevelDebug},
+	{"[INFO]", slog.LevelInfo},
+	{"[WARN]", slog.LevelWarn},
+	{"[ERR]", slog.LevelError},
+	{"[ERROR]", slog.LevelError},
+}
+
+func (w *levelWriter) Write(p []byte) (int, error) {
+	message := strings.TrimSpace(string(p))
+	l := slog.LevelInfo
+	for _, t := range levelTags {
+		if strings.HasPrefix(message, t.tag) {
+			l = t.level
+			message = strings.TrimSpace(strings.TrimPrefix(message, t.tag))
+			break
+		}
+	}
+	w.logger.Log(context.Background(), l, message)
+	return len(p), nil
+}
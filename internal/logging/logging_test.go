@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// setup sends logs to a buffer for the rest of the test.
+func setup(t *testing.T, levelName, format string) *bytes.Buffer {
+	t.Helper()
+	previous := slog.Default()
+	previousLevel := level.Level()
+	t.Cleanup(func() {
+		slog.SetDefault(previous)
+		level.Set(previousLevel)
+	})
+
+	var buf bytes.Buffer
+	if err := Setup(&buf, levelName, format, "synapse-test-9091"); err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	return &buf
+}
+
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var result []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Invalid JSON record %q: %v", line, err)
+		}
+		result = append(result, record)
+	}
+	return result
+}
+
+func TestSetup_JSONFields(t *testing.T) {
+	buf := setup(t, "info", FormatJSON)
+
+	kpak := core.NewKpak("user:alice", "email", "alice@example.com", "crm", 0.9)
+	For("agent").Info("Accepted", KpakFields(kpak)...)
+
+	got := records(t, buf)
+	if len(got) != 1 {
+		t.Fatalf("Expected 1 record, got %d", len(got))
+	}
+	want := map[string]any{
+		"msg":        "Accepted",
+		"level":      "INFO",
+		KeyComponent: "agent",
+		KeyNode:      "synapse-test-9091",
+		KeyKpak:      kpak.ID,
+		KeySPID:      kpak.SPID,
+		KeySource:    "crm",
+	}
+	for key, value := range want {
+		if got[0][key] != value {
+			t.Errorf("Expected %s=%v, got %v", key, value, got[0][key])
+		}
+	}
+}
+
+func TestSetup_Text(t *testing.T) {
+	buf := setup(t, "", "")
+
+	For("gossip").Info("Node joined cluster", KeyPeer, "synapse-10.0.0.2-9091")
+	line := buf.String()
+	for _, want := range []string{"level=INFO", `msg="Node joined cluster"`, "component=gossip", "node=synapse-test-9091", "peer=synapse-10.0.0.2-9091"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("Expected %q in %q", want, line)
+		}
+	}
+}
+
+func TestSetup_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Setup(&buf, "verbose", FormatText, ""); err == nil {
+		t.Fatal("Expected an unknown level to be rejected")
+	}
+	if err := Setup(&buf, "info", "xml", ""); err == nil {
+		t.Fatal("Expected an unknown format to be rejected")
+	}
+}
+
+func TestSetLevel(t *testing.T) {
+	buf := setup(t, "warn", FormatJSON)
+	logger := For("agent")
+
+	logger.Info("hidden")
+	if buf.Len() != 0 {
+		t.Fatalf("Expected info to be filtered at warn, got %q", buf.String())
+	}
+
+	previous, err := SetLevel("debug")
+	if err != nil {
+		t.Fatalf("SetLevel failed: %v", err)
+	}
+	if previous != "warn" || Level() != "debug" {
+		t.Fatalf("Expected warn -> debug, got %s -> %s", previous, Level())
+	}
+	logger.Debug("shown")
+	if got := records(t, buf); len(got) != 1 || got[0]["msg"] != "shown" {
+		t.Fatalf("Expected the debug record after lowering the level, got %v", got)
+	}
+
+	if _, err := SetLevel("loud"); err == nil {
+		t.Fatal("Expected an unknown level to be rejected")
+	}
+	if Level() != "debug" {
+		t.Fatalf("Expected a rejected level to leave debug in place, got %s", Level())
+	}
+}
+
+func TestStdLogger(t *testing.T) {
+	buf := setup(t, "info", FormatJSON)
+	std := StdLogger(For("memberlist"))
+
+	std.Printf("[DEBUG] memberlist: Stream connection from=127.0.0.1")
+	std.Printf("[WARN] memberlist: Was able to connect to node but other probes failed")
+	std.Printf("[ERR] memberlist: Failed to send ping")
+	std.Printf("untagged line")
+
+	got := records(t, buf)
+	if len(got) != 3 {
+		t.Fatalf("Expected the debug line to be filtered, got %d records", len(got))
+	}
+	wantLevels := []string{"WARN", "ERROR", "INFO"}
+	for i, record := range got {
+		if record["level"] != wantLevels[i] {
+			t.Errorf("Record %d: expected level %s, got %v", i, wantLevels[i], record["level"])
+		}
+		if record[KeyComponent] != "memberlist" {
+			t.Errorf("Record %d: expected component memberlist, got %v", i, record[KeyComponent])
+		}
+	}
+	if got[0]["msg"] != "memberlist: Was able to connect to node but other probes failed" {
+		t.Errorf("Expected the tag to be stripped, got %v", got[0]["msg"])
+	}
+}
@@ -1,12 +1,21 @@
 package monitoring
 
 import (
+	"fmt"
 	"runtime"
 	"sync"
 	"sync/atomic"
 	"time"
 )
 
+// MaxSourceLabels bounds how many distinct sources get their own per-source
+// series. Sources are whatever clients send, so claims from any further source
+// are counted under OtherSource instead.
+const MaxSourceLabels = 100
+
+// OtherSource labels the counts of sources beyond MaxSourceLabels.
+const OtherSource = "other"
+
 // Metrics tracks agent performance and health metrics.
 type Metrics struct {
 	startTime time.Time
@@ -16,74 +25,69 @@ type Metrics struct {
 	totalQueries       int64
 	totalAcceptedKpaks int64
 	totalRejectedKpaks int64
+	policyViolations   int64
+	confidenceCapped   int64
+	throttledKpaks     int64
+	quotaExceeded      int64
+	ingestErrors       int64
 
 	// Rate tracking
 	ingestRateTracker *RateTracker
 	queryRateTracker  *RateTracker
+	rejectRateTracker *RateTracker
+	errorRateTracker  *RateTracker
 
 	// Sources tracking
-	activeSources map[string]time.Time
-	sourcesMutex  sync.RWMutex
-}
-
-// RateTracker tracks operations per minute.
-type RateTracker struct {
-	events []time.Time
-	mutex  sync.Mutex
-	maxAge time.Duration
+	activeSources         map[string]time.Time
+	violationsBySource    map[string]int64
+	throttledBySource     map[string]int64
+	quotaExceededBySource map[string]int64
+	sourceLabels          map[string]struct{} // Sources with their own series, at most MaxSourceLabels
+	sourcesMutex          sync.RWMutex
+
+	// Exported to Prometheus
+	ingestBySource     *counterVec   // source, result
+	reconcileDecisions *counterVec   // outcome
+	gossipMessages     *counterVec   // direction, type
+	gossipDuplicates   *counterVec   // peer
+	propagationLatency *histogramVec // peer
+	gcRuns             int64
+	gcRemoved          int64
+	queryLatency       *Histogram
+	walAppendLatency   *Histogram
+	walFsyncLatency    *Histogram
+
+	process *ProcessSampler
 }
 
 // NewMetrics creates a new metrics tracker.
 func NewMetrics() *Metrics {
 	return &Metrics{
-		startTime:         time.Now(),
-		ingestRateTracker: NewRateTracker(time.Minute),
-		queryRateTracker:  NewRateTracker(time.Minute),
-		activeSources:     make(map[string]time.Time),
+		startTime:             time.Now(),
+		ingestRateTracker:     NewRateTracker(RateWindows...),
+		queryRateTracker:      NewRateTracker(RateWindows...),
+		rejectRateTracker:     NewRateTracker(RateWindows...),
+		errorRateTracker:      NewRateTracker(RateWindows...),
+		activeSources:         make(map[string]time.Time),
+		violationsBySource:    make(map[string]int64),
+		throttledBySource:     make(map[string]int64),
+		quotaExceededBySource: make(map[string]int64),
+		sourceLabels:          make(map[string]struct{}),
+		ingestBySource:        newCounterVec(),
+		reconcileDecisions:    newCounterVec(),
+		gossipMessages:        newCounterVec(),
+		gossipDuplicates:      newCounterVec(),
+		propagationLatency:    newHistogramVec(PropagationBuckets),
+		queryLatency:          NewHistogram(LatencyBuckets),
+		walAppendLatency:      NewHistogram(LatencyBuckets),
+		walFsyncLatency:       NewHistogram(LatencyBuckets),
+		process:               NewProcessSampler(DefaultCPUWindow),
 	}
 }
 
-// NewRateTracker creates a new rate tracker.
-func NewRateTracker(window time.Duration) *RateTracker {
-	return &RateTracker{
-		events: make([]time.Time, 0),
-		maxAge: window,
-	}
-}
-
-// Record records an event in the rate tracker.
-func (rt *RateTracker) Record() {
-	rt.mutex.Lock()
-	defer rt.mutex.Unlock()
-
-	now := time.Now()
-	rt.events = append(rt.events, now)
-
-	// Clean old events
-	rt.cleanOldEvents(now)
-}
-
-// GetRate returns the current rate (events per tracking window).
-func (rt *RateTracker) GetRate() int64 {
-	rt.mutex.Lock()
-	defer rt.mutex.Unlock()
-
-	rt.cleanOldEvents(time.Now())
-	return int64(len(rt.events))
-}
-
-// cleanOldEvents removes events older than the tracking window.
-func (rt *RateTracker) cleanOldEvents(now time.Time) {
-	cutoff := now.Add(-rt.maxAge)
-	validEvents := rt.events[:0]
-
-	for _, event := range rt.events {
-		if event.After(cutoff) {
-			validEvents = append(validEvents, event)
-		}
-	}
-
-	rt.events = validEvents
+// Process returns the sampler that reads the agent's own resource usage.
+func (m *Metrics) Process() *ProcessSampler {
+	return m.process
 }
 
 // Metric tracking methods
@@ -93,15 +97,58 @@ func (m *Metrics) RecordIngest(source string, accepted bool) {
 	atomic.AddInt64(&m.totalIngestedKpaks, 1)
 	m.ingestRateTracker.Record()
 
+	m.sourcesMutex.Lock()
+	label := m.sourceLabel(source)
+	m.activeSources[label] = time.Now()
+	m.sourcesMutex.Unlock()
+
 	if accepted {
 		atomic.AddInt64(&m.totalAcceptedKpaks, 1)
+		m.ingestBySource.add(1, label, "accepted")
 	} else {
 		atomic.AddInt64(&m.totalRejectedKpaks, 1)
+		m.rejectRateTracker.Record()
+		m.ingestBySource.add(1, label, "rejected")
 	}
+}
+
+// RecordIngestError records a k-pak that failed rather than lost: one that was
+// invalid, refused by the policy or a quota, or could not be persisted. Claims
+// kept as runner-ups, outranked or held for review are not errors.
+func (m *Metrics) RecordIngestError() {
+	atomic.AddInt64(&m.ingestErrors, 1)
+	m.errorRateTracker.Record()
+}
+
+// RecordPolicyViolation records a claim refused because its source may not assert it.
+func (m *Metrics) RecordPolicyViolation(source string) {
+	atomic.AddInt64(&m.policyViolations, 1)
+
+	m.sourcesMutex.Lock()
+	m.violationsBySource[m.sourceLabel(source)]++
+	m.sourcesMutex.Unlock()
+}
+
+// RecordConfidenceCapped records a claim whose confidence was lowered to its source's cap.
+func (m *Metrics) RecordConfidenceCapped() {
+	atomic.AddInt64(&m.confidenceCapped, 1)
+}
+
+// RecordThrottled records a claim that hit its source's or client's rate limit.
+func (m *Metrics) RecordThrottled(source string) {
+	atomic.AddInt64(&m.throttledKpaks, 1)
+
+	m.sourcesMutex.Lock()
+	m.throttledBySource[m.sourceLabel(source)]++
+	m.sourcesMutex.Unlock()
+}
+
+// RecordQuotaExceeded records a claim refused because its source used up its daily quota.
+func (m *Metrics) RecordQuotaExceeded(source string) {
+	atomic.AddInt64(&m.quotaExceeded, 1)
 
-	// Track active source
 	m.sourcesMutex.Lock()
-	m.activeSources[source] = time.Now()
+	m.quotaExceededBySource[m.sourceLabel(source)]++
 	m.sourcesMutex.Unlock()
 }
 
@@ -111,10 +158,53 @@ func (m *Metrics) RecordQuery() {
 	m.queryRateTracker.Record()
 }
 
+// ObserveQueryLatency records how long a query took to answer.
+func (m *Metrics) ObserveQueryLatency(d time.Duration) {
+	m.queryLatency.Observe(d.Seconds())
+}
+
+// RecordReconcile records a reconciliation decision ("accepted", "candidate" or "rejected").
+func (m *Metrics) RecordReconcile(outcome string) {
+	m.reconcileDecisions.add(1, outcome)
+}
+
+// ObserveWAL records the latency of a WAL operation ("append" or "fsync").
+func (m *Metrics) ObserveWAL(op string, d time.Duration) {
+	switch op {
+	case "append":
+		m.walAppendLatency.Observe(d.Seconds())
+	case "fsync":
+		m.walFsyncLatency.Observe(d.Seconds())
+	}
+}
+
+// RecordGossip records a gossip message "sent", "received" or "failed", by message type.
+func (m *Metrics) RecordGossip(direction, msgType string) {
+	m.gossipMessages.add(1, direction, msgType)
+}
+
+// ObservePropagation records how long a k-pak took to arrive from the peer that took it in.
+func (m *Metrics) ObservePropagation(peer string, d time.Duration) {
+	if d < 0 {
+		d = 0 // Clock skew between agents
+	}
+	m.propagationLatency.observe(d.Seconds(), peer)
+}
+
+// RecordGossipDuplicate records a gossiped k-pak that was already known on arrival.
+func (m *Metrics) RecordGossipDuplicate(peer string) {
+	m.gossipDuplicates.add(1, peer)
+}
+
+// RecordGC records a garbage collection run and how many k-paks it removed.
+func (m *Metrics) RecordGC(removed int) {
+	atomic.AddInt64(&m.gcRuns, 1)
+	atomic.AddInt64(&m.gcRemoved, int64(removed))
+}
+
 // GetMetrics returns current metric values.
 func (m *Metrics) GetMetrics(totalKpaks, totalSubjects int32) MetricsSnapshot {
-	var memStats runtime.MemStats
-	runtime.ReadMemStats(&memStats)
+	process := m.process.Stats()
 
 	// Get active sources (sources active in last 5 minutes)
 	m.sourcesMutex.RLock()
@@ -125,6 +215,9 @@ func (m *Metrics) GetMetrics(totalKpaks, totalSubjects int32) MetricsSnapshot {
 			activeSources = append(activeSources, source)
 		}
 	}
+	violations := copyCounts(m.violationsBySource)
+	throttled := copyCounts(m.throttledBySource)
+	quotaExceeded := copyCounts(m.quotaExceededBySource)
 	m.sourcesMutex.RUnlock()
 
 	return MetricsSnapshot{
@@ -132,33 +225,92 @@ func (m *Metrics) GetMetrics(totalKpaks, totalSubjects int32) MetricsSnapshot {
 		TotalSubjects:    totalSubjects,
 		IngestRatePerMin: m.ingestRateTracker.GetRate(),
 		QueryRatePerMin:  m.queryRateTracker.GetRate(),
+		IngestRates:      m.ingestRateTracker.PerMinute(),
+		QueryRates:       m.queryRateTracker.PerMinute(),
 		UptimeSeconds:    int64(time.Since(m.startTime).Seconds()),
-		MemoryUsageBytes: int64(memStats.Alloc),
-		CPUUsagePercent:  0.0, // TODO: Implement CPU tracking
+		MemoryUsageBytes: process.MemoryBytes(),
+		CPUUsagePercent:  float32(process.CPUPercent),
 		Version:          "1.0.0",
 		ActiveSources:    activeSources,
 		TotalIngested:    atomic.LoadInt64(&m.totalIngestedKpaks),
 		TotalAccepted:    atomic.LoadInt64(&m.totalAcceptedKpaks),
 		TotalRejected:    atomic.LoadInt64(&m.totalRejectedKpaks),
 		TotalQueries:     atomic.LoadInt64(&m.totalQueries),
+		PolicyViolations: atomic.LoadInt64(&m.policyViolations),
+		ConfidenceCapped: atomic.LoadInt64(&m.confidenceCapped),
+
+		PolicyViolationsBySource: violations,
+		Throttled:                atomic.LoadInt64(&m.throttledKpaks),
+		ThrottledBySource:        throttled,
+		QuotaExceeded:            atomic.LoadInt64(&m.quotaExceeded),
+		QuotaExceededBySource:    quotaExceeded,
+
+		HeapAllocBytes: process.HeapAllocBytes,
+		Goroutines:     int32(process.Goroutines),
+		OpenFDs:        int32(process.OpenFDs),
+		GCRuns:         process.GCRuns,
+		GCPauseTotalNs: int64(process.GCPauseTotal),
+		GCPauseLastNs:  int64(process.GCPauseLast),
+		GCPauseMaxNs:   int64(process.GCPauseMax),
+	}
+}
+
+// sourceLabel returns the label source is counted under: the source itself
+// until MaxSourceLabels sources have been seen, OtherSource for any after that.
+// The caller holds sourcesMutex.
+func (m *Metrics) sourceLabel(source string) string {
+	if _, ok := m.sourceLabels[source]; ok {
+		return source
+	}
+	if len(m.sourceLabels) >= MaxSourceLabels {
+		return OtherSource
+	}
+	m.sourceLabels[source] = struct{}{}
+	return source
+}
+
+// copyCounts copies a per-source count map; the caller holds sourcesMutex.
+func copyCounts(counts map[string]int64) map[string]int64 {
+	copied := make(map[string]int64, len(counts))
+	for source, count := range counts {
+		copied[source] = count
 	}
+	return copied
 }
 
 // MetricsSnapshot represents a point-in-time view of metrics.
 type MetricsSnapshot struct {
-	TotalKpaks       int32    `json:"total_kpaks"`
-	TotalSubjects    int32    `json:"total_subjects"`
-	IngestRatePerMin int64    `json:"ingest_rate_per_min"`
-	QueryRatePerMin  int64    `json:"query_rate_per_min"`
-	UptimeSeconds    int64    `json:"uptime_seconds"`
-	MemoryUsageBytes int64    `json:"memory_usage_bytes"`
-	CPUUsagePercent  float32  `json:"cpu_usage_percent"`
-	Version          string   `json:"version"`
-	ActiveSources    []string `json:"active_sources"`
-	TotalIngested    int64    `json:"total_ingested"`
-	TotalAccepted    int64    `json:"total_accepted"`
-	TotalRejected    int64    `json:"total_rejected"`
-	TotalQueries     int64    `json:"total_queries"`
+	TotalKpaks       int32     `json:"total_kpaks"`
+	TotalSubjects    int32     `json:"total_subjects"`
+	IngestRatePerMin int64     `json:"ingest_rate_per_min"`
+	QueryRatePerMin  int64     `json:"query_rate_per_min"`
+	IngestRates      []float64 `json:"ingest_rates"` // Per-minute averages over each of RateWindows
+	QueryRates       []float64 `json:"query_rates"`  // Per-minute averages over each of RateWindows
+	UptimeSeconds    int64     `json:"uptime_seconds"`
+	MemoryUsageBytes int64     `json:"memory_usage_bytes"` // Resident set size, or heap obtained from the OS where unknown
+	CPUUsagePercent  float32   `json:"cpu_usage_percent"`  // Averaged over DefaultCPUWindow; 100 is one full core
+	Version          string    `json:"version"`
+	ActiveSources    []string  `json:"active_sources"`
+	TotalIngested    int64     `json:"total_ingested"`
+	TotalAccepted    int64     `json:"total_accepted"`
+	TotalRejected    int64     `json:"total_rejected"`
+	TotalQueries     int64     `json:"total_queries"`
+	PolicyViolations int64     `json:"policy_violations"` // Claims refused by the source policy
+	ConfidenceCapped int64     `json:"confidence_capped"` // Claims lowered to their source's confidence cap
+
+	PolicyViolationsBySource map[string]int64 `json:"policy_violations_by_source"`
+	Throttled                int64            `json:"throttled"`                // Claims that hit a rate limit
+	ThrottledBySource        map[string]int64 `json:"throttled_by_source"`      // Claims that hit a rate limit, per source
+	QuotaExceeded            int64            `json:"quota_exceeded"`           // Claims refused by a daily quota
+	QuotaExceededBySource    map[string]int64 `json:"quota_exceeded_by_source"` // Claims refused by a daily quota, per source
+
+	HeapAllocBytes int64  `json:"heap_alloc_bytes"`
+	Goroutines     int32  `json:"goroutines"`
+	OpenFDs        int32  `json:"open_fds"` // -1 where unknown
+	GCRuns         uint32 `json:"gc_runs"`  // Go GC cycles, not k-pak garbage collection
+	GCPauseTotalNs int64  `json:"gc_pause_total_ns"`
+	GCPauseLastNs  int64  `json:"gc_pause_last_ns"`
+	GCPauseMaxNs   int64  `json:"gc_pause_max_ns"` // Longest of the last 256 pauses
 }
 
 // HealthStatus represents the health status of the agent.
@@ -170,21 +322,63 @@ type HealthStatus struct {
 	LastActivity  int64  `json:"last_activity"`  // Last activity timestamp
 }
 
+// Resource levels above which the agent reports itself degraded.
+const (
+	maxHealthyMemoryBytes = 1024 * 1024 * 1024 // 1GB
+	maxHealthyCPUPerCore  = 90.0               // Percent of every core
+	maxHealthyGoroutines  = 10000
+	maxHealthyFDFraction  = 0.9 // Of the open file descriptor limit
+)
+
+// IngestErrorRatio returns the share of k-paks that failed (see RecordIngestError)
+// within the last window and how many were ingested in it.
+func (m *Metrics) IngestErrorRatio(window time.Duration) (float64, int64) {
+	total := m.ingestRateTracker.Count(window)
+	if total == 0 {
+		return 0, 0
+	}
+	return float64(m.errorRateTracker.Count(window)) / float64(total), total
+}
+
+// ProcessHealth grades the agent's resource usage and uptime.
+func (m *Metrics) ProcessHealth() (string, string) {
+	return evaluateHealth(m.process.Stats(), runtime.NumCPU(), int64(time.Since(m.startTime).Seconds()))
+}
+
 // GetHealthStatus returns the current health status.
 func (m *Metrics) GetHealthStatus(totalKpaks int32) HealthStatus {
 	uptime := int64(time.Since(m.startTime).Seconds())
+	status, message := m.ProcessHealth()
 
+	return HealthStatus{
+		Status:        status,
+		Message:       message,
+		KpakCount:     totalKpaks,
+		UptimeSeconds: uptime,
+		LastActivity:  time.Now().Unix(),
+	}
+}
+
+// evaluateHealth decides the health status from the process's resource usage.
+func evaluateHealth(process ProcessStats, cpus int, uptime int64) (string, string) {
 	// Determine health status
 	status := "healthy"
 	message := "Agent is operating normally"
 
 	// Check for potential issues
-	memStats := runtime.MemStats{}
-	runtime.ReadMemStats(&memStats)
-
-	if memStats.Alloc > 1024*1024*1024 { // > 1GB
+	switch {
+	case process.MemoryBytes() > maxHealthyMemoryBytes:
 		status = "degraded"
 		message = "High memory usage detected"
+	case process.CPUPercent > maxHealthyCPUPerCore*float64(cpus):
+		status = "degraded"
+		message = fmt.Sprintf("High CPU usage detected (%.0f%%)", process.CPUPercent)
+	case process.Goroutines > maxHealthyGoroutines:
+		status = "degraded"
+		message = fmt.Sprintf("High goroutine count detected (%d)", process.Goroutines)
+	case process.MaxFDs > 0 && float64(process.OpenFDs) > maxHealthyFDFraction*float64(process.MaxFDs):
+		status = "degraded"
+		message = fmt.Sprintf("Close to the open file limit (%d of %d)", process.OpenFDs, process.MaxFDs)
 	}
 
 	if uptime < 30 { // Less than 30 seconds uptime
@@ -192,11 +386,5 @@ func (m *Metrics) GetHealthStatus(totalKpaks int32) HealthStatus {
 		message = "Agent recently started"
 	}
 
-	return HealthStatus{
-		Status:        status,
-		Message:       message,
-		KpakCount:     totalKpaks,
-		UptimeSeconds: uptime,
-		LastActivity:  time.Now().Unix(),
-	}
+	return status, message
 }
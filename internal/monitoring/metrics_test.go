@@ -40,16 +40,16 @@ func TestNewRateTracker(t *testing.T) {
 		t.Fatal("NewRateTracker returned nil")
 	}
 
-	if tracker.maxAge != window {
-		t.Fatalf("Expected max age %v, got %v", window, tracker.maxAge)
+	if len(tracker.windows) != 1 || tracker.windows[0] != window {
+		t.Fatalf("Expected window %v, got %v", window, tracker.windows)
 	}
 
-	if tracker.events == nil {
-		t.Fatal("Events slice not initialized")
+	if tracker.resolution != time.Second {
+		t.Fatalf("Expected one-second buckets, got %v", tracker.resolution)
 	}
 
-	if len(tracker.events) != 0 {
-		t.Fatal("Events slice should be empty initially")
+	if tracker.GetRate() != 0 {
+		t.Fatal("Rate should be zero initially")
 	}
 }
 
@@ -435,6 +435,84 @@ func TestMetricsSnapshot_Structure(t *testing.T) {
 	}
 }
 
+func TestMetrics_PolicyCounters(t *testing.T) {
+	metrics := NewMetrics()
+
+	metrics.RecordPolicyViolation("rogue-scout")
+	metrics.RecordPolicyViolation("rogue-scout")
+	metrics.RecordPolicyViolation("ai-scout")
+	metrics.RecordConfidenceCapped()
+
+	snapshot := metrics.GetMetrics(0, 0)
+	if snapshot.PolicyViolations != 3 || snapshot.ConfidenceCapped != 1 {
+		t.Fatalf("Unexpected policy counters: %d violations, %d capped", snapshot.PolicyViolations, snapshot.ConfidenceCapped)
+	}
+	if snapshot.PolicyViolationsBySource["rogue-scout"] != 2 || snapshot.PolicyViolationsBySource["ai-scout"] != 1 {
+		t.Fatalf("Unexpected violations by source: %v", snapshot.PolicyViolationsBySource)
+	}
+}
+
+func TestMetrics_RateLimitCounters(t *testing.T) {
+	metrics := NewMetrics()
+
+	metrics.RecordThrottled("runaway-scout")
+	metrics.RecordThrottled("runaway-scout")
+	metrics.RecordQuotaExceeded("runaway-scout")
+
+	snapshot := metrics.GetMetrics(0, 0)
+	if snapshot.Throttled != 2 || snapshot.QuotaExceeded != 1 {
+		t.Fatalf("Unexpected rate limit counters: %d throttled, %d over quota", snapshot.Throttled, snapshot.QuotaExceeded)
+	}
+	if snapshot.ThrottledBySource["runaway-scout"] != 2 {
+		t.Fatalf("Unexpected throttled by source: %v", snapshot.ThrottledBySource)
+	}
+	if snapshot.QuotaExceededBySource["runaway-scout"] != 1 {
+		t.Fatalf("Unexpected over quota by source: %v", snapshot.QuotaExceededBySource)
+	}
+
+	var out strings.Builder
+	if err := metrics.WritePrometheus(&out, Gauges{}); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `sutra_quota_exceeded_total{source="runaway-scout"} 1`) {
+		t.Fatalf("Expected the quota series labelled by source:\n%s", out.String())
+	}
+}
+
+func TestMetrics_SourceLabelsBounded(t *testing.T) {
+	metrics := NewMetrics()
+
+	for i := 0; i < MaxSourceLabels+50; i++ {
+		source := fmt.Sprintf("scout-%d", i)
+		metrics.RecordIngest(source, false)
+		metrics.RecordPolicyViolation(source)
+	}
+	// A source seen before the bound keeps its own series
+	metrics.RecordThrottled("scout-0")
+
+	snapshot := metrics.GetMetrics(0, 0)
+	if len(snapshot.PolicyViolationsBySource) != MaxSourceLabels+1 {
+		t.Fatalf("Expected %d violation series, got %d", MaxSourceLabels+1, len(snapshot.PolicyViolationsBySource))
+	}
+	if snapshot.PolicyViolationsBySource[OtherSource] != 50 {
+		t.Fatalf("Expected the extra sources folded into %q, got %d", OtherSource, snapshot.PolicyViolationsBySource[OtherSource])
+	}
+	if snapshot.ThrottledBySource["scout-0"] != 1 {
+		t.Fatalf("Unexpected throttled by source: %v", snapshot.ThrottledBySource)
+	}
+
+	var out strings.Builder
+	if err := metrics.WritePrometheus(&out, Gauges{}); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	if !strings.Contains(out.String(), `sutra_ingest_kpaks_total{source="other",result="rejected"} 50`) {
+		t.Fatalf("Expected the ingest series folded into %q:\n%s", OtherSource, out.String())
+	}
+	if strings.Contains(out.String(), fmt.Sprintf(`source="scout-%d"`, MaxSourceLabels)) {
+		t.Fatal("Expected no series for sources beyond the bound")
+	}
+}
+
 func TestHealthStatus_Structure(t *testing.T) {
 	// Test that HealthStatus has all expected fields
 	status := HealthStatus{
@@ -467,3 +545,72 @@ func TestHealthStatus_Structure(t *testing.T) {
 func containsString(s, substr string) bool {
 	return strings.Contains(s, substr)
 }
+
+func TestEvaluateHealth(t *testing.T) {
+	healthy := ProcessStats{RSSBytes: 64 << 20, CPUPercent: 20, Goroutines: 50, OpenFDs: 20, MaxFDs: 1024}
+	if status, _ := evaluateHealth(healthy, 4, 3600); status != "healthy" {
+		t.Fatalf("Expected healthy, got %s", status)
+	}
+
+	degraded := []ProcessStats{
+		{RSSBytes: 2 << 30},
+		{CPUPercent: 390},
+		{Goroutines: 20000},
+		{OpenFDs: 1000, MaxFDs: 1024},
+	}
+	for _, stats := range degraded {
+		if status, message := evaluateHealth(stats, 4, 3600); status != "degraded" || message == "" {
+			t.Fatalf("Expected %+v to be degraded, got %s", stats, status)
+		}
+	}
+
+	// Several cores may be busy before CPU counts as high
+	if status, _ := evaluateHealth(ProcessStats{CPUPercent: 150}, 4, 3600); status != "healthy" {
+		t.Fatalf("Expected 150%% on 4 cores to be healthy, got %s", status)
+	}
+}
+
+func TestMetrics_GetMetricsProcess(t *testing.T) {
+	snapshot := NewMetrics().GetMetrics(0, 0)
+	if snapshot.Goroutines <= 0 || snapshot.HeapAllocBytes <= 0 || snapshot.OpenFDs == 0 {
+		t.Fatalf("Expected process stats in the snapshot, got %+v", snapshot)
+	}
+}
+
+func TestMetrics_RateWindows(t *testing.T) {
+	metrics := NewMetrics()
+	for i := 0; i < 30; i++ {
+		metrics.RecordIngest("source1", true)
+	}
+	metrics.RecordQuery()
+
+	snapshot := metrics.GetMetrics(0, 0)
+	if len(snapshot.IngestRates) != len(RateWindows) || len(snapshot.QueryRates) != len(RateWindows) {
+		t.Fatalf("Expected a rate per window, got %v and %v", snapshot.IngestRates, snapshot.QueryRates)
+	}
+	if snapshot.IngestRates[0] != 30 || snapshot.IngestRates[1] != 6 || snapshot.IngestRates[2] != 2 {
+		t.Fatalf("Unexpected ingest rates %v", snapshot.IngestRates)
+	}
+}
+
+func TestMetrics_IngestErrorRatio(t *testing.T) {
+	metrics := NewMetrics()
+	if ratio, total := metrics.IngestErrorRatio(5 * time.Minute); ratio != 0 || total != 0 {
+		t.Fatalf("Expected no ingests, got %v of %d", ratio, total)
+	}
+
+	for i := 0; i < 2; i++ {
+		metrics.RecordIngest("source1", true)
+	}
+	// Runner-ups are not accepted, but nothing went wrong
+	metrics.RecordIngest("source1", false)
+	if ratio, total := metrics.IngestErrorRatio(5 * time.Minute); ratio != 0 || total != 3 {
+		t.Fatalf("Expected no errors among 3, got %v of %d", ratio, total)
+	}
+
+	metrics.RecordIngest("source1", false)
+	metrics.RecordIngestError()
+	if ratio, total := metrics.IngestErrorRatio(5 * time.Minute); ratio != 0.25 || total != 4 {
+		t.Fatalf("Expected a quarter of 4 failed, got %v of %d", ratio, total)
+	}
+}
@@ -0,0 +1,186 @@
+package monitoring
+
+import (
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultCPUWindow is how far back CPU usage is averaged.
+const DefaultCPUWindow = time.Minute
+
+// ProcessStats is a reading of the agent's own resource usage.
+type ProcessStats struct {
+	CPUPercent     float64       // CPU used over the sampling window; 100 is one full core
+	CPUSeconds     float64       // User and system CPU time since the process started
+	RSSBytes       int64         // Resident set size (0 = unknown on this platform)
+	HeapAllocBytes int64         // Bytes of allocated heap objects
+	HeapSysBytes   int64         // Heap memory obtained from the OS
+	Goroutines     int           // Live goroutines
+	OpenFDs        int           // Open file descriptors (-1 = unknown on this platform)
+	MaxFDs         int           // Soft limit on open file descriptors (0 = unknown)
+	GCRuns         uint32        // Completed Go GC cycles
+	GCPauseTotal   time.Duration // Stop-the-world pause time since the process started
+	GCPauseLast    time.Duration // Pause of the most recent GC cycle
+	GCPauseMax     time.Duration // Longest of the last 256 pauses
+}
+
+// MemoryBytes is the best available measure of the process's memory: its RSS,
+// or the heap obtained from the OS where RSS is unknown.
+func (p ProcessStats) MemoryBytes() int64 {
+	if p.RSSBytes > 0 {
+		return p.RSSBytes
+	}
+	return p.HeapSysBytes
+}
+
+type cpuSample struct {
+	at      time.Time
+	seconds float64
+}
+
+// ProcessSampler reads the process's resource usage and averages CPU usage over
+// a rolling window. Readings are taken on every Stats call and, once started,
+// periodically in the background so the window stays filled between calls.
+type ProcessSampler struct {
+	window  time.Duration
+	samples []cpuSample // Oldest first, none older than the window except the reference
+	mutex   sync.Mutex
+	stop    chan struct{}
+	wg      sync.WaitGroup
+}
+
+// NewProcessSampler creates a sampler averaging CPU usage over window (0 = DefaultCPUWindow).
+func NewProcessSampler(window time.Duration) *ProcessSampler {
+	if window <= 0 {
+		window = DefaultCPUWindow
+	}
+	s := &ProcessSampler{window: window}
+	s.record(time.Now(), cpuTime())
+	return s
+}
+
+// Start samples CPU time every interval until Stop is called.
+func (s *ProcessSampler) Start(interval time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.stop != nil {
+		return
+	}
+	s.stop = make(chan struct{})
+
+	s.wg.Add(1)
+	go func(stop chan struct{}) {
+		defer s.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				s.mutex.Lock()
+				s.record(time.Now(), cpuTime())
+				s.mutex.Unlock()
+			case <-stop:
+				return
+			}
+		}
+	}(s.stop)
+}
+
+// Stop ends background sampling.
+func (s *ProcessSampler) Stop() {
+	s.mutex.Lock()
+	stop := s.stop
+	s.stop = nil
+	s.mutex.Unlock()
+
+	if stop != nil {
+		close(stop)
+		s.wg.Wait()
+	}
+}
+
+// Stats takes a reading of the process's resource usage.
+func (s *ProcessSampler) Stats() ProcessStats {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+
+	now := time.Now()
+	seconds := cpuTime()
+
+	s.mutex.Lock()
+	reference := s.samples[0]
+	s.record(now, seconds)
+	s.mutex.Unlock()
+
+	stats := ProcessStats{
+		CPUSeconds:     seconds,
+		RSSBytes:       residentMemory(),
+		HeapAllocBytes: int64(memStats.Alloc),
+		HeapSysBytes:   int64(memStats.HeapSys),
+		Goroutines:     runtime.NumGoroutine(),
+		OpenFDs:        openFDs(),
+		MaxFDs:         maxFDs(),
+		GCRuns:         memStats.NumGC,
+		GCPauseTotal:   time.Duration(memStats.PauseTotalNs),
+	}
+	if elapsed := now.Sub(reference.at).Seconds(); elapsed > 0 {
+		stats.CPUPercent = (seconds - reference.seconds) / elapsed * 100
+	}
+	if memStats.NumGC > 0 {
+		stats.GCPauseLast = time.Duration(memStats.PauseNs[(memStats.NumGC+255)%256])
+		recent := len(memStats.PauseNs)
+		if memStats.NumGC < uint32(recent) {
+			recent = int(memStats.NumGC)
+		}
+		for _, pause := range memStats.PauseNs[:recent] {
+			if d := time.Duration(pause); d > stats.GCPauseMax {
+				stats.GCPauseMax = d
+			}
+		}
+	}
+	return stats
+}
+
+// record adds a sample and drops those no longer needed, keeping the newest one
+// at least a window old as the reference for the average. The caller holds the mutex.
+func (s *ProcessSampler) record(at time.Time, seconds float64) {
+	s.samples = append(s.samples, cpuSample{at: at, seconds: seconds})
+
+	cutoff := at.Add(-s.window)
+	drop := 0
+	for drop+1 < len(s.samples) && !s.samples[drop+1].at.After(cutoff) {
+		drop++
+	}
+	s.samples = s.samples[drop:]
+}
+
+// residentMemory reads the RSS from /proc, where available.
+func residentMemory() int64 {
+	data, err := os.ReadFile("/proc/self/statm")
+	if err != nil {
+		return 0
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) < 2 {
+		return 0
+	}
+	pages, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return pages * int64(os.Getpagesize())
+}
+
+// openFDs counts the entries in /proc/self/fd, where available.
+func openFDs() int {
+	entries, err := os.ReadDir("/proc/self/fd")
+	if err != nil {
+		return -1
+	}
+	return len(entries)
+}
@@ -0,0 +1,13 @@
+//go:build !unix
+
+package monitoring
+
+// cpuTime is not available on this platform; CPU usage reads as zero.
+func cpuTime() float64 {
+	return 0
+}
+
+// maxFDs is not available on this platform.
+func maxFDs() int {
+	return 0
+}
@@ -0,0 +1,68 @@
+package monitoring
+
+import (
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestProcessSampler_Stats(t *testing.T) {
+	sampler := NewProcessSampler(time.Minute)
+
+	// Burn some CPU so the average has something to measure
+	deadline := time.Now().Add(50 * time.Millisecond)
+	for x := 0; time.Now().Before(deadline); x++ {
+		_ = x * x
+	}
+	runtime.GC()
+
+	stats := sampler.Stats()
+	if stats.Goroutines <= 0 || stats.HeapAllocBytes <= 0 || stats.HeapSysBytes <= 0 {
+		t.Fatalf("Expected goroutines and heap to be reported, got %+v", stats)
+	}
+	if stats.GCRuns == 0 || stats.GCPauseTotal <= 0 || stats.GCPauseMax < stats.GCPauseLast {
+		t.Fatalf("Expected GC stats after a collection, got %+v", stats)
+	}
+	if stats.MemoryBytes() <= 0 {
+		t.Fatalf("Expected memory usage to be reported, got %+v", stats)
+	}
+
+	if runtime.GOOS == "linux" {
+		if stats.CPUSeconds <= 0 || stats.CPUPercent <= 0 {
+			t.Fatalf("Expected CPU usage to be measured, got %+v", stats)
+		}
+		if stats.RSSBytes <= 0 || stats.OpenFDs <= 0 || stats.MaxFDs <= 0 {
+			t.Fatalf("Expected RSS and open files to be read from /proc, got %+v", stats)
+		}
+	}
+}
+
+func TestProcessSampler_RecordKeepsWindow(t *testing.T) {
+	sampler := NewProcessSampler(time.Minute)
+	start := time.Now()
+	sampler.samples = nil
+
+	for i := 0; i <= 10; i++ {
+		sampler.record(start.Add(time.Duration(i)*15*time.Second), float64(i))
+	}
+
+	// The reference is the newest sample at least a minute old
+	if len(sampler.samples) != 5 || sampler.samples[0].seconds != 6 {
+		t.Fatalf("Expected samples from the last minute plus one reference, got %+v", sampler.samples)
+	}
+}
+
+func TestProcessSampler_StartStop(t *testing.T) {
+	sampler := NewProcessSampler(time.Minute)
+	sampler.Start(5 * time.Millisecond)
+	sampler.Start(5 * time.Millisecond) // Already started
+	time.Sleep(30 * time.Millisecond)
+	sampler.Stop()
+	sampler.Stop() // Already stopped
+
+	sampler.mutex.Lock()
+	defer sampler.mutex.Unlock()
+	if len(sampler.samples) < 2 {
+		t.Fatalf("Expected background samples, got %d", len(sampler.samples))
+	}
+}
@@ -0,0 +1,27 @@
+//go:build unix
+
+package monitoring
+
+import "syscall"
+
+// cpuTime returns the user and system CPU seconds used by the process.
+func cpuTime() float64 {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0
+	}
+	return timevalSeconds(usage.Utime) + timevalSeconds(usage.Stime)
+}
+
+func timevalSeconds(tv syscall.Timeval) float64 {
+	return float64(tv.Sec) + float64(tv.Usec)/1e6
+}
+
+// maxFDs returns the soft limit on open file descriptors.
+func maxFDs() int {
+	var limit syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &limit); err != nil {
+		return 0
+	}
+	return int(limit.Cur)
+}
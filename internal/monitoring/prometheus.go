@@ -0,0 +1,315 @@
+package monitoring
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// LatencyBuckets are histogram upper bounds, in seconds, for operations from
+// sub-millisecond fsyncs to slow multi-second queries.
+var LatencyBuckets = []float64{0.0001, 0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// PropagationBuckets are histogram upper bounds, in seconds, for how long a k-pak
+// takes to reach a peer, from a fast LAN to a mesh that takes half a minute.
+var PropagationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Histogram counts observations into cumulative buckets, as Prometheus expects.
+type Histogram struct {
+	bounds []float64
+	counts []int64 // Per bucket, not cumulative; the last one is +Inf
+	sum    float64
+	count  int64
+	mutex  sync.Mutex
+}
+
+// NewHistogram creates a histogram with the given ascending upper bounds.
+func NewHistogram(bounds []float64) *Histogram {
+	return &Histogram{
+		bounds: bounds,
+		counts: make([]int64, len(bounds)+1),
+	}
+}
+
+// Observe records a value.
+func (h *Histogram) Observe(v float64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	h.counts[sort.SearchFloat64s(h.bounds, v)]++
+	h.sum += v
+	h.count++
+}
+
+// snapshot returns the cumulative bucket counts, sum and count.
+func (h *Histogram) snapshot() ([]int64, float64, int64) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	cumulative := make([]int64, len(h.counts))
+	var total int64
+	for i, c := range h.counts {
+		total += c
+		cumulative[i] = total
+	}
+	return cumulative, h.sum, h.count
+}
+
+// histogramVec is a set of histograms keyed by label values.
+type histogramVec struct {
+	bounds []float64
+	values map[string]*Histogram
+	mutex  sync.Mutex
+}
+
+func newHistogramVec(bounds []float64) *histogramVec {
+	return &histogramVec{bounds: bounds, values: make(map[string]*Histogram)}
+}
+
+func (h *histogramVec) observe(v float64, labels ...string) {
+	key := strings.Join(labels, "\x00")
+	h.mutex.Lock()
+	histogram, ok := h.values[key]
+	if !ok {
+		histogram = NewHistogram(h.bounds)
+		h.values[key] = histogram
+	}
+	h.mutex.Unlock()
+	histogram.Observe(v)
+}
+
+// snapshot returns the label values and histograms, sorted by labels.
+func (h *histogramVec) snapshot() ([][]string, []*Histogram) {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	keys := make([]string, 0, len(h.values))
+	for key := range h.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	labels := make([][]string, len(keys))
+	histograms := make([]*Histogram, len(keys))
+	for i, key := range keys {
+		labels[i] = strings.Split(key, "\x00")
+		histograms[i] = h.values[key]
+	}
+	return labels, histograms
+}
+
+// counterVec is a set of counters keyed by label values.
+type counterVec struct {
+	values map[string]int64
+	mutex  sync.Mutex
+}
+
+func newCounterVec() *counterVec {
+	return &counterVec{values: make(map[string]int64)}
+}
+
+func (c *counterVec) add(n int64, labels ...string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.values[strings.Join(labels, "\x00")] += n
+}
+
+// snapshot returns the label values and counts, sorted by labels.
+func (c *counterVec) snapshot() ([][]string, []int64) {
+	c.mutex.Lock()
+	keys := make([]string, 0, len(c.values))
+	for key := range c.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	counts := make([]int64, len(keys))
+	for i, key := range keys {
+		counts[i] = c.values[key]
+	}
+	c.mutex.Unlock()
+
+	labels := make([][]string, len(keys))
+	for i, key := range keys {
+		labels[i] = strings.Split(key, "\x00")
+	}
+	return labels, counts
+}
+
+// Gauges are point-in-time values read from the agent's components at scrape time.
+type Gauges struct {
+	Kpaks          int // Current truths
+	Subjects       int
+	Candidates     int // Ranked claims, truths included
+	Conflicts      int // Recorded concurrent updates
+	Peers          int // Mesh members, this agent included
+	PendingReviews int
+}
+
+// WritePrometheus writes every metric in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(w io.Writer, gauges Gauges) error {
+	e := &expositionWriter{w: bufio.NewWriter(w)}
+
+	e.counter("sutra_ingest_kpaks_total", "K-paks ingested from clients and peers, by source and result.", []string{"source", "result"}, m.ingestBySource)
+	e.counter("sutra_reconcile_decisions_total", "Reconciliation decisions, by outcome.", []string{"outcome"}, m.reconcileDecisions)
+	e.counter("sutra_gossip_messages_total", "Gossip messages sent, received and failed, by message type.", []string{"direction", "type"}, m.gossipMessages)
+	e.histogramVec("sutra_gossip_propagation_seconds", "Time from a k-pak being taken in by an agent to its arrival here, by origin peer.", []string{"peer"}, m.propagationLatency)
+	e.counter("sutra_gossip_duplicate_kpaks_total", "Gossiped k-paks that were already known on arrival, by origin peer.", []string{"peer"}, m.gossipDuplicates)
+	e.single("sutra_queries_total", "Queries answered.", "counter", float64(atomic.LoadInt64(&m.totalQueries)))
+	e.histogram("sutra_query_duration_seconds", "Time taken to answer queries.", m.queryLatency)
+	e.histogram("sutra_wal_append_duration_seconds", "Time taken to write a WAL record, fsync included.", m.walAppendLatency)
+	e.histogram("sutra_wal_fsync_duration_seconds", "Time taken to fsync the WAL.", m.walFsyncLatency)
+	e.single("sutra_gc_runs_total", "Garbage collection runs.", "counter", float64(atomic.LoadInt64(&m.gcRuns)))
+	e.single("sutra_gc_removed_kpaks_total", "Expired or decayed k-paks removed by garbage collection.", "counter", float64(atomic.LoadInt64(&m.gcRemoved)))
+	e.single("sutra_ingest_errors_total", "K-paks that were invalid, refused by the policy or a quota, or not persisted.", "counter", float64(atomic.LoadInt64(&m.ingestErrors)))
+	e.single("sutra_policy_violations_total", "Claims refused by the source policy.", "counter", float64(atomic.LoadInt64(&m.policyViolations)))
+	e.single("sutra_confidence_capped_total", "Claims lowered to their source's confidence cap.", "counter", float64(atomic.LoadInt64(&m.confidenceCapped)))
+	e.single("sutra_throttled_kpaks_total", "Claims that hit a rate limit.", "counter", float64(atomic.LoadInt64(&m.throttledKpaks)))
+	m.sourcesMutex.RLock()
+	quotaExceeded := copyCounts(m.quotaExceededBySource)
+	m.sourcesMutex.RUnlock()
+	e.counterMap("sutra_quota_exceeded_total", "Claims refused by a daily quota, by source.", "source", quotaExceeded)
+
+	e.single("sutra_engine_kpaks", "Current truths held by the engine.", "gauge", float64(gauges.Kpaks))
+	e.single("sutra_engine_subjects", "Subjects with at least one truth.", "gauge", float64(gauges.Subjects))
+	e.single("sutra_engine_candidates", "Ranked claims kept for fallback, truths included.", "gauge", float64(gauges.Candidates))
+	e.single("sutra_engine_conflicts", "Recorded concurrent updates.", "gauge", float64(gauges.Conflicts))
+	e.single("sutra_mesh_peers", "Mesh members, this agent included.", "gauge", float64(gauges.Peers))
+	e.single("sutra_review_pending", "Claims waiting for review.", "gauge", float64(gauges.PendingReviews))
+
+	process := m.process.Stats()
+	e.single("sutra_process_cpu_seconds_total", "User and system CPU time spent.", "counter", process.CPUSeconds)
+	e.single("sutra_process_cpu_percent", "CPU used over the last minute; 100 is one full core.", "gauge", process.CPUPercent)
+	e.single("sutra_process_resident_memory_bytes", "Resident memory size.", "gauge", float64(process.RSSBytes))
+	e.single("sutra_process_open_fds", "Open file descriptors.", "gauge", float64(process.OpenFDs))
+	e.single("sutra_process_max_fds", "Limit on open file descriptors.", "gauge", float64(process.MaxFDs))
+	e.single("sutra_go_goroutines", "Live goroutines.", "gauge", float64(process.Goroutines))
+	e.single("sutra_memory_alloc_bytes", "Bytes of allocated heap objects.", "gauge", float64(process.HeapAllocBytes))
+	e.single("sutra_go_heap_sys_bytes", "Heap memory obtained from the OS.", "gauge", float64(process.HeapSysBytes))
+	e.single("sutra_go_gc_cycles_total", "Completed Go GC cycles.", "counter", float64(process.GCRuns))
+	e.single("sutra_go_gc_pause_seconds_total", "Go GC stop-the-world pause time.", "counter", process.GCPauseTotal.Seconds())
+	e.single("sutra_go_gc_last_pause_seconds", "Pause of the most recent Go GC cycle.", "gauge", process.GCPauseLast.Seconds())
+	e.single("sutra_go_gc_max_pause_seconds", "Longest of the last 256 Go GC pauses.", "gauge", process.GCPauseMax.Seconds())
+	e.single("sutra_uptime_seconds", "Seconds since the agent started.", "gauge", time.Since(m.startTime).Seconds())
+
+	if e.err != nil {
+		return e.err
+	}
+	return e.w.Flush()
+}
+
+// Handler serves the metrics to Prometheus, reading gauges on every scrape.
+func Handler(m *Metrics, gauges func() Gauges) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := m.WritePrometheus(w, gauges()); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// expositionWriter writes metric families, remembering the first write error.
+type expositionWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (e *expositionWriter) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+func (e *expositionWriter) header(name, help, kind string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (e *expositionWriter) single(name, help, kind string, value float64) {
+	e.header(name, help, kind)
+	e.printf("%s %s\n", name, formatValue(value))
+}
+
+func (e *expositionWriter) counter(name, help string, labelNames []string, vec *counterVec) {
+	e.header(name, help, "counter")
+	labels, counts := vec.snapshot()
+	for i := range labels {
+		e.printf("%s%s %d\n", name, formatLabels(labelNames, labels[i]), counts[i])
+	}
+}
+
+// counterMap writes one series per key of counts, labelled labelName.
+func (e *expositionWriter) counterMap(name, help, labelName string, counts map[string]int64) {
+	e.header(name, help, "counter")
+	keys := make([]string, 0, len(counts))
+	for key := range counts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		e.printf("%s%s %d\n", name, formatLabels([]string{labelName}, []string{key}), counts[key])
+	}
+}
+
+func (e *expositionWriter) histogram(name, help string, h *Histogram) {
+	e.header(name, help, "histogram")
+	e.histogramSeries(name, nil, nil, h)
+}
+
+func (e *expositionWriter) histogramVec(name, help string, labelNames []string, vec *histogramVec) {
+	e.header(name, help, "histogram")
+	labels, histograms := vec.snapshot()
+	for i := range labels {
+		e.histogramSeries(name, labelNames, labels[i], histograms[i])
+	}
+}
+
+// histogramSeries writes the buckets, sum and count of one labelled histogram.
+func (e *expositionWriter) histogramSeries(name string, labelNames, labelValues []string, h *Histogram) {
+	cumulative, sum, count := h.snapshot()
+	bucketLabels := append(labelNames[:len(labelNames):len(labelNames)], "le")
+	for i, bound := range h.bounds {
+		values := append(labelValues[:len(labelValues):len(labelValues)], formatValue(bound))
+		e.printf("%s_bucket%s %d\n", name, formatLabels(bucketLabels, values), cumulative[i])
+	}
+	values := append(labelValues[:len(labelValues):len(labelValues)], "+Inf")
+	e.printf("%s_bucket%s %d\n", name, formatLabels(bucketLabels, values), cumulative[len(cumulative)-1])
+	suffix := ""
+	if len(labelNames) > 0 {
+		suffix = formatLabels(labelNames, labelValues)
+	}
+	e.printf("%s_sum%s %s\n%s_count%s %d\n", name, suffix, formatValue(sum), name, suffix, count)
+}
+
+func formatLabels(names, values []string) string {
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := ""
+		if i < len(values) {
+			value = values[i]
+		}
+		pairs[i] = name + "=\"" + escapeLabel(value) + "\""
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(value string) string {
+	return labelEscaper.Replace(value)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
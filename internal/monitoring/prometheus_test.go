@@ -0,0 +1,96 @@
+package monitoring
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHistogram_Observe(t *testing.T) {
+	h := NewHistogram([]float64{0.1, 1})
+	h.Observe(0.05)
+	h.Observe(0.1)
+	h.Observe(0.5)
+	h.Observe(3)
+
+	cumulative, sum, count := h.snapshot()
+	if cumulative[0] != 2 || cumulative[1] != 3 || cumulative[2] != 4 {
+		t.Fatalf("Unexpected cumulative buckets %v", cumulative)
+	}
+	if count != 4 || sum < 3.64 || sum > 3.66 {
+		t.Fatalf("Unexpected sum %v and count %d", sum, count)
+	}
+}
+
+func TestMetrics_WritePrometheus(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.RecordIngest("scout-1", true)
+	metrics.RecordIngest("scout-1", false)
+	metrics.RecordIngest(`odd"source`, true)
+	metrics.RecordReconcile("accepted")
+	metrics.RecordGossip("sent", "kpak")
+	metrics.RecordGossip("failed", "kpak")
+	metrics.ObservePropagation("synapse-10.0.0.2-9091", 30*time.Millisecond)
+	metrics.ObservePropagation("synapse-10.0.0.2-9091", -time.Second)
+	metrics.RecordGossipDuplicate("synapse-10.0.0.2-9091")
+	metrics.RecordGC(3)
+	metrics.ObserveQueryLatency(2 * time.Millisecond)
+	metrics.ObserveWAL("fsync", 300*time.Microsecond)
+	metrics.ObserveWAL("append", 400*time.Microsecond)
+
+	var out strings.Builder
+	if err := metrics.WritePrometheus(&out, Gauges{Kpaks: 7, Peers: 3}); err != nil {
+		t.Fatalf("WritePrometheus failed: %v", err)
+	}
+	text := out.String()
+
+	for _, want := range []string{
+		"# TYPE sutra_ingest_kpaks_total counter",
+		`sutra_ingest_kpaks_total{source="scout-1",result="accepted"} 1`,
+		`sutra_ingest_kpaks_total{source="scout-1",result="rejected"} 1`,
+		`sutra_ingest_kpaks_total{source="odd\"source",result="accepted"} 1`,
+		`sutra_reconcile_decisions_total{outcome="accepted"} 1`,
+		`sutra_gossip_messages_total{direction="failed",type="kpak"} 1`,
+		"# TYPE sutra_gossip_propagation_seconds histogram",
+		`sutra_gossip_propagation_seconds_bucket{peer="synapse-10.0.0.2-9091",le="0.001"} 1`,
+		`sutra_gossip_propagation_seconds_bucket{peer="synapse-10.0.0.2-9091",le="0.05"} 2`,
+		`sutra_gossip_propagation_seconds_bucket{peer="synapse-10.0.0.2-9091",le="+Inf"} 2`,
+		`sutra_gossip_propagation_seconds_count{peer="synapse-10.0.0.2-9091"} 2`,
+		`sutra_gossip_duplicate_kpaks_total{peer="synapse-10.0.0.2-9091"} 1`,
+		"sutra_gc_runs_total 1",
+		"sutra_gc_removed_kpaks_total 3",
+		"# TYPE sutra_query_duration_seconds histogram",
+		`sutra_query_duration_seconds_bucket{le="0.001"} 0`,
+		`sutra_query_duration_seconds_bucket{le="0.0025"} 1`,
+		`sutra_query_duration_seconds_bucket{le="+Inf"} 1`,
+		"sutra_query_duration_seconds_count 1",
+		"sutra_wal_fsync_duration_seconds_count 1",
+		"sutra_wal_append_duration_seconds_count 1",
+		"sutra_engine_kpaks 7",
+		"sutra_mesh_peers 3",
+	} {
+		if !strings.Contains(text, want+"\n") {
+			t.Fatalf("Expected %q in exposition:\n%s", want, text)
+		}
+	}
+}
+
+func TestHandler(t *testing.T) {
+	metrics := NewMetrics()
+	metrics.RecordQuery()
+
+	recorder := httptest.NewRecorder()
+	Handler(metrics, func() Gauges { return Gauges{Subjects: 2} }).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", recorder.Code)
+	}
+	if !strings.HasPrefix(recorder.Header().Get("Content-Type"), "text/plain; version=0.0.4") {
+		t.Fatalf("Unexpected content type %q", recorder.Header().Get("Content-Type"))
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "sutra_queries_total 1\n") || !strings.Contains(body, "sutra_engine_subjects 2\n") {
+		t.Fatalf("Unexpected body:\n%s", body)
+	}
+}
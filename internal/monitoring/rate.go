@@ -0,0 +1,105 @@
+package monitoring
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RateWindows are the windows ingest and query rates are reported over, like a load average.
+var RateWindows = []time.Duration{time.Minute, 5 * time.Minute, 15 * time.Minute}
+
+// slotsPerWindow is how many buckets the shortest window is split into.
+const slotsPerWindow = 60
+
+// rateBucket counts the events of one time slot. slot says which one, so a
+// bucket left over from an earlier turn of the ring is recognized as stale.
+type rateBucket struct {
+	slot  int64
+	count int64
+}
+
+// RateTracker counts events over sliding windows in constant memory, using a ring
+// of buckets (one second wide for a one-minute window). Recording is an atomic add;
+// the lock is only taken when a bucket is reused for a new slot.
+type RateTracker struct {
+	windows    []time.Duration
+	resolution time.Duration // Width of a bucket
+	buckets    []rateBucket
+	mutex      sync.Mutex
+	now        func() time.Time
+}
+
+// NewRateTracker creates a rate tracker for the given windows; GetRate reports the first.
+func NewRateTracker(windows ...time.Duration) *RateTracker {
+	if len(windows) == 0 {
+		windows = []time.Duration{time.Minute}
+	}
+
+	shortest, longest := windows[0], windows[0]
+	for _, w := range windows {
+		if w < shortest {
+			shortest = w
+		}
+		if w > longest {
+			longest = w
+		}
+	}
+	resolution := shortest / slotsPerWindow
+	if resolution <= 0 {
+		resolution = 1
+	}
+
+	return &RateTracker{
+		windows:    windows,
+		resolution: resolution,
+		buckets:    make([]rateBucket, int(longest/resolution)+1),
+		now:        time.Now,
+	}
+}
+
+// Record records an event in the rate tracker.
+func (rt *RateTracker) Record() {
+	slot := rt.now().UnixNano() / int64(rt.resolution)
+	b := &rt.buckets[slot%int64(len(rt.buckets))]
+
+	if atomic.LoadInt64(&b.slot) != slot {
+		rt.mutex.Lock()
+		if atomic.LoadInt64(&b.slot) != slot {
+			atomic.StoreInt64(&b.count, 0)
+			atomic.StoreInt64(&b.slot, slot)
+		}
+		rt.mutex.Unlock()
+	}
+	atomic.AddInt64(&b.count, 1)
+}
+
+// GetRate returns the current rate (events per tracking window).
+func (rt *RateTracker) GetRate() int64 {
+	return rt.Count(rt.windows[0])
+}
+
+// Count returns the events recorded within the last window, to the nearest bucket.
+// Windows longer than the tracker's longest are cut short.
+func (rt *RateTracker) Count(window time.Duration) int64 {
+	current := rt.now().UnixNano() / int64(rt.resolution)
+	oldest := current - int64(window/rt.resolution) + 1
+
+	var total int64
+	for i := range rt.buckets {
+		b := &rt.buckets[i]
+		if slot := atomic.LoadInt64(&b.slot); slot >= oldest && slot <= current {
+			total += atomic.LoadInt64(&b.count)
+		}
+	}
+	return total
+}
+
+// PerMinute returns the average events per minute over each of the tracker's windows.
+func (rt *RateTracker) PerMinute() []float64 {
+	rates := make([]float64, len(rt.windows))
+	for i, w := range rt.windows {
+		rates[i] = float64(rt.Count(w)) / w.Minutes()
+	}
+	return rates
+}
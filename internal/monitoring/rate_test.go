@@ -0,0 +1,103 @@
+package monitoring
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// fakeClock is a settable time source for rate trackers.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func TestRateTracker_Windows(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1700000000, 0)}
+	tracker := NewRateTracker(RateWindows...)
+	tracker.now = clock.Now
+
+	if len(tracker.buckets) != 901 {
+		t.Fatalf("Expected 901 one-second buckets for 15 minutes, got %d", len(tracker.buckets))
+	}
+
+	// 10 events a minute for 15 minutes, then 60 in the last minute
+	for minute := 0; minute < 14; minute++ {
+		for i := 0; i < 10; i++ {
+			tracker.Record()
+			clock.now = clock.now.Add(6 * time.Second)
+		}
+	}
+	for i := 0; i < 60; i++ {
+		tracker.Record()
+		clock.now = clock.now.Add(time.Second)
+	}
+	clock.now = clock.now.Add(-time.Second)
+
+	if got := tracker.GetRate(); got != 60 {
+		t.Fatalf("Expected 60 events in the last minute, got %d", got)
+	}
+	rates := tracker.PerMinute()
+	if rates[0] != 60 || rates[1] != (60+40)/5.0 || rates[2] != (60+140)/15.0 {
+		t.Fatalf("Unexpected per-minute rates %v", rates)
+	}
+
+	// Buckets from an earlier turn of the ring are not counted
+	clock.now = clock.now.Add(20 * time.Minute)
+	tracker.Record()
+	if got := tracker.Count(15 * time.Minute); got != 1 {
+		t.Fatalf("Expected only the new event after the ring wrapped, got %d", got)
+	}
+}
+
+func TestRateTracker_ConstantMemory(t *testing.T) {
+	tracker := NewRateTracker(RateWindows...)
+	buckets := len(tracker.buckets)
+
+	allocs := testing.AllocsPerRun(100000, tracker.Record)
+	if allocs != 0 {
+		t.Fatalf("Expected Record not to allocate, got %v allocs per call", allocs)
+	}
+	if len(tracker.buckets) != buckets {
+		t.Fatalf("Expected the ring to stay at %d buckets, got %d", buckets, len(tracker.buckets))
+	}
+}
+
+// BenchmarkRateTracker_Record records bursts of growing size; heap use per tracker
+// stays flat no matter how many events are recorded.
+func BenchmarkRateTracker_Record(b *testing.B) {
+	for _, events := range []int{1000, 10000, 100000, 1000000} {
+		b.Run(fmt.Sprintf("events=%d", events), func(b *testing.B) {
+			b.ReportAllocs()
+			var before, after runtime.MemStats
+			runtime.GC()
+			runtime.ReadMemStats(&before)
+
+			tracker := NewRateTracker(RateWindows...)
+			for n := 0; n < b.N; n++ {
+				for i := 0; i < events; i++ {
+					tracker.Record()
+				}
+				tracker.GetRate()
+			}
+
+			runtime.ReadMemStats(&after)
+			b.ReportMetric(float64(after.HeapAlloc)-float64(before.HeapAlloc), "heap-bytes")
+			runtime.KeepAlive(tracker)
+		})
+	}
+}
+
+func BenchmarkRateTracker_RecordParallel(b *testing.B) {
+	tracker := NewRateTracker(RateWindows...)
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			tracker.Record()
+		}
+	})
+}
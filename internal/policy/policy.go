@@ -0,0 +1,149 @@
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// Default decides what happens to claims from sources no entry matches.
+type Default string
+
+const (
+	DefaultAllow Default = "allow"
+	DefaultDeny  Default = "deny"
+)
+
+// Grant lets a source claim facts whose subject and predicate match the patterns,
+// with confidence capped at MaxConfidence. Patterns use path.Match syntax; empty
+// matches everything.
+type Grant struct {
+	Subject       string  `yaml:"subject"`
+	Predicate     string  `yaml:"predicate"`
+	MaxConfidence float32 `yaml:"max_confidence"` // Higher confidences are lowered to this (0 = no cap)
+}
+
+func (g Grant) matches(kpak *core.Kpak) bool {
+	return matchPattern(g.Subject, kpak.Subject) && matchPattern(g.Predicate, kpak.Predicate)
+}
+
+// SourcePolicy lists what the sources matching Source may claim.
+type SourcePolicy struct {
+	Source string  `yaml:"source"` // Source name or pattern
+	Allow  []Grant `yaml:"allow"`
+}
+
+// Policy maps source identities to the facts they may assert. The first entry
+// whose Source matches a claim's source decides; sources no entry matches fall
+// back to Default.
+type Policy struct {
+	Default Default        `yaml:"default"` // "allow" (default) or "deny"
+	Sources []SourcePolicy `yaml:"sources"`
+}
+
+// Load reads a policy file.
+func Load(filename string) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a YAML policy.
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Validate checks the default and every pattern and cap.
+func (p *Policy) Validate() error {
+	switch p.Default {
+	case "", DefaultAllow, DefaultDeny:
+	default:
+		return fmt.Errorf("invalid policy default %q (want allow or deny)", p.Default)
+	}
+
+	for _, sp := range p.Sources {
+		if sp.Source == "" {
+			return fmt.Errorf("policy entry needs a source")
+		}
+		if _, err := path.Match(sp.Source, ""); err != nil {
+			return fmt.Errorf("invalid source pattern %q: %w", sp.Source, err)
+		}
+		for _, grant := range sp.Allow {
+			for _, pattern := range []string{grant.Subject, grant.Predicate} {
+				if _, err := path.Match(pattern, ""); err != nil {
+					return fmt.Errorf("source %q: invalid pattern %q: %w", sp.Source, pattern, err)
+				}
+			}
+			if grant.MaxConfidence < 0 || grant.MaxConfidence > 1 {
+				return fmt.Errorf("source %q: max_confidence must be between 0 and 1", sp.Source)
+			}
+		}
+	}
+	return nil
+}
+
+// Authorize checks that a claim's source may assert it and lowers its confidence
+// to the cap of the matching grants, regenerating its ID. It reports whether the
+// confidence was capped; an error means the claim must be refused.
+func (p *Policy) Authorize(kpak *core.Kpak) (capped bool, err error) {
+	sp := p.sourcePolicy(kpak.Source)
+	if sp == nil {
+		if p.Default == DefaultDeny {
+			return false, fmt.Errorf("source %q is not authorized by policy", kpak.Source)
+		}
+		return false, nil
+	}
+
+	// The most generous matching grant applies
+	allowed := false
+	var limit float32
+	for _, grant := range sp.Allow {
+		if !grant.matches(kpak) {
+			continue
+		}
+		if !allowed || grant.MaxConfidence == 0 || (limit != 0 && grant.MaxConfidence > limit) {
+			limit = grant.MaxConfidence
+		}
+		allowed = true
+	}
+	if !allowed {
+		return false, fmt.Errorf("source %q may not assert %s %s", kpak.Source, kpak.Subject, kpak.Predicate)
+	}
+
+	if limit > 0 && kpak.Confidence > limit {
+		kpak.Confidence = limit
+		kpak.RegenerateComputedFields()
+		return true, nil
+	}
+	return false, nil
+}
+
+func (p *Policy) sourcePolicy(source string) *SourcePolicy {
+	for i := range p.Sources {
+		if matchPattern(p.Sources[i].Source, source) {
+			return &p.Sources[i]
+		}
+	}
+	return nil
+}
+
+func matchPattern(pattern, value string) bool {
+	if pattern == "" {
+		return true
+	}
+	matched, _ := path.Match(pattern, value)
+	return matched
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+const testPolicy = `
+default: deny
+sources:
+  - source: "prometheus-*"
+    allow:
+      - subject: "server-*"
+        predicate: "cpu_*"
+  - source: "ai-scout"
+    allow:
+      - predicate: "status"
+        max_confidence: 0.6
+      - subject: "server-01"
+        predicate: "status"
+        max_confidence: 0.8
+`
+
+func TestParse(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Default != DefaultDeny || len(p.Sources) != 2 || len(p.Sources[1].Allow) != 2 {
+		t.Fatalf("Unexpected policy %+v", p)
+	}
+
+	invalid := []string{
+		"default: maybe",
+		"sources:\n  - allow: []",
+		"sources:\n  - source: \"[bad\"",
+		"sources:\n  - source: a\n    allow:\n      - predicate: \"[bad\"",
+		"sources:\n  - source: a\n    allow:\n      - max_confidence: 2",
+		"sources: [",
+	}
+	for _, data := range invalid {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Fatalf("Expected policy %q to be invalid", data)
+		}
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	allowed := core.NewKpak("server-01", "cpu_usage", 42.0, "prometheus-eu", 0.99)
+	if capped, err := p.Authorize(allowed); err != nil || capped {
+		t.Fatalf("Expected the claim to pass unchanged, got %v, %v", capped, err)
+	}
+
+	outOfScope := core.NewKpak("server-01", "owner", "team-a", "prometheus-eu", 0.9)
+	if _, err := p.Authorize(outOfScope); err == nil {
+		t.Fatal("Expected a predicate outside the grants to be refused")
+	}
+	unknown := core.NewKpak("server-01", "cpu_usage", 42.0, "rogue", 0.9)
+	if _, err := p.Authorize(unknown); err == nil {
+		t.Fatal("Expected an unlisted source to be refused by default")
+	}
+
+	// The most generous matching grant caps the confidence
+	overconfident := core.NewKpak("server-01", "status", "down", "ai-scout", 0.95)
+	id := overconfident.ID
+	capped, err := p.Authorize(overconfident)
+	if err != nil || !capped {
+		t.Fatalf("Expected the confidence to be capped, got %v, %v", capped, err)
+	}
+	if overconfident.Confidence != 0.8 || overconfident.ID == id {
+		t.Fatalf("Expected confidence 0.8 and a new ID, got %v (%s)", overconfident.Confidence, overconfident.ID)
+	}
+	other := core.NewKpak("server-02", "status", "down", "ai-scout", 0.95)
+	if p.Authorize(other); other.Confidence != 0.6 {
+		t.Fatalf("Expected confidence 0.6, got %v", other.Confidence)
+	}
+}
+
+func TestPolicy_DefaultAllow(t *testing.T) {
+	p := &Policy{Sources: []SourcePolicy{{Source: "ai-scout", Allow: []Grant{{Predicate: "status"}}}}}
+
+	if _, err := p.Authorize(core.NewKpak("server-01", "owner", "team-a", "cmdb", 0.9)); err != nil {
+		t.Fatalf("Expected unlisted sources to be allowed, got %v", err)
+	}
+	if _, err := p.Authorize(core.NewKpak("server-01", "owner", "team-a", "ai-scout", 0.9)); err == nil {
+		t.Fatal("Expected a listed source to be limited to its grants")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "policy_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	filename := filepath.Join(tempDir, "policy.yaml")
+	if err := os.WriteFile(filename, []byte(testPolicy), 0644); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	if _, err := Load(filename); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if _, err := Load(filepath.Join(tempDir, "missing.yaml")); err == nil {
+		t.Fatal("Expected a missing policy file to fail")
+	}
+}
@@ -0,0 +1,261 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"sync"
+	"time"
+)
+
+// maxIdleBuckets is how many buckets are kept before full (idle) ones are dropped.
+const maxIdleBuckets = 10000
+
+// Mode decides what happens to a claim over its rate limit.
+type Mode string
+
+const (
+	// ModeReject ends the ingest stream with ResourceExhausted.
+	ModeReject Mode = "reject"
+	// ModeThrottle holds the stream until the bucket refills.
+	ModeThrottle Mode = "throttle"
+)
+
+// Limit is a token bucket: Rate k-paks per second on average, up to Burst at once.
+type Limit struct {
+	Rate  float64 `yaml:"rate"`  // K-paks per second (0 = unlimited)
+	Burst int     `yaml:"burst"` // Largest burst (0 = Rate rounded up)
+}
+
+func (l Limit) burst() float64 {
+	if l.Burst > 0 {
+		return float64(l.Burst)
+	}
+	return math.Max(1, math.Ceil(l.Rate))
+}
+
+// SourceLimit overrides the per-source limit and quota for sources matching Source
+// (a name or path.Match pattern). Zero values mean unlimited.
+type SourceLimit struct {
+	Source     string `yaml:"source"`
+	Limit      `yaml:",inline"`
+	DailyQuota int64 `yaml:"daily_quota"`
+}
+
+// Config holds ingest rate limits. Without limits every claim passes.
+type Config struct {
+	Mode       Mode          `yaml:"mode"`        // "reject" (default) or "throttle"
+	PerSource  Limit         `yaml:"per_source"`  // Bucket for each source
+	PerClient  Limit         `yaml:"per_client"`  // Bucket for each client connection identity
+	DailyQuota int64         `yaml:"daily_quota"` // Accepted k-paks per source per UTC day (0 = unlimited)
+	Sources    []SourceLimit `yaml:"sources"`     // Per-source overrides; the first match wins
+}
+
+// Validate checks the mode, limits and patterns.
+func (c Config) Validate() error {
+	switch c.Mode {
+	case "", ModeReject, ModeThrottle:
+	default:
+		return fmt.Errorf("invalid rate limit mode %q (want reject or throttle)", c.Mode)
+	}
+
+	limits := []Limit{c.PerSource, c.PerClient}
+	for _, sl := range c.Sources {
+		if _, err := path.Match(sl.Source, ""); err != nil || sl.Source == "" {
+			return fmt.Errorf("invalid rate limit source pattern %q", sl.Source)
+		}
+		if sl.DailyQuota < 0 {
+			return fmt.Errorf("rate limit for %q: daily_quota must not be negative", sl.Source)
+		}
+		limits = append(limits, sl.Limit)
+	}
+	for _, l := range limits {
+		if l.Rate < 0 || l.Burst < 0 {
+			return fmt.Errorf("rate limits must not be negative")
+		}
+	}
+	if c.DailyQuota < 0 {
+		return fmt.Errorf("daily_quota must not be negative")
+	}
+	return nil
+}
+
+// Error reports a claim over a limit or quota.
+type Error struct {
+	Key        string        // Source or client that hit the limit
+	Quota      bool          // The daily quota, not the rate, was exhausted
+	RetryAfter time.Duration // When the bucket has a token again (rate limits only)
+}
+
+func (e *Error) Error() string {
+	if e.Quota {
+		return fmt.Sprintf("daily quota exhausted for source %q", e.Key)
+	}
+	return fmt.Sprintf("rate limit exceeded for %s, retry after %s", e.Key, e.RetryAfter.Round(time.Millisecond))
+}
+
+type bucket struct {
+	limit  Limit
+	tokens float64
+	last   time.Time
+}
+
+// available returns the tokens in the bucket at now.
+func (b *bucket) available(now time.Time) float64 {
+	return math.Min(b.limit.burst(), b.tokens+now.Sub(b.last).Seconds()*b.limit.Rate)
+}
+
+// refill adds the tokens earned since the last update.
+func (b *bucket) refill(now time.Time) {
+	b.tokens = b.available(now)
+	b.last = now
+}
+
+// wait returns how long until the bucket holds a whole token.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / b.limit.Rate * float64(time.Second))
+}
+
+// Limiter applies token buckets per source and per client and daily quotas per source.
+type Limiter struct {
+	config  Config
+	sources map[string]*bucket
+	clients map[string]*bucket
+	day     string           // UTC day the quotas count
+	quotas  map[string]int64 // Accepted k-paks per source on day
+	mutex   sync.Mutex
+	now     func() time.Time
+}
+
+// New creates a limiter.
+func New(config Config) (*Limiter, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.Mode == "" {
+		config.Mode = ModeReject
+	}
+
+	return &Limiter{
+		config:  config,
+		sources: make(map[string]*bucket),
+		clients: make(map[string]*bucket),
+		quotas:  make(map[string]int64),
+		now:     time.Now,
+	}, nil
+}
+
+// Mode returns what happens to claims over their rate limit.
+func (l *Limiter) Mode() Mode {
+	return l.config.Mode
+}
+
+// Allow takes a token from the client's and the source's buckets, or neither if
+// either is empty, in which case it returns an *Error saying when to retry.
+func (l *Limiter) Allow(client, source string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := l.now()
+	sourceLimit, _ := l.sourceLimits(source)
+
+	var retry time.Duration
+	var key string
+	var taken []*bucket
+	for _, check := range []struct {
+		buckets map[string]*bucket
+		key     string
+		limit   Limit
+	}{
+		{l.clients, client, l.config.PerClient},
+		{l.sources, source, sourceLimit},
+	} {
+		if check.limit.Rate <= 0 {
+			continue
+		}
+		b := l.bucketFor(check.buckets, check.key, check.limit, now)
+		if wait := b.wait(); wait > retry {
+			retry = wait
+			key = check.key
+		}
+		taken = append(taken, b)
+	}
+
+	if retry > 0 {
+		return &Error{Key: key, RetryAfter: retry}
+	}
+	for _, b := range taken {
+		b.tokens--
+	}
+	return nil
+}
+
+// CheckQuota returns an *Error if the source has used up today's quota.
+func (l *Limiter) CheckQuota(source string) error {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	_, limit := l.sourceLimits(source)
+	if limit <= 0 {
+		return nil
+	}
+	if l.todaysQuotas()[source] >= limit {
+		return &Error{Key: source, Quota: true}
+	}
+	return nil
+}
+
+// CountAccepted charges an accepted k-pak to the source's daily quota.
+func (l *Limiter) CountAccepted(source string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if _, limit := l.sourceLimits(source); limit > 0 {
+		l.todaysQuotas()[source]++
+	}
+}
+
+// sourceLimits returns the bucket limit and daily quota that apply to a source.
+func (l *Limiter) sourceLimits(source string) (Limit, int64) {
+	for _, sl := range l.config.Sources {
+		if matched, _ := path.Match(sl.Source, source); matched {
+			return sl.Limit, sl.DailyQuota
+		}
+	}
+	return l.config.PerSource, l.config.DailyQuota
+}
+
+func (l *Limiter) bucketFor(buckets map[string]*bucket, key string, limit Limit, now time.Time) *bucket {
+	b, exists := buckets[key]
+	if !exists {
+		if len(buckets) >= maxIdleBuckets {
+			pruneFull(buckets, now)
+		}
+		b = &bucket{limit: limit, tokens: limit.burst(), last: now}
+		buckets[key] = b
+		return b
+	}
+	b.refill(now)
+	return b
+}
+
+// pruneFull drops buckets that have refilled completely; they behave exactly like new ones.
+func pruneFull(buckets map[string]*bucket, now time.Time) {
+	for key, b := range buckets {
+		if b.available(now) >= b.limit.burst() {
+			delete(buckets, key)
+		}
+	}
+}
+
+// todaysQuotas returns the counts for the current UTC day, dropping earlier days'.
+func (l *Limiter) todaysQuotas() map[string]int64 {
+	if day := l.now().UTC().Format("2006-01-02"); day != l.day {
+		l.day = day
+		l.quotas = make(map[string]int64)
+	}
+	return l.quotas
+}
@@ -0,0 +1,169 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter whose clock only moves when the test advances it.
+func newTestLimiter(t *testing.T, config Config) (*Limiter, *time.Time) {
+	limiter, err := New(config)
+	if err != nil {
+		t.Fatalf("Failed to create limiter: %v", err)
+	}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	return limiter, &now
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := []Config{
+		{},
+		{Mode: ModeThrottle, PerSource: Limit{Rate: 10, Burst: 20}},
+		{Sources: []SourceLimit{{Source: "ai-*", Limit: Limit{Rate: 1}, DailyQuota: 100}}},
+	}
+	for _, config := range valid {
+		if err := config.Validate(); err != nil {
+			t.Fatalf("Expected config %+v to be valid, got %v", config, err)
+		}
+	}
+
+	invalid := []Config{
+		{Mode: "drop"},
+		{PerClient: Limit{Rate: -1}},
+		{DailyQuota: -1},
+		{Sources: []SourceLimit{{Source: "[bad"}}},
+		{Sources: []SourceLimit{{Source: ""}}},
+	}
+	for _, config := range invalid {
+		if err := config.Validate(); err == nil {
+			t.Fatalf("Expected config %+v to be invalid", config)
+		}
+	}
+}
+
+func TestLimiter_Unlimited(t *testing.T) {
+	limiter, _ := newTestLimiter(t, Config{})
+	for i := 0; i < 1000; i++ {
+		if err := limiter.Allow("10.0.0.1", "scout"); err != nil {
+			t.Fatalf("Expected no limit, got %v", err)
+		}
+	}
+	if limiter.Mode() != ModeReject {
+		t.Fatalf("Expected reject mode by default, got %v", limiter.Mode())
+	}
+}
+
+func TestLimiter_SourceBucket(t *testing.T) {
+	limiter, now := newTestLimiter(t, Config{PerSource: Limit{Rate: 2, Burst: 3}})
+
+	for i := 0; i < 3; i++ {
+		if err := limiter.Allow("10.0.0.1", "scout"); err != nil {
+			t.Fatalf("Expected the burst to pass, got %v", err)
+		}
+	}
+	err := limiter.Allow("10.0.0.1", "scout")
+	limitErr, ok := err.(*Error)
+	if !ok || limitErr.Key != "scout" || limitErr.RetryAfter != 500*time.Millisecond {
+		t.Fatalf("Expected to retry after 500ms, got %v", err)
+	}
+
+	// Other sources have their own bucket
+	if err := limiter.Allow("10.0.0.1", "other"); err != nil {
+		t.Fatalf("Expected another source to pass, got %v", err)
+	}
+
+	*now = now.Add(500 * time.Millisecond)
+	if err := limiter.Allow("10.0.0.1", "scout"); err != nil {
+		t.Fatalf("Expected a refilled token, got %v", err)
+	}
+	if err := limiter.Allow("10.0.0.1", "scout"); err == nil {
+		t.Fatal("Expected the bucket to be empty again")
+	}
+}
+
+func TestLimiter_ClientBucket(t *testing.T) {
+	limiter, _ := newTestLimiter(t, Config{PerClient: Limit{Rate: 1}, PerSource: Limit{Rate: 5}})
+
+	if err := limiter.Allow("10.0.0.1", "a"); err != nil {
+		t.Fatalf("Expected the first claim to pass, got %v", err)
+	}
+	err := limiter.Allow("10.0.0.1", "b")
+	if limitErr, ok := err.(*Error); !ok || limitErr.Key != "10.0.0.1" {
+		t.Fatalf("Expected the client limit to apply across sources, got %v", err)
+	}
+	if err := limiter.Allow("10.0.0.2", "b"); err != nil {
+		t.Fatalf("Expected another client to pass, got %v", err)
+	}
+
+	// A refused claim takes no token from the source bucket
+	for i := 0; i < 4; i++ {
+		if err := limiter.Allow(fmt.Sprintf("10.0.1.%d", i), "b"); err != nil {
+			t.Fatalf("Expected source b to have tokens left, got %v", err)
+		}
+	}
+}
+
+func TestLimiter_SourceOverrides(t *testing.T) {
+	limiter, _ := newTestLimiter(t, Config{
+		PerSource: Limit{Rate: 100},
+		Sources:   []SourceLimit{{Source: "ai-*", Limit: Limit{Rate: 1}}},
+	})
+
+	limiter.Allow("c", "ai-scout")
+	if err := limiter.Allow("c", "ai-scout"); err == nil {
+		t.Fatal("Expected the override to limit ai-scout")
+	}
+	limiter.Allow("c", "prometheus")
+	if err := limiter.Allow("c", "prometheus"); err != nil {
+		t.Fatalf("Expected the default limit for prometheus, got %v", err)
+	}
+}
+
+func TestLimiter_DailyQuota(t *testing.T) {
+	limiter, now := newTestLimiter(t, Config{
+		DailyQuota: 2,
+		Sources:    []SourceLimit{{Source: "cmdb", DailyQuota: 0}},
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.CheckQuota("scout"); err != nil {
+			t.Fatalf("Expected quota left, got %v", err)
+		}
+		limiter.CountAccepted("scout")
+	}
+	err := limiter.CheckQuota("scout")
+	if limitErr, ok := err.(*Error); !ok || !limitErr.Quota {
+		t.Fatalf("Expected the quota to be exhausted, got %v", err)
+	}
+
+	limiter.CountAccepted("cmdb")
+	limiter.CountAccepted("cmdb")
+	limiter.CountAccepted("cmdb")
+	if err := limiter.CheckQuota("cmdb"); err != nil {
+		t.Fatalf("Expected cmdb to be unlimited, got %v", err)
+	}
+
+	// The quota resets with the UTC day
+	*now = now.Add(12 * time.Hour)
+	if err := limiter.CheckQuota("scout"); err != nil {
+		t.Fatalf("Expected a fresh quota the next day, got %v", err)
+	}
+	if len(limiter.quotas) != 0 {
+		t.Fatalf("Expected the previous day's counts to be dropped, %d left", len(limiter.quotas))
+	}
+}
+
+func TestLimiter_PrunesIdleBuckets(t *testing.T) {
+	limiter, now := newTestLimiter(t, Config{PerSource: Limit{Rate: 1}})
+
+	for i := 0; i < maxIdleBuckets; i++ {
+		limiter.Allow("c", fmt.Sprintf("source-%d", i))
+	}
+	*now = now.Add(time.Second)
+	limiter.Allow("c", "new-source")
+	if len(limiter.sources) != 1 {
+		t.Fatalf("Expected refilled buckets to be dropped, %d left", len(limiter.sources))
+	}
+}
@@ -0,0 +1,74 @@
+package reconciliation
+
+import (
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// Conflict records a claim from another agent that was made concurrently with a
+// different value held here: neither agent had seen the other's update when it was
+// made. The engine still resolves the pair with its usual deterministic rules.
+type Conflict struct {
+	SPID         string
+	Subject      string
+	Predicate    string
+	Local        *core.Kpak         // Truth held here when the concurrent claim arrived
+	Remote       *core.Kpak         // The concurrent claim, carrying its own version vector
+	Winner       *core.Kpak         // Truth after reconciliation
+	LocalVersion core.VersionVector // Version of the fact here when the claim arrived
+	DetectedAt   time.Time
+}
+
+// trackVersion advances the version vector of a fact after a claim was reconciled.
+// A claim made on this agent (one without a version) is stamped with the fact's
+// next version if it was kept. A claim from another agent is merged into the
+// fact's version, and recorded as a conflict if it was made concurrently with a
+// different value than the one held here.
+func (e *Engine) trackVersion(key string, kpak, previous *core.Kpak, known core.VersionVector, outcome Outcome) {
+	if kpak.Version == nil {
+		if outcome != OutcomeRejected {
+			kpak.Version = known.Increment(e.actor)
+			e.versions[key] = kpak.Version
+		}
+		return
+	}
+
+	if previous != nil && previous.ObjectKey() != kpak.ObjectKey() && kpak.Version.Compare(known) == core.Concurrent {
+		e.recordConflict(Conflict{
+			SPID:         kpak.SPID,
+			Subject:      kpak.Subject,
+			Predicate:    kpak.Predicate,
+			Local:        previous,
+			Remote:       kpak,
+			Winner:       e.truthStore[key],
+			LocalVersion: known,
+			DetectedAt:   time.Now(),
+		})
+	}
+	e.versions[key] = known.Merge(kpak.Version)
+}
+
+// recordConflict keeps a conflict, dropping the oldest once the limit is reached.
+func (e *Engine) recordConflict(conflict Conflict) {
+	e.conflicts = append(e.conflicts, conflict)
+	if len(e.conflicts) > e.maxConflicts {
+		e.conflicts = e.conflicts[len(e.conflicts)-e.maxConflicts:]
+	}
+}
+
+// GetConflicts returns recorded conflicts, newest first. An empty subject or
+// predicate matches every subject or predicate.
+func (e *Engine) GetConflicts(subject, predicate string) []Conflict {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	var results []Conflict
+	for i := len(e.conflicts) - 1; i >= 0; i-- {
+		conflict := e.conflicts[i]
+		if (subject == "" || conflict.Subject == subject) && (predicate == "" || conflict.Predicate == predicate) {
+			results = append(results, conflict)
+		}
+	}
+	return results
+}
@@ -0,0 +1,111 @@
+package reconciliation
+
+import (
+	"testing"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// gossiped copies a claim as it arrives at another agent.
+func gossiped(kpak *core.Kpak) *core.Kpak {
+	copied := *kpak
+	copied.Version = kpak.Version.Clone()
+	return &copied
+}
+
+func TestVersion_LocalClaimsAreStamped(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Actor: "agent-a"})
+
+	first := core.NewKpak("svc-a", "owner", "alice", "hr", 0.8)
+	second := core.NewKpak("svc-a", "owner", "bob", "hr", 0.9)
+	engine.Reconcile(first)
+	engine.Reconcile(second)
+
+	if first.Version.String() != "{agent-a:1}" || second.Version.String() != "{agent-a:2}" {
+		t.Fatalf("Unexpected versions %s and %s", first.Version, second.Version)
+	}
+
+	if engine.ReconcileOutcome(second) != OutcomeRejected || second.Version.String() != "{agent-a:2}" {
+		t.Fatal("A duplicate claim should be dropped without being stamped again")
+	}
+}
+
+func TestVersion_SupersedingClaimIsNotAConflict(t *testing.T) {
+	a := NewEngineWithConfig(Config{Actor: "agent-a"})
+	b := NewEngineWithConfig(Config{Actor: "agent-b"})
+
+	first := core.NewKpak("svc-a", "owner", "alice", "hr", 0.8)
+	a.Reconcile(first)
+	b.Reconcile(gossiped(first))
+
+	// B has seen A's claim, so its update supersedes it
+	update := core.NewKpak("svc-a", "owner", "bob", "directory", 0.9)
+	b.Reconcile(update)
+	if update.Version.String() != "{agent-a:1, agent-b:1}" {
+		t.Fatalf("Unexpected version %s", update.Version)
+	}
+
+	a.Reconcile(gossiped(update))
+	if conflicts := a.GetConflicts("", ""); len(conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %d", len(conflicts))
+	}
+}
+
+func TestVersion_ConcurrentClaimsAreRecorded(t *testing.T) {
+	a := NewEngineWithConfig(Config{Actor: "agent-a"})
+	b := NewEngineWithConfig(Config{Actor: "agent-b"})
+
+	// Two scouts update the same fact on different agents without seeing each other
+	fromA := core.NewKpak("svc-a", "owner", "alice", "hr", 0.8)
+	fromB := core.NewKpak("svc-a", "owner", "bob", "directory", 0.9)
+	a.Reconcile(fromA)
+	b.Reconcile(fromB)
+
+	a.Reconcile(gossiped(fromB))
+	b.Reconcile(gossiped(fromA))
+
+	for _, engine := range []*Engine{a, b} {
+		conflicts := engine.GetConflicts("svc-a", "owner")
+		if len(conflicts) != 1 {
+			t.Fatalf("Expected 1 conflict, got %d", len(conflicts))
+		}
+		if winner := conflicts[0].Winner; winner == nil || winner.Object != "bob" {
+			t.Fatalf("Expected the deterministic winner bob, got %+v", winner)
+		}
+		if truth := engine.QueryBySubjectPredicate("svc-a", "owner"); truth.Object != "bob" {
+			t.Fatalf("Expected both agents to converge on bob, got %v", truth.Object)
+		}
+	}
+
+	// Agreeing concurrent claims are not a conflict
+	c := NewEngineWithConfig(Config{Actor: "agent-c"})
+	c.Reconcile(core.NewKpak("svc-b", "owner", "alice", "hr", 0.8))
+	agreeing := core.NewKpak("svc-b", "owner", "alice", "directory", 0.7)
+	agreeing.Version = core.VersionVector{"agent-d": 1}
+	c.Reconcile(agreeing)
+	if len(c.GetConflicts("svc-b", "")) != 0 {
+		t.Fatal("Claims for the same value should not be recorded as a conflict")
+	}
+}
+
+func TestVersion_ConflictsAreBounded(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Actor: "agent-a", MaxConflicts: 2})
+	engine.Reconcile(core.NewKpak("svc-a", "owner", "alice", "hr", 0.8))
+
+	for i, name := range []string{"bob", "carol", "dave"} {
+		remote := core.NewKpak("svc-a", "owner", name, "directory", 0.5)
+		remote.Version = core.VersionVector{"agent-b": uint64(i + 1)}
+		engine.Reconcile(remote)
+	}
+
+	conflicts := engine.GetConflicts("", "")
+	if len(conflicts) != 2 {
+		t.Fatalf("Expected 2 conflicts kept, got %d", len(conflicts))
+	}
+	if conflicts[0].Remote.Object != "dave" || conflicts[1].Remote.Object != "carol" {
+		t.Fatalf("Expected the newest conflicts first, got %v and %v", conflicts[0].Remote.Object, conflicts[1].Remote.Object)
+	}
+	if engine.GetStats()["total_conflicts"] != 2 {
+		t.Fatal("Expected stats to count kept conflicts")
+	}
+}
@@ -0,0 +1,162 @@
+package reconciliation
+
+import (
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// DampingRule holds back a new value for every predicate matching Pattern until it
+// has kept winning reconciliation for DwellSeconds and has been confirmed by
+// Confirmations claims; whichever of the two is set must be met. Patterns use
+// path.Match syntax and the first matching rule wins.
+type DampingRule struct {
+	Pattern       string `yaml:"pattern"`
+	DwellSeconds  int64  `yaml:"dwell_seconds"` // Time the new value must keep winning
+	Confirmations int    `yaml:"confirmations"` // Claims for the new value needed, the first included
+}
+
+// Validate checks that the rule's pattern and parameters make sense.
+func (r DampingRule) Validate() error {
+	if _, err := path.Match(r.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid damping pattern %q: %w", r.Pattern, err)
+	}
+	if r.DwellSeconds < 0 || r.Confirmations < 0 {
+		return fmt.Errorf("damping rule %q: dwell_seconds and confirmations must not be negative", r.Pattern)
+	}
+	if r.DwellSeconds == 0 && r.Confirmations == 0 {
+		return fmt.Errorf("damping rule %q: needs dwell_seconds or confirmations", r.Pattern)
+	}
+	return nil
+}
+
+// satisfied reports whether a pending value has earned its place as the truth.
+func (r DampingRule) satisfied(p *Pending, now time.Time) bool {
+	if r.DwellSeconds > 0 && now.Sub(p.Since) < time.Duration(r.DwellSeconds)*time.Second {
+		return false
+	}
+	return r.Confirmations <= 0 || p.Confirmations >= r.Confirmations
+}
+
+// Pending is a claim that outranks the truth of a damped predicate but has not yet
+// kept winning long enough to replace it.
+type Pending struct {
+	Kpak          *core.Kpak // Best claim for the pending value
+	Since         time.Time  // When this agent first saw the value winning
+	Confirmations int        // Claims for the value seen since then
+
+	timer *time.Timer
+}
+
+func (e *Engine) dampingRuleFor(predicate string) *DampingRule {
+	for i := range e.damping {
+		if matched, _ := path.Match(e.damping[i].Pattern, predicate); matched {
+			return &e.damping[i]
+		}
+	}
+	return nil
+}
+
+// reconcileDamped ranks a claim for a predicate with a damping rule. The best
+// claim only replaces the current truth once settle lets it.
+func (e *Engine) reconcileDamped(key string, kpak, existing *core.Kpak, ranked []*core.Kpak, rule *DampingRule, now time.Time) (Outcome, *TruthChange) {
+	updated := e.insertCandidate(ranked, kpak, existing, now)
+	e.candidates[key] = updated
+	change := e.settle(key, e.pickWinner(updated, now), existing, kpak, rule, now)
+
+	truth := e.truthStore[key]
+	switch {
+	case !containsKpak(updated, kpak):
+		return OutcomeRejected, change
+	case truth == kpak, e.modeFor(kpak.Predicate) == ModeFusion && truth.ObjectKey() == kpak.ObjectKey():
+		return OutcomeAccepted, change
+	default:
+		return OutcomeCandidate, change
+	}
+}
+
+// settle installs winner as the truth of a damped key if its value is the truth's
+// value or has kept winning long enough, and otherwise records it as pending.
+// trigger is the claim being reconciled, nil on re-evaluation.
+// Returns nil when the truth is unchanged.
+func (e *Engine) settle(key string, winner, existing, trigger *core.Kpak, rule *DampingRule, now time.Time) *TruthChange {
+	if winner == existing {
+		// The truth won again, so any pending value has to start over
+		delete(e.pending, key)
+		return nil
+	}
+
+	if winner.ObjectKey() != existing.ObjectKey() {
+		p, exists := e.pending[key]
+		if !exists || p.Kpak.ObjectKey() != winner.ObjectKey() {
+			p = &Pending{Since: now}
+			e.pending[key] = p
+		}
+		p.Kpak = winner
+		// Dwell is measured on this agent's clock; claim timestamps come from clients
+		// and a backdated one must not skip the wait
+		if trigger != nil && trigger.ObjectKey() == winner.ObjectKey() {
+			p.Confirmations++
+		}
+
+		if !rule.satisfied(p, now) {
+			e.scheduleSettle(key, p, rule, now)
+			return nil
+		}
+	}
+
+	e.acceptKpak(winner)
+	return &TruthChange{Type: ChangeAccepted, SPID: winner.SPID, Previous: existing, Current: winner}
+}
+
+// scheduleSettle re-evaluates a pending value once its dwell time is up, so it
+// takes over even if no further claim arrives.
+func (e *Engine) scheduleSettle(key string, p *Pending, rule *DampingRule, now time.Time) {
+	if rule.DwellSeconds <= 0 || p.timer != nil {
+		return
+	}
+	wait := p.Since.Add(time.Duration(rule.DwellSeconds) * time.Second).Sub(now)
+	p.timer = time.AfterFunc(wait, func() {
+		e.settleDue(key, p)
+	})
+}
+
+// settleDue re-evaluates a pending value whose dwell time is up.
+func (e *Engine) settleDue(key string, p *Pending) {
+	e.mutex.Lock()
+
+	var change *TruthChange
+	existing, exists := e.truthStore[key]
+	if e.pending[key] == p && exists && len(e.candidates[key]) > 0 {
+		if rule := e.dampingRuleFor(existing.Predicate); rule != nil {
+			now := time.Now()
+			change = e.settle(key, e.pickWinner(e.candidates[key], now), existing, nil, rule, now)
+		}
+	}
+
+	handler := e.onChange
+	e.mutex.Unlock()
+
+	if change != nil && handler != nil {
+		handler(*change)
+	}
+}
+
+// GetPending returns the values waiting to replace the truth of a subject's damped
+// predicates. An empty predicate matches every predicate.
+func (e *Engine) GetPending(subject, predicate string) []Pending {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	var results []Pending
+	for key := range e.subjectIndex[subject] {
+		p, exists := e.pending[key]
+		if !exists || (predicate != "" && p.Kpak.Predicate != predicate) {
+			continue
+		}
+		results = append(results, Pending{Kpak: p.Kpak, Since: p.Since, Confirmations: p.Confirmations})
+	}
+	return results
+}
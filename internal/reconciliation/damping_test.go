@@ -0,0 +1,138 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func TestDampingRule_Validate(t *testing.T) {
+	valid := []DampingRule{
+		{Pattern: "health_*", DwellSeconds: 60},
+		{Pattern: "status", Confirmations: 3},
+		{Pattern: "*", DwellSeconds: 30, Confirmations: 2},
+	}
+	for _, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Fatalf("Expected rule %+v to be valid, got %v", rule, err)
+		}
+	}
+
+	invalid := []DampingRule{
+		{Pattern: "status"},
+		{Pattern: "[bad", DwellSeconds: 1},
+		{Pattern: "status", DwellSeconds: -1},
+		{Pattern: "status", Confirmations: -1, DwellSeconds: 1},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Fatalf("Expected rule %+v to be invalid", rule)
+		}
+	}
+}
+
+func TestDamping_NeedsConfirmations(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Damping: []DampingRule{{Pattern: "health", Confirmations: 3}}})
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	engine.Reconcile(core.NewKpak("server1", "health", "up", "probe-a", 0.8))
+
+	if outcome := engine.ReconcileOutcome(core.NewKpak("server1", "health", "down", "probe-b", 0.9)); outcome != OutcomeCandidate {
+		t.Fatalf("Expected the new value to be held back, got outcome %v", outcome)
+	}
+	engine.Reconcile(core.NewKpak("server1", "health", "down", "probe-c", 0.9))
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "up" {
+		t.Fatalf("Expected the truth to hold, got %v", truth.Object)
+	}
+	pending := engine.GetPending("server1", "")
+	if len(pending) != 1 || pending[0].Kpak.Object != "down" || pending[0].Confirmations != 2 {
+		t.Fatalf("Unexpected pending values %+v", pending)
+	}
+
+	// The truth winning again resets the count
+	engine.Reconcile(core.NewKpak("server1", "health", "up", "probe-d", 0.95))
+	if len(engine.GetPending("server1", "health")) != 0 {
+		t.Fatal("Expected the pending value to be dropped once the truth won again")
+	}
+
+	for i, source := range []string{"probe-b", "probe-c", "probe-e"} {
+		engine.Reconcile(core.NewKpak("server1", "health", "down", source, 0.96+0.01*float32(i)))
+	}
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "down" {
+		t.Fatalf("Expected the third confirmation to switch the truth, got %v", truth.Object)
+	}
+	if last := changes[len(changes)-1]; last.Type != ChangeAccepted || last.Previous.Object != "up" {
+		t.Fatalf("Unexpected change %+v", last)
+	}
+	if len(engine.GetPending("server1", "")) != 0 {
+		t.Fatal("Expected no pending value after the switch")
+	}
+}
+
+func TestDamping_DwellTime(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Damping: []DampingRule{{Pattern: "status", DwellSeconds: 1}}})
+
+	accepted := make(chan TruthChange, 1)
+	engine.SetChangeHandler(func(change TruthChange) {
+		accepted <- change
+	})
+
+	engine.ReconcileOutcome(core.NewKpak("server1", "status", "up", "probe-a", 0.8))
+	<-accepted
+
+	engine.Reconcile(core.NewKpak("server1", "status", "down", "probe-b", 0.9))
+	if truth := engine.QueryBySubjectPredicate("server1", "status"); truth.Object != "up" {
+		t.Fatalf("Expected the truth to hold during the dwell time, got %v", truth.Object)
+	}
+
+	// The pending value takes over once the dwell time is up, without another claim
+	select {
+	case change := <-accepted:
+		if change.Current.Object != "down" {
+			t.Fatalf("Unexpected change %+v", change)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Expected the pending value to take over after the dwell time")
+	}
+	if truth := engine.QueryBySubjectPredicate("server1", "status"); truth.Object != "down" {
+		t.Fatalf("Expected down after the dwell time, got %v", truth.Object)
+	}
+}
+
+func TestDamping_SuppressesFlapping(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Damping: []DampingRule{{Pattern: "status", DwellSeconds: 60}}})
+
+	engine.Reconcile(core.NewKpak("server1", "status", "up", "probe", 0.8))
+	for i, value := range []string{"down", "up", "down", "up", "down"} {
+		engine.Reconcile(core.NewKpak("server1", "status", value, "probe", 0.8+0.01*float32(i+1)))
+		if truth := engine.QueryBySubjectPredicate("server1", "status"); truth.Object != "up" {
+			t.Fatalf("Expected the truth to stay up while the value flaps, got %v", truth.Object)
+		}
+	}
+}
+
+func TestDamping_BackdatedClaimsStillDwell(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Damping: []DampingRule{{Pattern: "status", DwellSeconds: 60}}})
+
+	up := core.NewKpak("server1", "status", "up", "probe-a", 0.8)
+	up.Timestamp -= 300
+	up.RegenerateComputedFields()
+	engine.Reconcile(up)
+
+	// Claim timestamps are the client's; the dwell starts when the value first wins here
+	down := core.NewKpak("server1", "status", "down", "probe-b", 0.9)
+	down.Timestamp -= 120
+	down.RegenerateComputedFields()
+	if engine.Reconcile(down) {
+		t.Fatal("Expected a backdated claim to wait out the dwell time")
+	}
+	pending := engine.GetPending("server1", "status")
+	if len(pending) != 1 || time.Since(pending[0].Since) > time.Second {
+		t.Fatalf("Expected the dwell to start now, got %+v", pending)
+	}
+}
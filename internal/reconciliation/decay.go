@@ -0,0 +1,111 @@
+package reconciliation
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// DecayFunction names how a claim's confidence drops as it ages.
+type DecayFunction string
+
+const (
+	DecayNone        DecayFunction = "none"        // Confidence never changes
+	DecayLinear      DecayFunction = "linear"      // Falls linearly to zero over LifetimeSeconds
+	DecayExponential DecayFunction = "exponential" // Halves every HalfLifeSeconds
+	DecayStep        DecayFunction = "step"        // Multiplied by StepFactor every StepSeconds
+)
+
+// DecayRule applies a decay function to every predicate matching Pattern.
+// Patterns use path.Match syntax ("status", "health_*"). The first matching rule wins.
+type DecayRule struct {
+	Pattern         string        `yaml:"pattern"`
+	Function        DecayFunction `yaml:"function"`
+	LifetimeSeconds int64         `yaml:"lifetime_seconds"`  // linear
+	HalfLifeSeconds int64         `yaml:"half_life_seconds"` // exponential
+	StepSeconds     int64         `yaml:"step_seconds"`      // step
+	StepFactor      float64       `yaml:"step_factor"`       // step
+}
+
+// Validate checks that the rule's pattern and parameters make sense.
+func (r DecayRule) Validate() error {
+	if _, err := path.Match(r.Pattern, ""); err != nil {
+		return fmt.Errorf("invalid decay pattern %q: %w", r.Pattern, err)
+	}
+
+	switch r.Function {
+	case DecayNone:
+	case DecayLinear:
+		if r.LifetimeSeconds <= 0 {
+			return fmt.Errorf("decay rule %q: linear decay needs lifetime_seconds > 0", r.Pattern)
+		}
+	case DecayExponential:
+		if r.HalfLifeSeconds <= 0 {
+			return fmt.Errorf("decay rule %q: exponential decay needs half_life_seconds > 0", r.Pattern)
+		}
+	case DecayStep:
+		if r.StepSeconds <= 0 || r.StepFactor < 0 || r.StepFactor > 1 {
+			return fmt.Errorf("decay rule %q: step decay needs step_seconds > 0 and step_factor in [0, 1]", r.Pattern)
+		}
+	default:
+		return fmt.Errorf("decay rule %q: unknown function %q", r.Pattern, r.Function)
+	}
+
+	return nil
+}
+
+// factor returns the multiplier applied to a claim's confidence after ageSeconds.
+func (r DecayRule) factor(ageSeconds float64) float64 {
+	switch r.Function {
+	case DecayLinear:
+		return math.Max(0, 1-ageSeconds/float64(r.LifetimeSeconds))
+	case DecayExponential:
+		return math.Pow(0.5, ageSeconds/float64(r.HalfLifeSeconds))
+	case DecayStep:
+		return math.Pow(r.StepFactor, math.Floor(ageSeconds/float64(r.StepSeconds)))
+	default:
+		return 1
+	}
+}
+
+// EffectiveConfidence returns the k-pak's confidence after applying the engine's
+// decay rules for its predicate at the given time. CRDT values do not decay.
+func (e *Engine) EffectiveConfidence(kpak *core.Kpak, now time.Time) float32 {
+	rule := e.decayRuleFor(kpak.Predicate)
+	if rule == nil || e.crdtFor(kpak.Predicate) != "" {
+		return kpak.Confidence
+	}
+
+	age := float64(now.Unix() - kpak.Timestamp)
+	if age < 0 {
+		age = 0
+	}
+	return float32(float64(kpak.Confidence) * rule.factor(age))
+}
+
+func (e *Engine) decayRuleFor(predicate string) *DecayRule {
+	for i := range e.decay {
+		if matched, _ := path.Match(e.decay[i].Pattern, predicate); matched {
+			return &e.decay[i]
+		}
+	}
+	return nil
+}
+
+// isDecayed reports whether a claim has decayed to nothing and can be dropped.
+func (e *Engine) isDecayed(kpak *core.Kpak, now time.Time) bool {
+	return kpak.Confidence > 0 && e.EffectiveConfidence(kpak, now) <= 0
+}
+
+// moreTrusted is IsMoreTrustedThan evaluated on effective confidence:
+// higher effective confidence wins, the more recent claim breaks ties.
+func (e *Engine) moreTrusted(a, b *core.Kpak, now time.Time) bool {
+	ea, eb := e.EffectiveConfidence(a, now), e.EffectiveConfidence(b, now)
+	if ea != eb {
+		return ea > eb
+	}
+	return a.Timestamp > b.Timestamp
+}
@@ -0,0 +1,124 @@
+package reconciliation
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// agedKpak creates a k-pak whose timestamp lies ageSeconds in the past.
+func agedKpak(subject, predicate string, object interface{}, source string, confidence float32, ageSeconds int64) *core.Kpak {
+	kpak := core.NewKpak(subject, predicate, object, source, confidence)
+	kpak.Timestamp -= ageSeconds
+	kpak.RegenerateComputedFields()
+	return kpak
+}
+
+func TestDecayRule_Validate(t *testing.T) {
+	testCases := []struct {
+		rule    DecayRule
+		wantErr bool
+	}{
+		{DecayRule{Pattern: "status", Function: DecayNone}, false},
+		{DecayRule{Pattern: "status", Function: DecayLinear, LifetimeSeconds: 60}, false},
+		{DecayRule{Pattern: "status", Function: DecayLinear}, true},
+		{DecayRule{Pattern: "health_*", Function: DecayExponential, HalfLifeSeconds: 60}, false},
+		{DecayRule{Pattern: "health_*", Function: DecayExponential}, true},
+		{DecayRule{Pattern: "status", Function: DecayStep, StepSeconds: 60, StepFactor: 0.5}, false},
+		{DecayRule{Pattern: "status", Function: DecayStep, StepSeconds: 60, StepFactor: 2}, true},
+		{DecayRule{Pattern: "status", Function: "cubic"}, true},
+		{DecayRule{Pattern: "[", Function: DecayNone}, true},
+	}
+
+	for i, tc := range testCases {
+		err := tc.rule.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("Test case %d: Validate() error = %v, wantErr %v", i, err, tc.wantErr)
+		}
+	}
+}
+
+func TestEffectiveConfidence(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Decay: []DecayRule{
+		{Pattern: "linear", Function: DecayLinear, LifetimeSeconds: 100},
+		{Pattern: "exp_*", Function: DecayExponential, HalfLifeSeconds: 100},
+		{Pattern: "step", Function: DecayStep, StepSeconds: 100, StepFactor: 0.5},
+	}})
+
+	now := time.Now()
+	testCases := []struct {
+		predicate string
+		age       int64
+		expected  float64
+	}{
+		{"linear", 0, 0.8},
+		{"linear", 50, 0.4},
+		{"linear", 200, 0},
+		{"exp_latency", 100, 0.4},
+		{"exp_latency", 200, 0.2},
+		{"step", 99, 0.8},
+		{"step", 250, 0.2},
+		{"other", 10000, 0.8},
+	}
+
+	for _, tc := range testCases {
+		kpak := core.NewKpak("svc", tc.predicate, "x", "source", 0.8)
+		kpak.Timestamp = now.Unix() - tc.age
+
+		got := engine.EffectiveConfidence(kpak, now)
+		if math.Abs(float64(got)-tc.expected) > 1e-4 {
+			t.Fatalf("%s at age %d: expected %.4f, got %.4f", tc.predicate, tc.age, tc.expected, got)
+		}
+	}
+}
+
+func TestDecay_FreshClaimBeatsStaleClaim(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Decay: []DecayRule{
+		{Pattern: "status", Function: DecayExponential, HalfLifeSeconds: 3600},
+	}})
+
+	stale := agedKpak("db-1", "status", "up", "MonitorA", 0.99, 30*24*3600)
+	engine.Reconcile(stale)
+
+	fresh := core.NewKpak("db-1", "status", "down", "MonitorB", 0.9)
+	if !engine.Reconcile(fresh) {
+		t.Fatal("Fresh claim should beat a month-old claim once decay applies")
+	}
+
+	// Without decay the stale claim would still win
+	plain := NewEngine()
+	plain.Reconcile(stale)
+	if plain.Reconcile(fresh) {
+		t.Fatal("Without decay the higher raw confidence should win")
+	}
+}
+
+func TestDecay_RemoveExpiredDropsFullyDecayedClaims(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Decay: []DecayRule{
+		{Pattern: "status", Function: DecayLinear, LifetimeSeconds: 60},
+	}})
+
+	engine.Reconcile(agedKpak("db-1", "status", "up", "MonitorA", 0.9, 120))
+
+	if removed := engine.RemoveExpiredKpaks(); removed != 1 {
+		t.Fatalf("Expected 1 fully decayed claim removed, got %d", removed)
+	}
+	if engine.QueryBySubjectPredicate("db-1", "status") != nil {
+		t.Fatal("Fully decayed claim should no longer be the truth")
+	}
+}
+
+func TestDecay_EvidenceUsesEffectiveConfidence(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Decay: []DecayRule{
+		{Pattern: "status", Function: DecayLinear, LifetimeSeconds: 100},
+	}})
+
+	engine.Reconcile(agedKpak("db-1", "status", "up", "MonitorA", 0.8, 50))
+
+	evidence := engine.GetEvidence("db-1", "status")
+	if math.Abs(float64(evidence.Confidence)-0.4) > 0.02 {
+		t.Fatalf("Expected evidence confidence around 0.4, got %.4f", evidence.Confidence)
+	}
+}
@@ -1,81 +1,744 @@
 package reconciliation
 
 import (
+	"context"
+	"fmt"
+	"sort"
 	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/tracing"
+)
+
+// DefaultMaxCandidates is the number of ranked claims kept per SPID when no limit is configured.
+const DefaultMaxCandidates = 5
+
+// maxEvidence bounds the claims kept per SPID in fusion mode, where every source's
+// claim is evidence rather than a runner-up.
+const maxEvidence = 256
+
+// DefaultMaxConflicts is the number of recent conflicts kept when no limit is configured.
+const DefaultMaxConflicts = 1000
+
+// Mode selects how the engine turns competing claims into a single truth.
+type Mode string
+
+const (
+	// ModeHighestConfidence picks the single most trusted claim (confidence, then recency).
+	ModeHighestConfidence Mode = "highest_confidence"
+	// ModeFusion combines independent claims per value with noisy-OR and picks the value
+	// with the highest combined belief.
+	ModeFusion Mode = "fusion"
 )
 
+// ParseMode validates a configured mode name. An empty name selects ModeHighestConfidence.
+func ParseMode(name string) (Mode, error) {
+	switch Mode(name) {
+	case "", ModeHighestConfidence:
+		return ModeHighestConfidence, nil
+	case ModeFusion:
+		return ModeFusion, nil
+	default:
+		return "", fmt.Errorf("unknown reconciliation mode %q", name)
+	}
+}
+
+// Config holds reconciliation engine settings.
+type Config struct {
+	MaxCandidates int           // Ranked claims kept per SPID, including the winner (0 = DefaultMaxCandidates); fusion keeps one per source as evidence
+	Mode          Mode          // How competing claims are resolved ("" = ModeHighestConfidence)
+	Decay         []DecayRule   // Per-predicate confidence decay, first match wins
+	Actor         string        // Identifies this agent in CRDT state and version vectors ("" = "local")
+	MaxConflicts  int           // Recent concurrent-update conflicts kept (0 = DefaultMaxConflicts)
+	Damping       []DampingRule // Per-predicate hysteresis for new values, first match wins
+}
+
+// Evidence summarizes the claims backing the current truth for an SPID.
+type Evidence struct {
+	Confidence float32  // Combined confidence of the winning value
+	Sources    []string // Sources whose claims support the winning value
+}
+
+// Outcome describes what the engine did with a reconciled k-pak.
+type Outcome int
+
+const (
+	// OutcomeRejected means the k-pak was dropped (duplicate or outranked by every kept claim).
+	OutcomeRejected Outcome = iota
+	// OutcomeAccepted means the k-pak became the current truth for its SPID.
+	OutcomeAccepted
+	// OutcomeCandidate means the k-pak was kept as a runner-up behind the current truth.
+	OutcomeCandidate
+)
+
+// String returns the outcome's name.
+func (o Outcome) String() string {
+	switch o {
+	case OutcomeAccepted:
+		return "accepted"
+	case OutcomeCandidate:
+		return "candidate"
+	}
+	return "rejected"
+}
+
+// ChangeType identifies why the accepted truth for an SPID changed.
+type ChangeType string
+
+const (
+	ChangeAccepted  ChangeType = "accepted"  // A new claim won reconciliation
+	ChangePromoted  ChangeType = "promoted"  // A runner-up replaced an expired, retracted or decayed winner
+	ChangeExpired   ChangeType = "expired"   // The winner expired and no runner-up was left
+	ChangeRetracted ChangeType = "retracted" // The winner was retracted and no runner-up was left
+	ChangePinned    ChangeType = "pinned"    // An operator pin now overrides the truth
+	ChangeUnpinned  ChangeType = "unpinned"  // A pin was lifted or expired; the reconciled truth (if any) shows again
+)
+
+// TruthChange is emitted whenever the accepted truth for an SPID changes.
+type TruthChange struct {
+	Type     ChangeType
+	SPID     string
+	Previous *core.Kpak // Truth before the change (nil for brand new facts)
+	Current  *core.Kpak // Truth after the change (nil when the fact is gone)
+}
+
 // Engine is the "brain" that decides what is true.
 // It manages the reconciliation logic and maintains the current state of truth.
 type Engine struct {
-	// truthStore holds the currently accepted truths, indexed by SPID
+	// truthStore holds the currently accepted truths, indexed by key (see keyFor)
 	truthStore map[string]*core.Kpak
+	// candidates holds the claims kept per key, ranked by their own trust, best first
+	candidates map[string][]*core.Kpak
 	// subjectIndex allows fast lookup by subject
-	subjectIndex map[string]map[string]struct{} // subject -> set of SPIDs
-	mutex        sync.RWMutex
+	subjectIndex map[string]map[string]struct{} // subject -> set of keys
+	// crdts holds the replicated state of CRDT predicates, indexed by SPID
+	crdts map[string]*crdt.State
+	// versions holds the version vector of each fact, indexed by key
+	versions map[string]core.VersionVector
+	// pins holds operator overrides, indexed by SPID
+	pins map[string]*Pin
+	// pending holds the values waiting out their damping rule, indexed by key
+	pending map[string]*Pending
+	// conflicts holds the most recent concurrent updates, oldest first
+	conflicts []Conflict
+	mutex     sync.RWMutex
+
+	maxCandidates int
+	mode          Mode
+	decay         []DecayRule
+	damping       []DampingRule
+	modeResolver  func(predicate string) Mode
+	multiValued   func(predicate string) bool
+	crdtType      func(predicate string) crdt.Type
+	actor         string
+	maxConflicts  int
+	onChange      func(TruthChange)
 }
 
-// NewEngine creates a new reconciliation engine.
+// NewEngine creates a new reconciliation engine with default settings.
 func NewEngine() *Engine {
+	return NewEngineWithConfig(Config{})
+}
+
+// NewEngineWithConfig creates a new reconciliation engine.
+func NewEngineWithConfig(config Config) *Engine {
+	if config.MaxCandidates <= 0 {
+		config.MaxCandidates = DefaultMaxCandidates
+	}
+	if config.Mode == "" {
+		config.Mode = ModeHighestConfidence
+	}
+	if config.Actor == "" {
+		config.Actor = "local"
+	}
+	if config.MaxConflicts <= 0 {
+		config.MaxConflicts = DefaultMaxConflicts
+	}
+
 	return &Engine{
-		truthStore:   make(map[string]*core.Kpak),
-		subjectIndex: make(map[string]map[string]struct{}),
+		truthStore:    make(map[string]*core.Kpak),
+		candidates:    make(map[string][]*core.Kpak),
+		subjectIndex:  make(map[string]map[string]struct{}),
+		crdts:         make(map[string]*crdt.State),
+		versions:      make(map[string]core.VersionVector),
+		pending:       make(map[string]*Pending),
+		pins:          make(map[string]*Pin),
+		maxCandidates: config.MaxCandidates,
+		mode:          config.Mode,
+		decay:         config.Decay,
+		damping:       config.Damping,
+		actor:         config.Actor,
+		maxConflicts:  config.MaxConflicts,
+	}
+}
+
+// SetChangeHandler sets the callback invoked after the accepted truth for an SPID changes.
+// The handler is called without the engine lock held.
+func (e *Engine) SetChangeHandler(handler func(TruthChange)) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.onChange = handler
+}
+
+// SetModeResolver sets a lookup for predicates that override the engine's mode.
+// The resolver returns "" for predicates that use the default.
+func (e *Engine) SetModeResolver(resolver func(predicate string) Mode) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.modeResolver = resolver
+}
+
+// modeFor returns the mode used to resolve claims about a predicate.
+func (e *Engine) modeFor(predicate string) Mode {
+	if e.modeResolver != nil {
+		if mode := e.modeResolver(predicate); mode != "" {
+			return mode
+		}
+	}
+	return e.mode
+}
+
+// SetMultiValuedResolver sets a lookup for predicates that hold a set of values.
+// Each (subject, predicate, object) member of such a set is reconciled, expires and
+// is retracted on its own; competing claims are only those about the same member.
+func (e *Engine) SetMultiValuedResolver(resolver func(predicate string) bool) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.multiValued = resolver
+}
+
+// SetCRDTResolver sets a lookup for predicates declared as CRDTs. Updates to such
+// predicates are merged into a replicated state instead of competing for the truth;
+// the resolver returns "" for ordinary predicates.
+func (e *Engine) SetCRDTResolver(resolver func(predicate string) crdt.Type) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.crdtType = resolver
+}
+
+func (e *Engine) crdtFor(predicate string) crdt.Type {
+	if e.crdtType == nil {
+		return ""
 	}
+	return e.crdtType(predicate)
+}
+
+// keyFor returns the key a claim is reconciled under: its SPID, or for a
+// multi-valued predicate its SPID together with its object.
+func (e *Engine) keyFor(kpak *core.Kpak) string {
+	if e.multiValued != nil && e.multiValued(kpak.Predicate) {
+		return kpak.SPID + "|" + kpak.ObjectKey()
+	}
+	return kpak.SPID
+}
+
+// keysFor returns the keys holding truths for a subject+predicate, sorted so
+// multi-valued sets are returned in a stable order.
+func (e *Engine) keysFor(subject, predicate string) []string {
+	spid := spidFor(subject, predicate)
+	var keys []string
+	for key := range e.subjectIndex[subject] {
+		if truth, exists := e.truthStore[key]; exists && truth.SPID == spid {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
 }
 
 // Reconcile processes a new k-pak and determines if it should be accepted.
 // Returns true if the k-pak was accepted (new truth), false if rejected.
 func (e *Engine) Reconcile(kpak *core.Kpak) bool {
+	return e.ReconcileOutcome(kpak) == OutcomeAccepted
+}
+
+// ReconcileOutcome processes a new k-pak and reports whether it became the truth,
+// was kept as a runner-up, or was dropped.
+func (e *Engine) ReconcileOutcome(kpak *core.Kpak) Outcome {
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
+	outcome, change := e.reconcileLocked(kpak)
+	handler := e.onChange
+	e.mutex.Unlock()
 
-	// Check if we already have a k-pak for this Subject+Predicate
-	existing, exists := e.truthStore[kpak.SPID]
+	if change != nil && handler != nil {
+		handler(*change)
+	}
+	return outcome
+}
 
-	if !exists {
+// ReconcileContext is ReconcileOutcome traced as a span under ctx; the time spent
+// waiting for the engine's lock is included.
+func (e *Engine) ReconcileContext(ctx context.Context, kpak *core.Kpak) Outcome {
+	_, span := tracing.Start(ctx, "github.com/Pew-X/sutra/internal/reconciliation", "Engine.Reconcile", tracing.KpakAttributes(kpak)...)
+	defer span.End()
+
+	outcome := e.ReconcileOutcome(kpak)
+	span.SetAttributes(attribute.String("reconcile.outcome", outcome.String()))
+	return outcome
+}
+
+func (e *Engine) reconcileLocked(kpak *core.Kpak) (Outcome, *TruthChange) {
+	if t := e.crdtFor(kpak.Predicate); t != "" {
+		return e.reconcileCRDT(kpak, t)
+	}
+
+	key := e.keyFor(kpak)
+	ranked := e.candidates[key]
+	if knownIn(ranked, kpak) {
+		return OutcomeRejected, nil
+	}
+
+	existing := e.truthStore[key]
+	known := e.versions[key]
+	outcome, change := e.resolveLocked(key, kpak, existing, ranked)
+	e.trackVersion(key, kpak, existing, known, outcome)
+	return outcome, change
+}
+
+// resolveLocked decides whether a claim becomes the truth for its key, is kept as
+// a runner-up or is dropped.
+func (e *Engine) resolveLocked(key string, kpak, existing *core.Kpak, ranked []*core.Kpak) (Outcome, *TruthChange) {
+	if existing == nil {
 		// New knowledge - accept it
+		e.candidates[key] = []*core.Kpak{kpak}
 		e.acceptKpak(kpak)
-		return true
+		return OutcomeAccepted, &TruthChange{Type: ChangeAccepted, SPID: kpak.SPID, Current: kpak}
+	}
+
+	now := time.Now()
+	if rule := e.dampingRuleFor(kpak.Predicate); rule != nil {
+		return e.reconcileDamped(key, kpak, existing, ranked, rule, now)
+	}
+	if e.modeFor(kpak.Predicate) == ModeFusion {
+		return e.reconcileFusion(key, kpak, existing, ranked, now)
 	}
 
 	// Conflict resolution: check if new k-pak is more trusted
-	if kpak.IsMoreTrustedThan(existing) {
-		// Replace existing with new k-pak
+	if e.moreTrusted(kpak, existing, now) {
+		// Replace existing with new k-pak, keeping the old truth as a runner-up
+		e.candidates[key] = e.insertCandidate(ranked, kpak, nil, now)
 		e.acceptKpak(kpak)
-		return true
+		return OutcomeAccepted, &TruthChange{Type: ChangeAccepted, SPID: kpak.SPID, Previous: existing, Current: kpak}
+	}
+
+	// Existing truth is more trusted - keep the claim only if it ranks within the bound
+	updated := e.insertCandidate(ranked, kpak, existing, now)
+	e.candidates[key] = updated
+
+	// With decay a runner-up may have overtaken the ageing truth in the meantime
+	var change *TruthChange
+	if updated[0] != existing {
+		e.acceptKpak(updated[0])
+		change = &TruthChange{Type: ChangePromoted, SPID: kpak.SPID, Previous: existing, Current: updated[0]}
+	}
+
+	if containsKpak(updated, kpak) {
+		return OutcomeCandidate, change
+	}
+	return OutcomeRejected, change
+}
+
+// reconcileFusion adds a claim to the evidence for its SPID and re-derives the truth.
+// A claim is accepted when its value is the one with the highest combined belief.
+func (e *Engine) reconcileFusion(key string, kpak, existing *core.Kpak, ranked []*core.Kpak, now time.Time) (Outcome, *TruthChange) {
+	updated := e.insertCandidate(ranked, kpak, nil, now)
+	e.candidates[key] = updated
+
+	winner, _ := e.fuse(updated, now)
+	var change *TruthChange
+	if winner != existing {
+		e.acceptKpak(winner)
+		change = &TruthChange{Type: ChangeAccepted, SPID: kpak.SPID, Previous: existing, Current: winner}
+	}
+
+	switch {
+	case !containsKpak(updated, kpak):
+		return OutcomeRejected, change
+	case kpak.ObjectKey() == winner.ObjectKey():
+		return OutcomeAccepted, change
+	default:
+		return OutcomeCandidate, change
+	}
+}
+
+// reconcileCRDT applies a local update to the replicated state of a CRDT predicate.
+// The update is accepted when it changed the state.
+func (e *Engine) reconcileCRDT(kpak *core.Kpak, t crdt.Type) (Outcome, *TruthChange) {
+	state, exists := e.crdts[kpak.SPID]
+	if !exists {
+		state = crdt.NewState(t, kpak.Subject, kpak.Predicate)
+	}
+
+	changed, err := state.Apply(kpak, e.actor)
+	if err != nil || !changed {
+		return OutcomeRejected, nil
+	}
+	e.crdts[kpak.SPID] = state
+	return OutcomeAccepted, e.materializeCRDT(kpak.SPID, state)
+}
+
+// materializeCRDT installs a CRDT state's current value as the truth for its SPID.
+// Returns nil when the truth is unchanged.
+func (e *Engine) materializeCRDT(spid string, state *crdt.State) *TruthChange {
+	previous := e.truthStore[spid]
+	current := state.Materialize()
+
+	switch {
+	case current == nil && previous == nil:
+		return nil
+	case current == nil:
+		e.removeTruth(previous)
+		return &TruthChange{Type: ChangeRetracted, SPID: spid, Previous: previous}
+	case previous != nil && previous.ID == current.ID:
+		return nil
+	}
+
+	e.acceptKpak(current)
+	return &TruthChange{Type: ChangeAccepted, SPID: spid, Previous: previous, Current: current}
+}
+
+// MergeCRDT folds a CRDT state received from another agent (or replayed from the
+// log) into the local state and reports whether anything changed.
+func (e *Engine) MergeCRDT(state *crdt.State) (bool, error) {
+	e.mutex.Lock()
+
+	spid := spidFor(state.Subject, state.Predicate)
+	local, exists := e.crdts[spid]
+	if !exists {
+		local = crdt.NewState(state.Type, state.Subject, state.Predicate)
+	}
+
+	changed, err := local.Merge(state)
+	var change *TruthChange
+	if err == nil && changed {
+		e.crdts[spid] = local
+		change = e.materializeCRDT(spid, local)
+	}
+
+	handler := e.onChange
+	e.mutex.Unlock()
+
+	if change != nil && handler != nil {
+		handler(*change)
+	}
+	return changed, err
+}
+
+// GetCRDT returns a copy of the replicated state of a CRDT predicate, or nil.
+func (e *Engine) GetCRDT(subject, predicate string) *crdt.State {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	state, exists := e.crdts[spidFor(subject, predicate)]
+	if !exists {
+		return nil
+	}
+	return state.Clone()
+}
+
+// GetAllCRDTs returns copies of every replicated CRDT state.
+func (e *Engine) GetAllCRDTs() []*crdt.State {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	results := make([]*crdt.State, 0, len(e.crdts))
+	for _, state := range e.crdts {
+		results = append(results, state.Clone())
+	}
+	return results
+}
+
+// insertCandidate adds kpak to the claims for its SPID, re-ranks them by effective
+// confidence and trims the list to the configured bound.
+// Older claims from the same source are superseded by the newer claim, so a source
+// that changed its mind is not later promoted on the strength of what it used to say.
+// The protected k-pak (the standing truth, if any) is never superseded.
+// In fusion mode every source's claim is kept as evidence, so agreeing claims keep
+// adding up however many other values compete.
+func (e *Engine) insertCandidate(ranked []*core.Kpak, kpak, protected *core.Kpak, now time.Time) []*core.Kpak {
+	result := make([]*core.Kpak, 0, len(ranked)+1)
+	for _, candidate := range ranked {
+		if candidate != protected && candidate.Source == kpak.Source && candidate.Timestamp <= kpak.Timestamp {
+			continue
+		}
+		result = append(result, candidate)
+	}
+	result = append(result, kpak)
+	e.rank(result, now)
+
+	if e.modeFor(kpak.Predicate) == ModeFusion {
+		return e.trimEvidence(result, now)
+	}
+	if len(result) > e.maxCandidates {
+		result = result[:e.maxCandidates]
+	}
+	return result
+}
+
+// trimEvidence drops the weakest claims of the least believed values once an SPID
+// holds more than maxEvidence claims.
+func (e *Engine) trimEvidence(ranked []*core.Kpak, now time.Time) []*core.Kpak {
+	if len(ranked) <= maxEvidence {
+		return ranked
+	}
+
+	order := make(map[string]int)
+	for i, value := range e.beliefs(ranked, now) {
+		order[value.best.ObjectKey()] = i
+	}
+	byBelief := append([]*core.Kpak(nil), ranked...)
+	sort.SliceStable(byBelief, func(i, j int) bool {
+		return order[byBelief[i].ObjectKey()] < order[byBelief[j].ObjectKey()]
+	})
+	result := byBelief[:maxEvidence]
+	e.rank(result, now)
+	return result
+}
+
+// rank orders claims best first by effective confidence. The sort is stable,
+// so among equally trusted claims the one seen first keeps its place.
+func (e *Engine) rank(ranked []*core.Kpak, now time.Time) {
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return e.moreTrusted(ranked[i], ranked[j], now)
+	})
+}
+
+// pickWinner chooses the truth among the ranked claims for an SPID.
+func (e *Engine) pickWinner(ranked []*core.Kpak, now time.Time) *core.Kpak {
+	if e.modeFor(ranked[0].Predicate) == ModeFusion {
+		winner, _ := e.fuse(ranked, now)
+		return winner
+	}
+	return ranked[0]
+}
+
+// valueBelief is the combined evidence of every claim for one value.
+type valueBelief struct {
+	best      *core.Kpak
+	disbelief float64
+	sources   []string
+}
+
+// beliefs groups claims by value and combines each group's effective confidences
+// with noisy-OR, treating every source as independent evidence. Values are returned
+// most believed first; ties go to the value whose best claim is more trusted.
+func (e *Engine) beliefs(ranked []*core.Kpak, now time.Time) []*valueBelief {
+	var values []*valueBelief
+	groups := make(map[string]*valueBelief)
+	for _, candidate := range ranked {
+		key := candidate.ObjectKey()
+		g, exists := groups[key]
+		if !exists {
+			// ranked is ordered best first, so the first claim seen is the group's best
+			g = &valueBelief{best: candidate, disbelief: 1}
+			groups[key] = g
+			values = append(values, g)
+		}
+		g.disbelief *= 1 - float64(e.EffectiveConfidence(candidate, now))
+		g.sources = append(g.sources, candidate.Source)
 	}
 
-	// Reject the k-pak - existing truth is more trusted
+	sort.SliceStable(values, func(i, j int) bool {
+		if values[i].disbelief != values[j].disbelief {
+			return values[i].disbelief < values[j].disbelief
+		}
+		return e.moreTrusted(values[i].best, values[j].best, now)
+	})
+	return values
+}
+
+// fuse returns the most trusted claim of the most believed value together with
+// the value's combined evidence.
+func (e *Engine) fuse(ranked []*core.Kpak, now time.Time) (*core.Kpak, Evidence) {
+	winner := e.beliefs(ranked, now)[0]
+	return winner.best, Evidence{
+		Confidence: float32(1 - winner.disbelief),
+		Sources:    winner.sources,
+	}
+}
+
+func containsKpak(ranked []*core.Kpak, kpak *core.Kpak) bool {
+	for _, candidate := range ranked {
+		if candidate == kpak {
+			return true
+		}
+	}
 	return false
 }
 
 // acceptKpak stores a k-pak as accepted truth and updates indices.
 func (e *Engine) acceptKpak(kpak *core.Kpak) {
 	// Store in truth store
-	e.truthStore[kpak.SPID] = kpak
+	key := e.keyFor(kpak)
+	e.truthStore[key] = kpak
+	delete(e.pending, key)
 
 	// Update subject index
 	if _, exists := e.subjectIndex[kpak.Subject]; !exists {
 		e.subjectIndex[kpak.Subject] = make(map[string]struct{})
 	}
-	e.subjectIndex[kpak.Subject][kpak.SPID] = struct{}{}
+	e.subjectIndex[kpak.Subject][key] = struct{}{}
+}
+
+// removeTruth drops a key from the truth store and indices.
+func (e *Engine) removeTruth(kpak *core.Kpak) {
+	key := e.keyFor(kpak)
+	delete(e.truthStore, key)
+	delete(e.candidates, key)
+	delete(e.versions, key)
+	delete(e.pending, key)
+
+	if spidSet, exists := e.subjectIndex[kpak.Subject]; exists {
+		delete(spidSet, key)
+		// If this was the last SPID for this subject, remove the subject entry
+		if len(spidSet) == 0 {
+			delete(e.subjectIndex, kpak.Subject)
+		}
+	}
+}
+
+// Rekey moves the claims about a predicate to the keys its current cardinality calls
+// for. Call it after a predicate switches between single- and multi-valued, so
+// claims reconciled under the old keys are not orphaned. The truths are recomputed
+// from the moved claims; damping waits are dropped and no change events are sent,
+// since replaying the schema change recomputes the same state.
+func (e *Engine) Rekey(predicate string) {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if e.crdtFor(predicate) != "" {
+		return // CRDT states are kept per SPID
+	}
+
+	type moved struct {
+		ranked  []*core.Kpak
+		version core.VersionVector
+	}
+	regrouped := make(map[string]*moved)
+	var order []string
+	for subject, keys := range e.subjectIndex {
+		for key := range keys {
+			truth, exists := e.truthStore[key]
+			if !exists || truth.Predicate != predicate {
+				continue
+			}
+			for _, candidate := range e.candidates[key] {
+				newKey := e.keyFor(candidate)
+				m, exists := regrouped[newKey]
+				if !exists {
+					m = &moved{}
+					regrouped[newKey] = m
+					order = append(order, newKey)
+				}
+				m.ranked = append(m.ranked, candidate)
+				m.version = m.version.Merge(e.versions[key])
+			}
+
+			delete(e.truthStore, key)
+			delete(e.candidates, key)
+			delete(e.versions, key)
+			delete(e.pending, key)
+			delete(keys, key)
+		}
+		if len(keys) == 0 {
+			delete(e.subjectIndex, subject)
+		}
+	}
+
+	now := time.Now()
+	for _, key := range order {
+		m := regrouped[key]
+		m.ranked = latestPerSource(m.ranked)
+		e.rank(m.ranked, now)
+		if e.modeFor(predicate) == ModeFusion {
+			m.ranked = e.trimEvidence(m.ranked, now)
+		} else if len(m.ranked) > e.maxCandidates {
+			m.ranked = m.ranked[:e.maxCandidates]
+		}
+		e.candidates[key] = m.ranked
+		if len(m.version) > 0 {
+			e.versions[key] = m.version
+		}
+		e.acceptKpak(e.pickWinner(m.ranked, now))
+	}
+}
+
+// latestPerSource keeps each source's newest claim, as insertCandidate does when
+// a source changes its mind.
+func latestPerSource(claims []*core.Kpak) []*core.Kpak {
+	latest := make(map[string]*core.Kpak, len(claims))
+	for _, claim := range claims {
+		if kept, exists := latest[claim.Source]; !exists || claim.Timestamp > kept.Timestamp {
+			latest[claim.Source] = claim
+		}
+	}
+	result := claims[:0]
+	for _, claim := range claims {
+		if latest[claim.Source] == claim {
+			result = append(result, claim)
+		}
+	}
+	return result
+}
+
+// replaceCandidates installs a new ranked list for an SPID after claims were removed,
+// promoting a new winner if needed or removing the fact when nothing is left.
+// Returns nil when the truth is unchanged.
+func (e *Engine) replaceCandidates(previous *core.Kpak, remaining []*core.Kpak, gone ChangeType, now time.Time) *TruthChange {
+	if len(remaining) == 0 {
+		e.removeTruth(previous)
+		return &TruthChange{Type: gone, SPID: previous.SPID, Previous: previous}
+	}
+
+	e.rank(remaining, now)
+	key := e.keyFor(previous)
+	e.candidates[key] = remaining
+	winner := e.pickWinner(remaining, now)
+	if rule := e.dampingRuleFor(previous.Predicate); rule != nil && containsKpak(remaining, previous) {
+		// Only re-ranked: the new winner still has to earn its place
+		return e.settle(key, winner, previous, nil, rule, now)
+	}
+	if winner == previous {
+		return nil
+	}
+	e.acceptKpak(winner)
+	return &TruthChange{Type: ChangePromoted, SPID: previous.SPID, Previous: previous, Current: winner}
 }
 
 // QueryBySubject returns all accepted k-paks for a given subject. simple full text matching , may require semantics in future
+// Facts pinned by an operator return the pinned k-pak instead.
 func (e *Engine) QueryBySubject(subject string) []*core.Kpak {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 
-	spids, exists := e.subjectIndex[subject]
-	if !exists {
-		return nil
-	}
+	spids := e.subjectIndex[subject]
 
 	var results []*core.Kpak
+	pinned := make(map[string]bool)
 	for spid := range spids {
-		if kpak, exists := e.truthStore[spid]; exists {
-			results = append(results, kpak)
+		kpak, exists := e.truthStore[spid]
+		if !exists {
+			continue
+		}
+		if pin := e.activePin(kpak.SPID); pin != nil {
+			// A pin replaces every value of its subject+predicate
+			if !pinned[kpak.SPID] {
+				pinned[kpak.SPID] = true
+				results = append(results, pin.Kpak)
+			}
+			continue
+		}
+		results = append(results, kpak)
+	}
+
+	// Pins may also stand in for facts nothing has claimed
+	for spid, pin := range e.pins {
+		if pin.Kpak.Subject == subject && !pinned[spid] && pin.active() {
+			results = append(results, pin.Kpak)
 		}
 	}
 
@@ -83,17 +746,112 @@ func (e *Engine) QueryBySubject(subject string) []*core.Kpak {
 }
 
 // QueryBySubjectPredicate returns the accepted k-pak for a specific subject+predicate. may require semantics
+// Multi-valued predicates have no single truth; use QueryValues for them. A pin takes precedence.
 func (e *Engine) QueryBySubjectPredicate(subject, predicate string) *core.Kpak {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 
-	// Generate SPID for lookup
-	tempKpak := &core.Kpak{Subject: subject, Predicate: predicate}
-	spid := tempKpak.GenerateSPID()
-
+	spid := spidFor(subject, predicate)
+	if pin := e.activePin(spid); pin != nil {
+		return pin.Kpak
+	}
 	return e.truthStore[spid]
 }
 
+// QueryValues returns every accepted k-pak for a subject+predicate: the single
+// truth, or the whole live set of a multi-valued predicate ordered by object.
+// A pinned fact returns only the pinned k-pak.
+func (e *Engine) QueryValues(subject, predicate string) []*core.Kpak {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if pin := e.activePin(spidFor(subject, predicate)); pin != nil {
+		return []*core.Kpak{pin.Kpak}
+	}
+
+	var results []*core.Kpak
+	for _, key := range e.keysFor(subject, predicate) {
+		results = append(results, e.truthStore[key])
+	}
+	return results
+}
+
+// Known reports whether a claim is already kept, as the truth or a runner-up, so
+// reconciling it again would change nothing. CRDT updates are never known.
+func (e *Engine) Known(kpak *core.Kpak) bool {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	if e.crdtFor(kpak.Predicate) != "" {
+		return false
+	}
+	return knownIn(e.candidates[e.keyFor(kpak)], kpak)
+}
+
+func knownIn(ranked []*core.Kpak, kpak *core.Kpak) bool {
+	for _, candidate := range ranked {
+		if candidate.ID == kpak.ID {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCandidates returns the claims kept for a subject+predicate, ranked by their own trust.
+// In the default mode the first element is the current truth. For multi-valued
+// predicates the ranked claims of each member follow one another.
+func (e *Engine) GetCandidates(subject, predicate string) []*core.Kpak {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	var results []*core.Kpak
+	for _, key := range e.keysFor(subject, predicate) {
+		results = append(results, e.candidates[key]...)
+	}
+	return results
+}
+
+// GetEvidence returns the combined confidence and supporting sources of the
+// current truth for a subject+predicate, or nil if there is none.
+func (e *Engine) GetEvidence(subject, predicate string) *Evidence {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.evidenceLocked(spidFor(subject, predicate))
+}
+
+// GetEvidenceFor returns the evidence behind an accepted k-pak, including a
+// member of a multi-valued predicate, or nil if it is not a current truth.
+func (e *Engine) GetEvidenceFor(kpak *core.Kpak) *Evidence {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.evidenceLocked(e.keyFor(kpak))
+}
+
+func (e *Engine) evidenceLocked(key string) *Evidence {
+	winner, exists := e.truthStore[key]
+	if !exists {
+		return nil
+	}
+
+	now := time.Now()
+	if e.modeFor(winner.Predicate) == ModeFusion {
+		// Only claims for the truth's value back it, even while another value is pending
+		var backing []*core.Kpak
+		for _, candidate := range e.candidates[key] {
+			if candidate.ObjectKey() == winner.ObjectKey() {
+				backing = append(backing, candidate)
+			}
+		}
+		if len(backing) > 0 {
+			_, evidence := e.fuse(backing, now)
+			return &evidence
+		}
+	}
+	return &Evidence{Confidence: e.EffectiveConfidence(winner, now), Sources: []string{winner.Source}}
+}
+
 // GetAllTruths returns all currently accepted k-paks.
 func (e *Engine) GetAllTruths() []*core.Kpak {
 	e.mutex.RLock()
@@ -107,48 +865,148 @@ func (e *Engine) GetAllTruths() []*core.Kpak {
 	return results
 }
 
+// GetAllCandidates returns every claim kept, truths and runner-ups alike.
+func (e *Engine) GetAllCandidates() []*core.Kpak {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	var results []*core.Kpak
+	for _, ranked := range e.candidates {
+		results = append(results, ranked...)
+	}
+	return results
+}
+
 // GetStats returns statistics about the current state.
 func (e *Engine) GetStats() map[string]interface{} {
 	e.mutex.RLock()
 	defer e.mutex.RUnlock()
 
+	totalCandidates := 0
+	for _, ranked := range e.candidates {
+		totalCandidates += len(ranked)
+	}
+
 	return map[string]interface{}{
-		"total_kpaks":    len(e.truthStore),
-		"total_subjects": len(e.subjectIndex),
+		"total_kpaks":      len(e.truthStore),
+		"total_subjects":   len(e.subjectIndex),
+		"total_candidates": totalCandidates,
+		"total_conflicts":  len(e.conflicts),
 	}
 }
 
-// RemoveExpiredKpaks removes all expired k-paks from the truth store.
+// RemoveExpiredKpaks removes all expired k-paks from the truth store, along with
+// claims whose confidence has decayed to zero. When an expired truth has an
+// unexpired runner-up, the runner-up is promoted. With decay rules configured,
+// every SPID is re-ranked so a fresher claim can overtake an ageing truth.
+// CRDT predicates are exempt: their value is the merge of every update in the
+// mesh, so dropping it here would only bring it back at the next state sync.
 // Returns the number of k-paks that were removed.
 func (e *Engine) RemoveExpiredKpaks() int {
 	e.mutex.Lock()
-	defer e.mutex.Unlock()
 
-	var expiredSPIDs []string
+	now := time.Now()
+	removed := 0
+	var changes []TruthChange
+
+	for key, ranked := range e.candidates {
+		remaining := ranked[:0:0]
+		for _, candidate := range ranked {
+			if candidate.IsExpired() || e.isDecayed(candidate, now) {
+				removed++
+				continue
+			}
+			remaining = append(remaining, candidate)
+		}
+		if len(remaining) == len(ranked) && len(e.decay) == 0 {
+			continue
+		}
 
-	// Find all expired k-paks
-	for spid, kpak := range e.truthStore {
-		if kpak.IsExpired() {
-			expiredSPIDs = append(expiredSPIDs, spid)
+		if change := e.replaceCandidates(e.truthStore[key], remaining, ChangeExpired, now); change != nil {
+			changes = append(changes, *change)
 		}
 	}
+	changes = append(changes, e.removeExpiredPins(now)...)
 
-	// Remove expired k-paks from truth store and update indices
-	for _, spid := range expiredSPIDs {
-		kpak := e.truthStore[spid]
+	handler := e.onChange
+	e.mutex.Unlock()
 
-		// Remove from truth store
-		delete(e.truthStore, spid)
+	if handler != nil {
+		for _, change := range changes {
+			handler(change)
+		}
+	}
+
+	return removed
+}
 
-		// Update subject index
-		if spidSet, exists := e.subjectIndex[kpak.Subject]; exists {
-			delete(spidSet, spid)
-			// If this was the last SPID for this subject, remove the subject entry
-			if len(spidSet) == 0 {
-				delete(e.subjectIndex, kpak.Subject)
+// Retract withdraws every claim a source made about a subject+predicate up to
+// asOf (a Unix timestamp), including its claims to any member of a multi-valued
+// predicate. Newer claims are kept, so a retraction gossiped out of order cannot
+// withdraw a claim the source made after it.
+// If the source held the truth, the best remaining runner-up is promoted.
+// Returns the number of claims withdrawn.
+func (e *Engine) Retract(subject, predicate, source string, asOf int64) int {
+	return e.retract(subject, predicate, source, "", asOf)
+}
+
+// RetractMember withdraws a source's claims up to asOf that a subject+predicate
+// has one particular value, identified by its ObjectKey. For a multi-valued
+// predicate this removes the source's support for a single member of the set.
+// Returns the number of claims withdrawn.
+func (e *Engine) RetractMember(subject, predicate, source, objectKey string, asOf int64) int {
+	return e.retract(subject, predicate, source, objectKey, asOf)
+}
+
+func (e *Engine) retract(subject, predicate, source, objectKey string, asOf int64) int {
+	e.mutex.Lock()
+
+	now := time.Now()
+	retracted := 0
+	var changes []TruthChange
+
+	spid := spidFor(subject, predicate)
+	if state, exists := e.crdts[spid]; exists {
+		// A source can only withdraw the elements it added to an OR-set
+		retracted = state.Retract(source, objectKey)
+		if retracted > 0 {
+			if change := e.materializeCRDT(spid, state); change != nil {
+				changes = append(changes, *change)
+			}
+		}
+	} else {
+		for _, key := range e.keysFor(subject, predicate) {
+			ranked := e.candidates[key]
+			remaining := make([]*core.Kpak, 0, len(ranked))
+			for _, candidate := range ranked {
+				if candidate.Source != source || candidate.Timestamp > asOf || (objectKey != "" && candidate.ObjectKey() != objectKey) {
+					remaining = append(remaining, candidate)
+				}
+			}
+			if len(remaining) == len(ranked) {
+				continue
+			}
+
+			retracted += len(ranked) - len(remaining)
+			if change := e.replaceCandidates(e.truthStore[key], remaining, ChangeRetracted, now); change != nil {
+				changes = append(changes, *change)
 			}
 		}
 	}
 
-	return len(expiredSPIDs)
+	handler := e.onChange
+	e.mutex.Unlock()
+
+	if handler != nil {
+		for _, change := range changes {
+			handler(change)
+		}
+	}
+	return retracted
+}
+
+// spidFor computes the SPID for a subject+predicate pair.
+func spidFor(subject, predicate string) string {
+	tempKpak := &core.Kpak{Subject: subject, Predicate: predicate}
+	return tempKpak.GenerateSPID()
 }
@@ -0,0 +1,211 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func TestReconcileOutcome_Candidates(t *testing.T) {
+	engine := NewEngine()
+
+	winner := core.NewKpak("db-1", "status", "up", "MonitorA", 0.9)
+	runnerUp := core.NewKpak("db-1", "status", "down", "MonitorB", 0.8)
+
+	if outcome := engine.ReconcileOutcome(winner); outcome != OutcomeAccepted {
+		t.Fatalf("Expected first k-pak to be accepted, got %v", outcome)
+	}
+	if outcome := engine.ReconcileOutcome(runnerUp); outcome != OutcomeCandidate {
+		t.Fatalf("Expected lower confidence k-pak to be kept as candidate, got %v", outcome)
+	}
+	if outcome := engine.ReconcileOutcome(runnerUp); outcome != OutcomeRejected {
+		t.Fatalf("Expected duplicate k-pak to be rejected, got %v", outcome)
+	}
+
+	candidates := engine.GetCandidates("db-1", "status")
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+	if candidates[0].ID != winner.ID || candidates[1].ID != runnerUp.ID {
+		t.Fatal("Candidates are not ranked best first")
+	}
+}
+
+func TestReconcileOutcome_BoundedCandidates(t *testing.T) {
+	engine := NewEngineWithConfig(Config{MaxCandidates: 2})
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorA", 0.9))
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "MonitorB", 0.8))
+
+	low := core.NewKpak("db-1", "status", "degraded", "MonitorC", 0.1)
+	if outcome := engine.ReconcileOutcome(low); outcome != OutcomeRejected {
+		t.Fatalf("Expected k-pak outside the bound to be rejected, got %v", outcome)
+	}
+
+	higher := core.NewKpak("db-1", "status", "degraded", "MonitorD", 0.85)
+	if outcome := engine.ReconcileOutcome(higher); outcome != OutcomeCandidate {
+		t.Fatalf("Expected k-pak inside the bound to be kept, got %v", outcome)
+	}
+
+	candidates := engine.GetCandidates("db-1", "status")
+	if len(candidates) != 2 {
+		t.Fatalf("Expected candidate set bounded to 2, got %d", len(candidates))
+	}
+	if candidates[1].Source != "MonitorD" {
+		t.Fatalf("Expected MonitorD as runner-up, got %s", candidates[1].Source)
+	}
+}
+
+func TestReconcileOutcome_SameSourceSupersedesRunnerUp(t *testing.T) {
+	engine := NewEngine()
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorA", 0.9))
+
+	old := core.NewKpak("db-1", "status", "down", "MonitorB", 0.8)
+	old.Timestamp -= 10
+	old.RegenerateComputedFields()
+	engine.Reconcile(old)
+
+	newer := core.NewKpak("db-1", "status", "up", "MonitorB", 0.5)
+	engine.Reconcile(newer)
+
+	candidates := engine.GetCandidates("db-1", "status")
+	if len(candidates) != 2 {
+		t.Fatalf("Expected 2 candidates, got %d", len(candidates))
+	}
+	if candidates[1].ID != newer.ID {
+		t.Fatal("Newer claim from the same source should replace the older runner-up")
+	}
+}
+
+func TestRemoveExpiredKpaks_PromotesRunnerUp(t *testing.T) {
+	engine := NewEngine()
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	winner := core.NewKpakWithTTL("db-1", "status", "up", "MonitorA", 0.99, 60)
+	engine.Reconcile(winner)
+
+	expiredRunnerUp := core.NewKpakWithTTL("db-1", "status", "unknown", "MonitorC", 0.95, 60)
+	engine.Reconcile(expiredRunnerUp)
+
+	runnerUp := core.NewKpak("db-1", "status", "down", "MonitorB", 0.9)
+	engine.Reconcile(runnerUp)
+
+	winner.ExpiresAt = time.Now().Unix() - 1
+	expiredRunnerUp.ExpiresAt = time.Now().Unix() - 1
+	changes = nil
+
+	removed := engine.RemoveExpiredKpaks()
+	if removed != 2 {
+		t.Fatalf("Expected 2 expired k-paks removed, got %d", removed)
+	}
+
+	result := engine.QueryBySubjectPredicate("db-1", "status")
+	if result == nil {
+		t.Fatal("Runner-up should have been promoted")
+	}
+	if result.ID != runnerUp.ID {
+		t.Fatalf("Expected runner-up %s to be promoted, got %v", runnerUp.ID, result.Object)
+	}
+
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change event, got %d", len(changes))
+	}
+	if changes[0].Type != ChangePromoted || changes[0].Previous.ID != winner.ID || changes[0].Current.ID != runnerUp.ID {
+		t.Fatalf("Unexpected change event: %+v", changes[0])
+	}
+}
+
+func TestRemoveExpiredKpaks_ExpiredRunnerUpOnly(t *testing.T) {
+	engine := NewEngine()
+
+	winner := core.NewKpak("db-1", "status", "up", "MonitorA", 0.9)
+	engine.Reconcile(winner)
+
+	runnerUp := core.NewKpakWithTTL("db-1", "status", "down", "MonitorB", 0.8, 60)
+	engine.Reconcile(runnerUp)
+	runnerUp.ExpiresAt = time.Now().Unix() - 1
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	if removed := engine.RemoveExpiredKpaks(); removed != 1 {
+		t.Fatalf("Expected 1 expired runner-up removed, got %d", removed)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("Truth did not change, expected no events, got %d", len(changes))
+	}
+	if len(engine.GetCandidates("db-1", "status")) != 1 {
+		t.Fatal("Expired runner-up should be dropped from the candidate set")
+	}
+}
+
+func TestRetract(t *testing.T) {
+	engine := NewEngine()
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorA", 0.9))
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "MonitorB", 0.8))
+	changes = nil
+
+	if retracted := engine.Retract("db-1", "status", "MonitorA", time.Now().Unix()); retracted != 1 {
+		t.Fatalf("Expected 1 claim retracted, got %d", retracted)
+	}
+
+	result := engine.QueryBySubjectPredicate("db-1", "status")
+	if result == nil || result.Source != "MonitorB" {
+		t.Fatal("MonitorB's claim should have been promoted after retraction")
+	}
+	if len(changes) != 1 || changes[0].Type != ChangePromoted {
+		t.Fatalf("Expected a promotion event, got %+v", changes)
+	}
+
+	if retracted := engine.Retract("db-1", "status", "MonitorB", time.Now().Unix()); retracted != 1 {
+		t.Fatalf("Expected 1 claim retracted, got %d", retracted)
+	}
+	if engine.QueryBySubjectPredicate("db-1", "status") != nil {
+		t.Fatal("Fact should be gone once every claim is retracted")
+	}
+	if len(changes) != 2 || changes[1].Type != ChangeRetracted {
+		t.Fatalf("Expected a retraction event, got %+v", changes)
+	}
+
+	stats := engine.GetStats()
+	if stats["total_subjects"].(int) != 0 {
+		t.Fatal("Subject index should be empty after all claims are retracted")
+	}
+
+	if retracted := engine.Retract("db-1", "status", "Unknown", time.Now().Unix()); retracted != 0 {
+		t.Fatalf("Retracting unknown claims should be a no-op, got %d", retracted)
+	}
+}
+
+func TestRetract_KeepsNewerClaims(t *testing.T) {
+	engine := NewEngine()
+
+	claim := core.NewKpak("db-1", "status", "up", "MonitorA", 0.9)
+	engine.Reconcile(claim)
+
+	// A retraction gossiped after the source's newer claim leaves that claim alone
+	if retracted := engine.Retract("db-1", "status", "MonitorA", claim.Timestamp-1); retracted != 0 {
+		t.Fatalf("Expected a stale retraction to withdraw nothing, got %d", retracted)
+	}
+	if engine.QueryBySubjectPredicate("db-1", "status") == nil {
+		t.Fatal("The newer claim should still be the truth")
+	}
+
+	if retracted := engine.Retract("db-1", "status", "MonitorA", claim.Timestamp); retracted != 1 {
+		t.Fatalf("Expected a retraction at the claim's timestamp to withdraw it, got %d", retracted)
+	}
+}
@@ -0,0 +1,128 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+)
+
+func newCRDTEngine(actor string) *Engine {
+	engine := NewEngineWithConfig(Config{Actor: actor})
+	engine.SetCRDTResolver(func(predicate string) crdt.Type {
+		switch predicate {
+		case "incidents":
+			return crdt.GCounter
+		case "tags":
+			return crdt.ORSet
+		}
+		return ""
+	})
+	return engine
+}
+
+func TestCRDT_CounterAccumulatesUpdates(t *testing.T) {
+	engine := newCRDTEngine("agent-a")
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	engine.Reconcile(core.NewKpak("svc-a", "incidents", int64(1), "pager", 0.5))
+	engine.Reconcile(core.NewKpak("svc-a", "incidents", int64(2), "pager", 0.5))
+
+	truth := engine.QueryBySubjectPredicate("svc-a", "incidents")
+	if truth == nil || truth.Object != int64(3) {
+		t.Fatalf("Expected counter value 3, got %+v", truth)
+	}
+	for _, change := range changes {
+		if change.Type == ChangePromoted {
+			t.Fatal("CRDT updates should never be reported as promotions")
+		}
+	}
+
+	if engine.Reconcile(core.NewKpak("svc-a", "incidents", "lots", "pager", 0.5)) {
+		t.Fatal("A non-integer counter update should be rejected")
+	}
+}
+
+func TestCRDT_MergeFromAnotherAgent(t *testing.T) {
+	a := newCRDTEngine("agent-a")
+	b := newCRDTEngine("agent-b")
+
+	a.Reconcile(core.NewKpak("svc-a", "incidents", int64(2), "pager", 0.5))
+	b.Reconcile(core.NewKpak("svc-a", "incidents", int64(5), "pager", 0.5))
+
+	for _, state := range b.GetAllCRDTs() {
+		if changed, err := a.MergeCRDT(state); err != nil || !changed {
+			t.Fatalf("Expected merge to change state, got %v, %v", changed, err)
+		}
+	}
+	for _, state := range a.GetAllCRDTs() {
+		b.MergeCRDT(state)
+	}
+
+	for _, engine := range []*Engine{a, b} {
+		if truth := engine.QueryBySubjectPredicate("svc-a", "incidents"); truth == nil || truth.Object != int64(7) {
+			t.Fatalf("Expected both agents to converge on 7, got %+v", truth)
+		}
+	}
+
+	if changed, _ := a.MergeCRDT(b.GetCRDT("svc-a", "incidents")); changed {
+		t.Fatal("Re-merging a converged state should change nothing")
+	}
+}
+
+func TestCRDT_ORSetRetract(t *testing.T) {
+	engine := newCRDTEngine("agent-a")
+
+	engine.Reconcile(core.NewKpak("host-1", "tags", "web", "inventory", 0.9))
+	engine.Reconcile(core.NewKpak("host-1", "tags", "prod", "inventory", 0.9))
+
+	if n := engine.RetractMember("host-1", "tags", "inventory", core.CanonicalObject("web"), time.Now().Unix()); n != 1 {
+		t.Fatalf("Expected 1 element retracted, got %d", n)
+	}
+	truth := engine.QueryBySubjectPredicate("host-1", "tags")
+	if truth == nil || string(truth.Object.(core.JSON)) != `["prod"]` {
+		t.Fatalf("Expected only prod to remain, got %+v", truth)
+	}
+
+	if n := engine.Retract("host-1", "tags", "someone-else", time.Now().Unix()); n != 0 {
+		t.Fatalf("A source should not retract another source's elements, got %d", n)
+	}
+
+	engine.Retract("host-1", "tags", "inventory", time.Now().Unix())
+	if truth := engine.QueryBySubjectPredicate("host-1", "tags"); truth != nil {
+		t.Fatalf("Expected the set to be gone, got %+v", truth)
+	}
+}
+
+func TestCRDT_ExemptFromExpiry(t *testing.T) {
+	engine := NewEngineWithConfig(Config{
+		Actor: "agent-a",
+		Decay: []DecayRule{{Pattern: "incidents", Function: DecayLinear, LifetimeSeconds: 1}},
+	})
+	engine.SetCRDTResolver(func(predicate string) crdt.Type {
+		return crdt.GCounter
+	})
+
+	// Updates that have long expired and decayed still count towards the merged value
+	update := core.NewKpakWithTTL("svc-a", "incidents", int64(3), "pager", 0.5, 1)
+	update.Timestamp -= 60
+	update.ExpiresAt -= 60
+	update.RegenerateComputedFields()
+	if !engine.Reconcile(update) {
+		t.Fatal("Expected the update to be applied")
+	}
+
+	if removed := engine.RemoveExpiredKpaks(); removed != 0 {
+		t.Fatalf("Expected garbage collection to leave CRDT state alone, removed %d", removed)
+	}
+	if truth := engine.QueryBySubjectPredicate("svc-a", "incidents"); truth == nil || truth.Object != int64(3) {
+		t.Fatalf("Expected the counter to survive garbage collection, got %+v", truth)
+	} else if confidence := engine.EffectiveConfidence(truth, time.Now()); confidence != truth.Confidence {
+		t.Fatalf("Expected the counter not to decay, got %v", confidence)
+	}
+}
@@ -0,0 +1,190 @@
+package reconciliation
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func TestParseMode(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected Mode
+		wantErr  bool
+	}{
+		{"", ModeHighestConfidence, false},
+		{"highest_confidence", ModeHighestConfidence, false},
+		{"fusion", ModeFusion, false},
+		{"majority", "", true},
+	}
+
+	for _, tc := range testCases {
+		mode, err := ParseMode(tc.name)
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("ParseMode(%q) error = %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+		if mode != tc.expected {
+			t.Fatalf("ParseMode(%q) = %q, expected %q", tc.name, mode, tc.expected)
+		}
+	}
+}
+
+func TestFusion_AgreeingSourcesBeatSingleClaim(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Mode: ModeFusion})
+
+	stale := core.NewKpak("db-1", "status", "up", "OldMonitor", 0.95)
+	if !engine.Reconcile(stale) {
+		t.Fatal("First claim should be accepted")
+	}
+
+	first := core.NewKpak("db-1", "status", "down", "MonitorA", 0.7)
+	if outcome := engine.ReconcileOutcome(first); outcome != OutcomeCandidate {
+		t.Fatalf("Single medium claim should not win yet, got %v", outcome)
+	}
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "MonitorB", 0.7))
+
+	third := core.NewKpak("db-1", "status", "down", "MonitorC", 0.7)
+	if outcome := engine.ReconcileOutcome(third); outcome != OutcomeAccepted {
+		t.Fatalf("Third agreeing claim should tip the balance, got %v", outcome)
+	}
+
+	result := engine.QueryBySubjectPredicate("db-1", "status")
+	if result.Object != "down" {
+		t.Fatalf("Expected fused truth 'down', got '%v'", result.Object)
+	}
+
+	evidence := engine.GetEvidence("db-1", "status")
+	if evidence == nil {
+		t.Fatal("Evidence should be available for the truth")
+	}
+	expected := 1 - math.Pow(0.3, 3)
+	if math.Abs(float64(evidence.Confidence)-expected) > 1e-4 {
+		t.Fatalf("Expected fused confidence %.4f, got %.4f", expected, evidence.Confidence)
+	}
+	if len(evidence.Sources) != 3 {
+		t.Fatalf("Expected 3 supporting sources, got %v", evidence.Sources)
+	}
+}
+
+func TestFusion_AgreeingSourcesSurviveCandidateBound(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Mode: ModeFusion, MaxCandidates: 2})
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "OldMonitor", 0.95))
+	// More confident single claims for other values would push the agreeing claims out
+	engine.Reconcile(core.NewKpak("db-1", "status", "degraded", "ProbeA", 0.9))
+	engine.Reconcile(core.NewKpak("db-1", "status", "maintenance", "ProbeB", 0.9))
+
+	for _, source := range []string{"MonitorA", "MonitorB", "MonitorC"} {
+		engine.Reconcile(core.NewKpak("db-1", "status", "down", source, 0.7))
+	}
+
+	if result := engine.QueryBySubjectPredicate("db-1", "status"); result.Object != "down" {
+		t.Fatalf("Expected 'down' to win on combined belief, got '%v'", result.Object)
+	}
+	if evidence := engine.GetEvidence("db-1", "status"); evidence == nil || len(evidence.Sources) != 3 {
+		t.Fatalf("Expected all 3 agreeing sources as evidence, got %+v", evidence)
+	}
+}
+
+func TestFusion_EvidenceBound(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Mode: ModeFusion})
+
+	for i := 0; i < maxEvidence; i++ {
+		engine.Reconcile(core.NewKpak("db-1", "status", "up", fmt.Sprintf("monitor-%d", i), 0.5))
+	}
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "lone", 0.9))
+
+	candidates := engine.GetCandidates("db-1", "status")
+	if len(candidates) != maxEvidence {
+		t.Fatalf("Expected %d claims kept, got %d", maxEvidence, len(candidates))
+	}
+	for _, candidate := range candidates {
+		if candidate.Source == "lone" {
+			t.Fatal("The claim for the least believed value should be dropped first")
+		}
+	}
+}
+
+func TestFusion_ReinforcingClaimIsAccepted(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Mode: ModeFusion})
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorA", 0.8))
+
+	reinforcing := core.NewKpak("db-1", "status", "up", "MonitorB", 0.5)
+	if outcome := engine.ReconcileOutcome(reinforcing); outcome != OutcomeAccepted {
+		t.Fatalf("Claim agreeing with the truth should be accepted, got %v", outcome)
+	}
+
+	evidence := engine.GetEvidence("db-1", "status")
+	if math.Abs(float64(evidence.Confidence)-0.9) > 1e-4 {
+		t.Fatalf("Expected fused confidence 0.9, got %.4f", evidence.Confidence)
+	}
+}
+
+func TestFusion_RetractionRecomputesWinner(t *testing.T) {
+	engine := NewEngineWithConfig(Config{Mode: ModeFusion})
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "OldMonitor", 0.9))
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "MonitorA", 0.7))
+	engine.Reconcile(core.NewKpak("db-1", "status", "down", "MonitorB", 0.7))
+
+	if result := engine.QueryBySubjectPredicate("db-1", "status"); result.Object != "down" {
+		t.Fatalf("Expected 'down' to win on combined belief, got '%v'", result.Object)
+	}
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	engine.Retract("db-1", "status", "MonitorB", time.Now().Unix())
+
+	if result := engine.QueryBySubjectPredicate("db-1", "status"); result.Object != "up" {
+		t.Fatalf("Expected 'up' after losing supporting evidence, got '%v'", result.Object)
+	}
+	if len(changes) != 1 || changes[0].Type != ChangePromoted {
+		t.Fatalf("Expected a promotion event, got %+v", changes)
+	}
+}
+
+func TestEvidence_HighestConfidenceMode(t *testing.T) {
+	engine := NewEngine()
+
+	if engine.GetEvidence("db-1", "status") != nil {
+		t.Fatal("No evidence expected for an unknown fact")
+	}
+
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorA", 0.8))
+	engine.Reconcile(core.NewKpak("db-1", "status", "up", "MonitorB", 0.7))
+
+	evidence := engine.GetEvidence("db-1", "status")
+	if evidence.Confidence != 0.8 || len(evidence.Sources) != 1 || evidence.Sources[0] != "MonitorA" {
+		t.Fatalf("Default mode should report the winning claim only, got %+v", evidence)
+	}
+}
+
+func TestModeResolver_OverridesModePerPredicate(t *testing.T) {
+	engine := NewEngine()
+	engine.SetModeResolver(func(predicate string) Mode {
+		if predicate == "status" {
+			return ModeFusion
+		}
+		return ""
+	})
+
+	for _, predicate := range []string{"status", "owner"} {
+		engine.Reconcile(core.NewKpak("db-1", predicate, "a", "OldMonitor", 0.9))
+		engine.Reconcile(core.NewKpak("db-1", predicate, "b", "MonitorA", 0.7))
+		engine.Reconcile(core.NewKpak("db-1", predicate, "b", "MonitorB", 0.7))
+	}
+
+	if result := engine.QueryBySubjectPredicate("db-1", "status"); result.Object != "b" {
+		t.Fatalf("Expected fusion to pick 'b' for status, got '%v'", result.Object)
+	}
+	if result := engine.QueryBySubjectPredicate("db-1", "owner"); result.Object != "a" {
+		t.Fatalf("Expected the default mode to keep 'a' for owner, got '%v'", result.Object)
+	}
+}
@@ -0,0 +1,163 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func newMultiValuedEngine() *Engine {
+	engine := NewEngine()
+	engine.SetMultiValuedResolver(func(predicate string) bool {
+		return predicate == "has_tag"
+	})
+	return engine
+}
+
+func objects(kpaks []*core.Kpak) []string {
+	var result []string
+	for _, kpak := range kpaks {
+		result = append(result, core.FormatObject(kpak.Object))
+	}
+	return result
+}
+
+func TestMultiValued_MembersCoexist(t *testing.T) {
+	engine := newMultiValuedEngine()
+
+	for _, tag := range []string{"web", "prod", "eu"} {
+		if !engine.Reconcile(core.NewKpak("host-1", "has_tag", tag, "inventory", 0.9)) {
+			t.Fatalf("Member %q should be accepted", tag)
+		}
+	}
+	engine.Reconcile(core.NewKpak("host-1", "owner", "alice", "inventory", 0.9))
+
+	members := engine.QueryValues("host-1", "has_tag")
+	if got := objects(members); len(got) != 3 {
+		t.Fatalf("Expected 3 members, got %v", got)
+	}
+	if len(engine.QueryBySubject("host-1")) != 4 {
+		t.Fatal("Subject queries should return every member and the single-valued fact")
+	}
+	if engine.QueryBySubjectPredicate("host-1", "has_tag") != nil {
+		t.Fatal("A multi-valued predicate has no single truth")
+	}
+
+	// Single-valued predicates still overwrite
+	engine.Reconcile(core.NewKpak("host-1", "owner", "bob", "cmdb", 0.95))
+	if values := engine.QueryValues("host-1", "owner"); len(values) != 1 || values[0].Object != "bob" {
+		t.Fatalf("Expected a single owner 'bob', got %v", objects(values))
+	}
+}
+
+func TestMultiValued_ClaimsCompetePerMember(t *testing.T) {
+	engine := newMultiValuedEngine()
+
+	first := core.NewKpak("host-1", "has_tag", "web", "scanner", 0.6)
+	engine.Reconcile(first)
+	if outcome := engine.ReconcileOutcome(core.NewKpak("host-1", "has_tag", "web", "inventory", 0.9)); outcome != OutcomeAccepted {
+		t.Fatalf("A more trusted claim to the same member should win, got %v", outcome)
+	}
+	if outcome := engine.ReconcileOutcome(core.NewKpak("host-1", "has_tag", "db", "scanner", 0.1)); outcome != OutcomeAccepted {
+		t.Fatalf("A low-confidence claim to a new member should still be accepted, got %v", outcome)
+	}
+
+	members := engine.QueryValues("host-1", "has_tag")
+	if len(members) != 2 {
+		t.Fatalf("Expected 2 members, got %v", objects(members))
+	}
+	if len(engine.GetCandidates("host-1", "has_tag")) != 3 {
+		t.Fatal("Expected the claims of every member to be kept")
+	}
+
+	evidence := engine.GetEvidenceFor(members[1])
+	if evidence == nil || evidence.Sources[0] != "inventory" {
+		t.Fatalf("Expected evidence for the 'web' member from inventory, got %+v", evidence)
+	}
+}
+
+func TestMultiValued_MembersExpireOnTheirOwn(t *testing.T) {
+	engine := newMultiValuedEngine()
+
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "web", "inventory", 0.9))
+	expiring := core.NewKpak("host-1", "has_tag", "canary", "deployer", 0.9)
+	expiring.ExpiresAt = time.Now().Unix() - 1
+	engine.Reconcile(expiring)
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	if removed := engine.RemoveExpiredKpaks(); removed != 1 {
+		t.Fatalf("Expected 1 expired member, got %d", removed)
+	}
+	if got := objects(engine.QueryValues("host-1", "has_tag")); len(got) != 1 || got[0] != "web" {
+		t.Fatalf("Expected only 'web' to remain, got %v", got)
+	}
+	if len(changes) != 1 || changes[0].Type != ChangeExpired || changes[0].Previous.Object != "canary" {
+		t.Fatalf("Expected an expiry change for 'canary', got %+v", changes)
+	}
+}
+
+func TestMultiValued_RetractMember(t *testing.T) {
+	engine := newMultiValuedEngine()
+
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "web", "inventory", 0.9))
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "prod", "inventory", 0.9))
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "prod", "scanner", 0.5))
+
+	if retracted := engine.RetractMember("host-1", "has_tag", "inventory", core.CanonicalObject("web"), time.Now().Unix()); retracted != 1 {
+		t.Fatalf("Expected 1 claim retracted, got %d", retracted)
+	}
+	if got := objects(engine.QueryValues("host-1", "has_tag")); len(got) != 1 || got[0] != "prod" {
+		t.Fatalf("Expected only 'prod' to remain, got %v", got)
+	}
+
+	// Retracting everything a source said leaves members other sources support
+	if retracted := engine.Retract("host-1", "has_tag", "inventory", time.Now().Unix()); retracted != 1 {
+		t.Fatalf("Expected 1 claim retracted, got %d", retracted)
+	}
+	members := engine.QueryValues("host-1", "has_tag")
+	if len(members) != 1 || members[0].Source != "scanner" {
+		t.Fatalf("Expected scanner's 'prod' claim to be promoted, got %v", members)
+	}
+
+	if retracted := engine.RetractMember("host-1", "has_tag", "scanner", core.CanonicalObject("missing"), time.Now().Unix()); retracted != 0 {
+		t.Fatalf("Expected nothing retracted for an unknown member, got %d", retracted)
+	}
+}
+
+func TestRekey_CardinalityChange(t *testing.T) {
+	engine := NewEngine()
+	multiValued := false
+	engine.SetMultiValuedResolver(func(predicate string) bool {
+		return multiValued && predicate == "has_tag"
+	})
+
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "web", "inventory", 0.9))
+	engine.Reconcile(core.NewKpak("host-1", "has_tag", "prod", "scanner", 0.8))
+
+	multiValued = true
+	engine.Rekey("has_tag")
+	if got := objects(engine.QueryValues("host-1", "has_tag")); len(got) != 2 {
+		t.Fatalf("Expected both claims as members after the switch, got %v", got)
+	}
+	if retracted := engine.Retract("host-1", "has_tag", "inventory", time.Now().Unix()); retracted != 1 {
+		t.Fatalf("Expected the moved claim to be retracted, got %d", retracted)
+	}
+	if got := objects(engine.QueryBySubject("host-1")); len(got) != 1 || got[0] != "prod" {
+		t.Fatalf("Expected only 'prod' left, got %v", got)
+	}
+
+	multiValued = false
+	engine.Rekey("has_tag")
+	if truth := engine.QueryBySubjectPredicate("host-1", "has_tag"); truth == nil || truth.Object != "prod" {
+		t.Fatalf("Expected 'prod' as the single truth after switching back, got %v", truth)
+	}
+	engine.Retract("host-1", "has_tag", "scanner", time.Now().Unix())
+	if stats := engine.GetStats(); stats["total_subjects"].(int) != 0 {
+		t.Fatalf("Expected no truths left behind, got %v", engine.QueryBySubject("host-1"))
+	}
+}
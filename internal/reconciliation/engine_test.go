@@ -1,10 +1,16 @@
 package reconciliation
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
 	"github.com/Pew-X/sutra/internal/core"
 )
 
@@ -341,3 +347,59 @@ func TestTrustBasedReconciliation(t *testing.T) {
 		}
 	}
 }
+
+func TestEngine_ReconcileContext(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	otel.SetTracerProvider(provider)
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	engine := NewEngine()
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "Agent.Ingest")
+	if outcome := engine.ReconcileContext(ctx, core.NewKpak("server1", "cpu", "10", "scout", 0.9)); outcome != OutcomeAccepted {
+		t.Fatalf("Expected the first claim to be accepted, got %v", outcome)
+	}
+	parent.End()
+
+	// Untraced reconciliation, as during WAL replay, records nothing
+	engine.Reconcile(core.NewKpak("server1", "cpu", "20", "scout", 0.95))
+
+	spans := recorder.Ended()
+	if len(spans) != 2 || spans[0].Name() != "Engine.Reconcile" {
+		t.Fatalf("Expected a reconcile span and its parent, got %v", spans)
+	}
+	if spans[0].Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Fatal("Expected the reconcile span under the caller's span")
+	}
+	outcome := ""
+	for _, kv := range spans[0].Attributes() {
+		if kv.Key == "reconcile.outcome" {
+			outcome = kv.Value.AsString()
+		}
+	}
+	if outcome != "accepted" {
+		t.Fatalf("Expected the outcome on the span, got %q", outcome)
+	}
+}
+
+func TestEngine_Known(t *testing.T) {
+	engine := NewEngine()
+
+	truth := core.NewKpak("server1", "cpu", "10", "scout", 0.9)
+	runnerUp := core.NewKpak("server1", "cpu", "20", "probe", 0.5)
+	if engine.Known(truth) {
+		t.Fatal("Expected an unseen claim to be unknown")
+	}
+	engine.Reconcile(truth)
+	engine.Reconcile(runnerUp)
+
+	if !engine.Known(truth) || !engine.Known(runnerUp) {
+		t.Fatal("Expected the truth and its runner-up to be known")
+	}
+	if engine.Known(core.NewKpak("server1", "cpu", "30", "scout", 0.95)) {
+		t.Fatal("Expected a new claim on a known fact to be unknown")
+	}
+	if outcome := engine.ReconcileOutcome(truth); outcome != OutcomeRejected {
+		t.Fatalf("Expected a known claim to change nothing, got %v", outcome)
+	}
+}
@@ -0,0 +1,133 @@
+package reconciliation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// liftLifetime is how long a lifted pin is kept, and shared with peers, so a peer
+// that missed the lift learns of it before the record is dropped.
+const liftLifetime = 24 * time.Hour
+
+// Pin is an operator override that replaces the reconciled truth of a
+// subject+predicate until it expires (Kpak.ExpiresAt) or is lifted. Claims keep
+// being reconciled underneath, so the reconciled truth shows again afterwards.
+// Pins are shared by gossip; the most recent update of a subject+predicate wins.
+type Pin struct {
+	Kpak      *core.Kpak `json:"kpak"`              // The forced value, attributed to the operator
+	Operator  string     `json:"operator"`          // Who set (or lifted) the pin
+	Reason    string     `json:"reason"`            // Why
+	UpdatedAt int64      `json:"updated_at"`        // Unix nanoseconds of this update, for ordering
+	Removed   bool       `json:"removed,omitempty"` // Lifts an earlier pin
+}
+
+// Validate checks that the pin carries a fact and who set it.
+func (p *Pin) Validate() error {
+	if p.Kpak == nil || p.Kpak.Subject == "" || p.Kpak.Predicate == "" {
+		return fmt.Errorf("pin needs a subject and predicate")
+	}
+	if p.Operator == "" {
+		return fmt.Errorf("pin needs an operator")
+	}
+	return nil
+}
+
+// active reports whether the pin currently overrides the truth.
+func (p *Pin) active() bool {
+	return !p.Removed && !p.Kpak.IsExpired()
+}
+
+// newerThan orders pin updates by time, breaking ties by the pinned k-pak's ID so
+// every agent picks the same one.
+func (p *Pin) newerThan(other *Pin) bool {
+	if p.UpdatedAt != other.UpdatedAt {
+		return p.UpdatedAt > other.UpdatedAt
+	}
+	return p.Kpak.ID > other.Kpak.ID
+}
+
+// SetPin applies a pin update if it is newer than the one held for its
+// subject+predicate, and reports whether it was applied.
+func (e *Engine) SetPin(pin *Pin) (bool, error) {
+	if err := pin.Validate(); err != nil {
+		return false, err
+	}
+
+	e.mutex.Lock()
+
+	spid := spidFor(pin.Kpak.Subject, pin.Kpak.Predicate)
+	previous, exists := e.pins[spid]
+	if exists && !pin.newerThan(previous) {
+		e.mutex.Unlock()
+		return false, nil
+	}
+	e.pins[spid] = pin
+
+	var change *TruthChange
+	switch {
+	case pin.active():
+		change = &TruthChange{Type: ChangePinned, SPID: spid, Current: pin.Kpak}
+		if exists && previous.active() {
+			change.Previous = previous.Kpak
+		} else {
+			change.Previous = e.truthStore[spid]
+		}
+	case exists && previous.active():
+		change = &TruthChange{Type: ChangeUnpinned, SPID: spid, Previous: previous.Kpak, Current: e.truthStore[spid]}
+	}
+
+	handler := e.onChange
+	e.mutex.Unlock()
+
+	if change != nil && handler != nil {
+		handler(*change)
+	}
+	return true, nil
+}
+
+// GetPin returns the pin in force for a subject+predicate, or nil.
+func (e *Engine) GetPin(subject, predicate string) *Pin {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	return e.activePin(spidFor(subject, predicate))
+}
+
+// GetAllPins returns every pin update held, recent lifts included, for sharing with peers.
+func (e *Engine) GetAllPins() []*Pin {
+	e.mutex.RLock()
+	defer e.mutex.RUnlock()
+
+	pins := make([]*Pin, 0, len(e.pins))
+	for _, pin := range e.pins {
+		pins = append(pins, pin)
+	}
+	return pins
+}
+
+// activePin returns the pin in force for an SPID, or nil.
+func (e *Engine) activePin(spid string) *Pin {
+	if pin, exists := e.pins[spid]; exists && pin.active() {
+		return pin
+	}
+	return nil
+}
+
+// removeExpiredPins drops pins that have expired and lifts older than liftLifetime,
+// reporting the truth that shows again for those that were in force.
+func (e *Engine) removeExpiredPins(now time.Time) []TruthChange {
+	var changes []TruthChange
+	for spid, pin := range e.pins {
+		lifted := pin.Removed && now.Sub(time.Unix(0, pin.UpdatedAt)) > liftLifetime
+		if !pin.Kpak.IsExpired() && !lifted {
+			continue
+		}
+		delete(e.pins, spid)
+		if !pin.Removed {
+			changes = append(changes, TruthChange{Type: ChangeUnpinned, SPID: spid, Previous: pin.Kpak, Current: e.truthStore[spid]})
+		}
+	}
+	return changes
+}
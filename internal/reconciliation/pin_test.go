@@ -0,0 +1,157 @@
+package reconciliation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func newTestPin(subject, predicate string, object interface{}, operator string, updatedAt int64) *Pin {
+	return &Pin{
+		Kpak:      core.NewKpak(subject, predicate, object, operator, 1.0),
+		Operator:  operator,
+		Reason:    "maintenance",
+		UpdatedAt: updatedAt,
+	}
+}
+
+func TestPin_OverridesTruth(t *testing.T) {
+	engine := NewEngine()
+	engine.Reconcile(core.NewKpak("server1", "health", "up", "probe", 0.9))
+	engine.Reconcile(core.NewKpak("server1", "region", "eu", "probe", 0.9))
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+
+	applied, err := engine.SetPin(newTestPin("server1", "health", "down", "alice", 1))
+	if err != nil || !applied {
+		t.Fatalf("Expected the pin to apply, got %v, %v", applied, err)
+	}
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "down" {
+		t.Fatalf("Expected the pinned value, got %v", truth.Object)
+	}
+	if values := engine.QueryValues("server1", "health"); len(values) != 1 || values[0].Object != "down" {
+		t.Fatalf("Unexpected values %+v", values)
+	}
+	results := engine.QueryBySubject("server1")
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 facts for server1, got %d", len(results))
+	}
+	for _, kpak := range results {
+		if kpak.Predicate == "health" && kpak.Object != "down" {
+			t.Fatalf("Expected the subject query to show the pin, got %v", kpak.Object)
+		}
+	}
+	if len(changes) != 1 || changes[0].Type != ChangePinned || changes[0].Previous.Object != "up" {
+		t.Fatalf("Unexpected changes %+v", changes)
+	}
+
+	// Claims keep being reconciled underneath the pin
+	engine.Reconcile(core.NewKpak("server1", "health", "degraded", "probe-b", 0.95))
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "down" {
+		t.Fatalf("Expected the pin to hold, got %v", truth.Object)
+	}
+
+	// Lifting the pin shows the reconciled truth again
+	lift := newTestPin("server1", "health", "down", "alice", 2)
+	lift.Removed = true
+	if applied, _ := engine.SetPin(lift); !applied {
+		t.Fatal("Expected the pin to be lifted")
+	}
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "degraded" {
+		t.Fatalf("Expected the reconciled truth after unpinning, got %v", truth.Object)
+	}
+	if engine.GetPin("server1", "health") != nil {
+		t.Fatal("Expected no pin in force")
+	}
+	last := changes[len(changes)-1]
+	if last.Type != ChangeUnpinned || last.Current.Object != "degraded" {
+		t.Fatalf("Unexpected unpin change %+v", last)
+	}
+}
+
+func TestPin_LatestUpdateWins(t *testing.T) {
+	engine := NewEngine()
+
+	engine.SetPin(newTestPin("server1", "health", "down", "alice", 20))
+	if applied, _ := engine.SetPin(newTestPin("server1", "health", "up", "bob", 10)); applied {
+		t.Fatal("Expected an older pin to be ignored")
+	}
+	if pin := engine.GetPin("server1", "health"); pin.Operator != "alice" {
+		t.Fatalf("Expected alice's pin, got %+v", pin)
+	}
+
+	// A lift older than the pin does not remove it
+	stale := newTestPin("server1", "health", "down", "bob", 15)
+	stale.Removed = true
+	if applied, _ := engine.SetPin(stale); applied {
+		t.Fatal("Expected a stale lift to be ignored")
+	}
+	if len(engine.GetAllPins()) != 1 {
+		t.Fatalf("Expected one pin, got %d", len(engine.GetAllPins()))
+	}
+
+	if _, err := engine.SetPin(&Pin{Kpak: core.NewKpak("server1", "health", "up", "x", 1.0)}); err == nil {
+		t.Fatal("Expected a pin without an operator to be rejected")
+	}
+}
+
+func TestPin_ForFactWithoutClaims(t *testing.T) {
+	engine := NewEngine()
+	engine.SetPin(newTestPin("server1", "owner", "team-a", "alice", 1))
+
+	results := engine.QueryBySubject("server1")
+	if len(results) != 1 || results[0].Object != "team-a" {
+		t.Fatalf("Expected the pin to be returned, got %+v", results)
+	}
+}
+
+func TestPin_Expires(t *testing.T) {
+	engine := NewEngine()
+	engine.Reconcile(core.NewKpak("server1", "health", "up", "probe", 0.9))
+
+	pin := newTestPin("server1", "health", "down", "alice", 1)
+	pin.Kpak.ExpiresAt = time.Now().Unix() - 1
+	engine.SetPin(pin)
+
+	if truth := engine.QueryBySubjectPredicate("server1", "health"); truth.Object != "up" {
+		t.Fatalf("Expected an expired pin to be ignored, got %v", truth.Object)
+	}
+
+	var changes []TruthChange
+	engine.SetChangeHandler(func(change TruthChange) {
+		changes = append(changes, change)
+	})
+	engine.RemoveExpiredKpaks()
+	if len(engine.GetAllPins()) != 0 {
+		t.Fatal("Expected the expired pin to be removed")
+	}
+	if len(changes) != 1 || changes[0].Type != ChangeUnpinned || changes[0].Current.Object != "up" {
+		t.Fatalf("Unexpected changes %+v", changes)
+	}
+}
+
+func TestPin_LiftsAreDroppedAfterTheirLifetime(t *testing.T) {
+	engine := NewEngine()
+
+	old := time.Now().Add(-liftLifetime - time.Hour).UnixNano()
+	engine.SetPin(newTestPin("server1", "health", "down", "alice", old))
+	oldLift := newTestPin("server1", "health", "down", "alice", old+1)
+	oldLift.Removed = true
+	engine.SetPin(oldLift)
+
+	engine.SetPin(newTestPin("server2", "health", "down", "alice", time.Now().UnixNano()))
+	recentLift := newTestPin("server2", "health", "down", "alice", time.Now().UnixNano())
+	recentLift.Removed = true
+	engine.SetPin(recentLift)
+
+	// Neither pin had a TTL; only the lift older than its lifetime goes
+	engine.RemoveExpiredKpaks()
+	pins := engine.GetAllPins()
+	if len(pins) != 1 || pins[0].Kpak.Subject != "server2" || !pins[0].Removed {
+		t.Fatalf("Expected only the recent lift to be kept, got %+v", pins)
+	}
+}
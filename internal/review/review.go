@@ -0,0 +1,359 @@
+package review
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// Defaults used for unset config values.
+const (
+	DefaultMaxPending = 10000
+	DefaultMaxDecided = 1000
+)
+
+// Status is the review state of a held claim.
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusRejected Status = "rejected"
+)
+
+// ParseStatus maps a status name to a Status; empty means pending.
+func ParseStatus(s string) (Status, error) {
+	switch Status(s) {
+	case "":
+		return StatusPending, nil
+	case StatusPending, StatusApproved, StatusRejected:
+		return Status(s), nil
+	}
+	return "", fmt.Errorf("unknown review status %q (want pending, approved or rejected)", s)
+}
+
+// Rule decides which claims are held for review. A claim is held if any rule
+// whose Predicate matches it has a criterion the claim meets.
+type Rule struct {
+	Predicate      string  `yaml:"predicate"`       // Predicate name or path.Match pattern (empty = every predicate)
+	MinConfidence  float32 `yaml:"min_confidence"`  // Hold claims below this confidence
+	UnknownSources bool    `yaml:"unknown_sources"` // Hold claims from sources with no admitted claim yet
+	Always         bool    `yaml:"always"`          // Hold every claim (sensitive predicates)
+}
+
+// Validate checks that the rule's pattern is valid and that it holds something.
+func (r Rule) Validate() error {
+	if _, err := path.Match(r.Predicate, ""); err != nil {
+		return fmt.Errorf("invalid review pattern %q: %w", r.Predicate, err)
+	}
+	if r.MinConfidence < 0 || r.MinConfidence > 1 {
+		return fmt.Errorf("review rule %q: min_confidence must be between 0 and 1", r.Predicate)
+	}
+	if r.MinConfidence == 0 && !r.UnknownSources && !r.Always {
+		return fmt.Errorf("review rule %q: needs min_confidence, unknown_sources or always", r.Predicate)
+	}
+	return nil
+}
+
+func (r Rule) matches(predicate string) bool {
+	if r.Predicate == "" {
+		return true
+	}
+	matched, _ := path.Match(r.Predicate, predicate)
+	return matched
+}
+
+// Config holds review queue settings. Without rules every claim is admitted.
+type Config struct {
+	Rules        []Rule   `yaml:"rules"`
+	KnownSources []string `yaml:"known_sources"` // Sources treated as already seen by unknown_sources rules
+	MaxPending   int      `yaml:"max_pending"`   // Claims held at once (0 = DefaultMaxPending)
+	MaxDecided   int      `yaml:"max_decided"`   // Decided claims kept for listing (0 = DefaultMaxDecided)
+}
+
+// Validate checks every rule.
+func (c Config) Validate() error {
+	for _, rule := range c.Rules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Item is a claim held for review and, once decided, the decision. Items are
+// shared by gossip so a claim can be reviewed on any agent.
+type Item struct {
+	ID        string     `json:"id"` // ID of the held k-pak
+	Kpak      *core.Kpak `json:"kpak"`
+	Reasons   []string   `json:"reasons"`
+	Status    Status     `json:"status"`
+	HeldAt    time.Time  `json:"held_at"`
+	HeldBy    string     `json:"held_by"` // Agent that held the claim
+	Reviewer  string     `json:"reviewer,omitempty"`
+	Note      string     `json:"note,omitempty"`
+	DecidedAt time.Time  `json:"decided_at,omitempty"`
+}
+
+// supersedes reports whether an update for the same claim replaces the held item.
+// A decision replaces a pending item, and between two decisions the earlier one
+// stands (a rejection on a tie), so every agent settles on the same outcome.
+func (i *Item) supersedes(held *Item) bool {
+	if i.Status == StatusPending {
+		return false
+	}
+	if held.Status == StatusPending {
+		return true
+	}
+	if !i.DecidedAt.Equal(held.DecidedAt) {
+		return i.DecidedAt.Before(held.DecidedAt)
+	}
+	return i.Status == StatusRejected && held.Status != StatusRejected
+}
+
+// Queue holds claims awaiting review and remembers recent decisions.
+type Queue struct {
+	config Config
+	items  map[string]*Item
+	known  map[string]bool // Sources with an admitted claim
+	mutex  sync.RWMutex
+}
+
+// NewQueue creates a review queue.
+func NewQueue(config Config) (*Queue, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.MaxPending <= 0 {
+		config.MaxPending = DefaultMaxPending
+	}
+	if config.MaxDecided <= 0 {
+		config.MaxDecided = DefaultMaxDecided
+	}
+
+	q := &Queue{
+		config: config,
+		items:  make(map[string]*Item),
+		known:  make(map[string]bool),
+	}
+	for _, source := range config.KnownSources {
+		q.known[source] = true
+	}
+	return q, nil
+}
+
+// Learn records that a claim from source was admitted, so unknown_sources rules
+// stop holding its claims.
+func (q *Queue) Learn(source string) {
+	if len(q.config.Rules) == 0 {
+		return
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+	q.known[source] = true
+}
+
+// Screen returns why a claim must be held for review, or nil if it may be
+// reconciled. A claim that was already approved is always admitted.
+func (q *Queue) Screen(kpak *core.Kpak) []string {
+	if len(q.config.Rules) == 0 {
+		return nil
+	}
+
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	if item, exists := q.items[kpak.ID]; exists && item.Status == StatusApproved {
+		return nil
+	}
+
+	var reasons []string
+	add := func(reason string) {
+		for _, r := range reasons {
+			if r == reason {
+				return
+			}
+		}
+		reasons = append(reasons, reason)
+	}
+	for _, rule := range q.config.Rules {
+		if !rule.matches(kpak.Predicate) {
+			continue
+		}
+		if rule.Always {
+			add(fmt.Sprintf("predicate %q requires review", kpak.Predicate))
+		}
+		if rule.MinConfidence > 0 && kpak.Confidence < rule.MinConfidence {
+			add(fmt.Sprintf("confidence %.2f is below %.2f", kpak.Confidence, rule.MinConfidence))
+		}
+		if rule.UnknownSources && !q.known[kpak.Source] {
+			add(fmt.Sprintf("first claim from source %q", kpak.Source))
+		}
+	}
+	return reasons
+}
+
+// Hold queues a claim for review. If the claim is already known the existing item
+// is returned, decided or not, and held is false.
+func (q *Queue) Hold(kpak *core.Kpak, reasons []string, heldBy string) (item *Item, held bool, err error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if existing, exists := q.items[kpak.ID]; exists {
+		return existing, false, nil
+	}
+	if q.countLocked(StatusPending) >= q.config.MaxPending {
+		return nil, false, fmt.Errorf("review queue is full (%d claims pending)", q.config.MaxPending)
+	}
+
+	item = &Item{
+		ID:      kpak.ID,
+		Kpak:    kpak,
+		Reasons: reasons,
+		Status:  StatusPending,
+		HeldAt:  time.Now(),
+		HeldBy:  heldBy,
+	}
+	q.items[item.ID] = item
+	return item, true, nil
+}
+
+// Decide approves or rejects a pending claim, found by its ID or a unique prefix
+// of it, and returns the decided item.
+func (q *Queue) Decide(id string, approve bool, reviewer, note string) (*Item, error) {
+	if reviewer == "" {
+		return nil, fmt.Errorf("reviewer is required")
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	held, err := q.findLocked(id)
+	if err != nil {
+		return nil, err
+	}
+	if held.Status != StatusPending {
+		return nil, fmt.Errorf("claim %s was already %s by %s", held.ID, held.Status, held.Reviewer)
+	}
+
+	decided := *held
+	decided.Status = StatusRejected
+	if approve {
+		decided.Status = StatusApproved
+	}
+	decided.Reviewer = reviewer
+	decided.Note = note
+	decided.DecidedAt = time.Now()
+
+	q.items[decided.ID] = &decided
+	q.pruneLocked()
+	return &decided, nil
+}
+
+// Merge applies an item received from a peer or replayed from the log, and
+// reports whether it changed the queue.
+func (q *Queue) Merge(item *Item) bool {
+	if item == nil || item.Kpak == nil || item.ID == "" {
+		return false
+	}
+
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if held, exists := q.items[item.ID]; exists && !item.supersedes(held) {
+		return false
+	}
+	q.items[item.ID] = item
+	q.pruneLocked()
+	return true
+}
+
+// Get returns the item for a claim ID, or nil.
+func (q *Queue) Get(id string) *Item {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return q.items[id]
+}
+
+// Find returns the item for a claim ID or a unique prefix of it.
+func (q *Queue) Find(id string) (*Item, error) {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+	return q.findLocked(id)
+}
+
+// List returns the items with the given status (all items if empty), oldest first.
+func (q *Queue) List(status Status) []*Item {
+	q.mutex.RLock()
+	defer q.mutex.RUnlock()
+
+	var items []*Item
+	for _, item := range q.items {
+		if status == "" || item.Status == status {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].HeldAt.Equal(items[j].HeldAt) {
+			return items[i].HeldAt.Before(items[j].HeldAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+	return items
+}
+
+// findLocked looks an item up by ID or unique ID prefix.
+func (q *Queue) findLocked(id string) (*Item, error) {
+	if item, exists := q.items[id]; exists {
+		return item, nil
+	}
+	var found *Item
+	for key, item := range q.items {
+		if id == "" || !strings.HasPrefix(key, id) {
+			continue
+		}
+		if found != nil {
+			return nil, fmt.Errorf("claim ID prefix %q is ambiguous", id)
+		}
+		found = item
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no claim %q in the review queue", id)
+	}
+	return found, nil
+}
+
+func (q *Queue) countLocked(status Status) int {
+	count := 0
+	for _, item := range q.items {
+		if item.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// pruneLocked forgets the oldest decisions beyond MaxDecided.
+func (q *Queue) pruneLocked() {
+	var decided []*Item
+	for _, item := range q.items {
+		if item.Status != StatusPending {
+			decided = append(decided, item)
+		}
+	}
+	if len(decided) <= q.config.MaxDecided {
+		return
+	}
+	sort.Slice(decided, func(i, j int) bool {
+		return decided[i].DecidedAt.Before(decided[j].DecidedAt)
+	})
+	for _, item := range decided[:len(decided)-q.config.MaxDecided] {
+		delete(q.items, item.ID)
+	}
+}
@@ -0,0 +1,179 @@
+package review
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+func TestRule_Validate(t *testing.T) {
+	valid := []Rule{
+		{Predicate: "status", Always: true},
+		{MinConfidence: 0.5},
+		{Predicate: "health_*", UnknownSources: true},
+	}
+	for _, rule := range valid {
+		if err := rule.Validate(); err != nil {
+			t.Fatalf("Expected rule %+v to be valid, got %v", rule, err)
+		}
+	}
+
+	invalid := []Rule{
+		{Predicate: "status"},
+		{Predicate: "[bad", Always: true},
+		{MinConfidence: 1.5},
+	}
+	for _, rule := range invalid {
+		if err := rule.Validate(); err == nil {
+			t.Fatalf("Expected rule %+v to be invalid", rule)
+		}
+	}
+}
+
+func TestQueue_Screen(t *testing.T) {
+	queue, err := NewQueue(Config{
+		Rules: []Rule{
+			{MinConfidence: 0.6},
+			{Predicate: "owner", Always: true},
+			{Predicate: "health_*", UnknownSources: true},
+		},
+		KnownSources: []string{"probe-a"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+
+	if reasons := queue.Screen(core.NewKpak("server1", "region", "eu", "scout", 0.9)); reasons != nil {
+		t.Fatalf("Expected a confident claim to be admitted, got %v", reasons)
+	}
+	if reasons := queue.Screen(core.NewKpak("server1", "region", "eu", "scout", 0.4)); len(reasons) != 1 {
+		t.Fatalf("Expected a low-confidence claim to be held, got %v", reasons)
+	}
+	if reasons := queue.Screen(core.NewKpak("server1", "owner", "team-a", "scout", 0.9)); len(reasons) != 1 {
+		t.Fatalf("Expected a sensitive predicate to be held, got %v", reasons)
+	}
+	if reasons := queue.Screen(core.NewKpak("server1", "health_http", "up", "probe-a", 0.9)); reasons != nil {
+		t.Fatalf("Expected a known source to be admitted, got %v", reasons)
+	}
+
+	unknown := core.NewKpak("server1", "health_http", "up", "ai-scout", 0.5)
+	if reasons := queue.Screen(unknown); len(reasons) != 2 {
+		t.Fatalf("Expected both matching rules to give a reason, got %v", reasons)
+	}
+	queue.Learn("ai-scout")
+	if reasons := queue.Screen(core.NewKpak("server1", "health_http", "up", "ai-scout", 0.9)); reasons != nil {
+		t.Fatalf("Expected a learned source to be admitted, got %v", reasons)
+	}
+}
+
+func TestQueue_HoldAndDecide(t *testing.T) {
+	queue, err := NewQueue(Config{Rules: []Rule{{MinConfidence: 0.6}}})
+	if err != nil {
+		t.Fatalf("Failed to create queue: %v", err)
+	}
+
+	kpak := core.NewKpak("server1", "status", "down", "ai-scout", 0.3)
+	item, held, err := queue.Hold(kpak, queue.Screen(kpak), "agent-a")
+	if err != nil || !held || item.Status != StatusPending {
+		t.Fatalf("Expected the claim to be held, got %+v, %v, %v", item, held, err)
+	}
+	if _, held, _ := queue.Hold(kpak, nil, "agent-a"); held {
+		t.Fatal("Expected a claim already in the queue not to be held twice")
+	}
+	if len(queue.List(StatusPending)) != 1 {
+		t.Fatal("Expected one pending claim")
+	}
+
+	if found, err := queue.Find(kpak.ID[:8]); err != nil || found.ID != kpak.ID {
+		t.Fatalf("Expected to find the claim by prefix, got %+v, %v", found, err)
+	}
+	if _, err := queue.Decide(kpak.ID[:8], true, "", ""); err == nil {
+		t.Fatal("Expected a decision without a reviewer to fail")
+	}
+	if _, err := queue.Decide("nope", true, "alice", ""); err == nil {
+		t.Fatal("Expected an unknown claim to fail")
+	}
+	decided, err := queue.Decide(kpak.ID[:8], true, "alice", "checked the console")
+	if err != nil {
+		t.Fatalf("Decide failed: %v", err)
+	}
+	if decided.Status != StatusApproved || decided.Reviewer != "alice" || decided.DecidedAt.IsZero() {
+		t.Fatalf("Unexpected decision %+v", decided)
+	}
+	if _, err := queue.Decide(kpak.ID, false, "bob", ""); err == nil {
+		t.Fatal("Expected a decided claim not to be decided again")
+	}
+
+	// An approved claim is admitted when it is sent again
+	if reasons := queue.Screen(kpak); reasons != nil {
+		t.Fatalf("Expected the approved claim to be admitted, got %v", reasons)
+	}
+	if len(queue.List(StatusPending)) != 0 || len(queue.List("")) != 1 {
+		t.Fatal("Expected the claim to have left the pending list")
+	}
+}
+
+func TestQueue_Merge(t *testing.T) {
+	queue, _ := NewQueue(Config{})
+
+	kpak := core.NewKpak("server1", "status", "down", "ai-scout", 0.3)
+	pending := &Item{ID: kpak.ID, Kpak: kpak, Status: StatusPending, HeldAt: time.Now()}
+	if !queue.Merge(pending) {
+		t.Fatal("Expected a new item to be merged")
+	}
+	if queue.Merge(pending) {
+		t.Fatal("Expected a repeated item to change nothing")
+	}
+
+	now := time.Now()
+	rejected := *pending
+	rejected.Status, rejected.Reviewer, rejected.DecidedAt = StatusRejected, "bob", now.Add(time.Second)
+	approved := *pending
+	approved.Status, approved.Reviewer, approved.DecidedAt = StatusApproved, "alice", now
+
+	if !queue.Merge(&rejected) {
+		t.Fatal("Expected a decision to replace the pending item")
+	}
+	if !queue.Merge(&approved) {
+		t.Fatal("Expected the earlier decision to win")
+	}
+	if queue.Merge(&rejected) || queue.Merge(pending) {
+		t.Fatal("Expected later decisions and pending copies to be ignored")
+	}
+	if item := queue.Get(kpak.ID); item.Status != StatusApproved || item.Reviewer != "alice" {
+		t.Fatalf("Unexpected item %+v", item)
+	}
+}
+
+func TestQueue_Limits(t *testing.T) {
+	queue, _ := NewQueue(Config{Rules: []Rule{{Always: true}}, MaxPending: 2, MaxDecided: 1})
+
+	var ids []string
+	for i, object := range []string{"a", "b", "c"} {
+		kpak := core.NewKpak("server1", "status", object, "scout", 0.9)
+		_, _, err := queue.Hold(kpak, []string{"always"}, "agent-a")
+		if i < 2 && err != nil {
+			t.Fatalf("Hold failed: %v", err)
+		}
+		if i == 2 && err == nil {
+			t.Fatal("Expected a full queue to refuse the claim")
+		}
+		ids = append(ids, kpak.ID)
+	}
+
+	queue.Decide(ids[0], false, "alice", "")
+	queue.Decide(ids[1], false, "alice", "")
+	if len(queue.List("")) != 1 || queue.Get(ids[1]) == nil {
+		t.Fatalf("Expected only the latest decision to be kept, got %d items", len(queue.List("")))
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	if status, err := ParseStatus(""); err != nil || status != StatusPending {
+		t.Fatalf("Expected pending by default, got %v, %v", status, err)
+	}
+	if _, err := ParseStatus("maybe"); err == nil {
+		t.Fatal("Expected an unknown status to be refused")
+	}
+}
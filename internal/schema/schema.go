@@ -0,0 +1,402 @@
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+)
+
+// Cardinality declares whether a predicate holds one value or a set of values.
+type Cardinality string
+
+const (
+	CardinalitySingle Cardinality = "single" // One truth per subject+predicate (default)
+	CardinalityMulti  Cardinality = "multi"  // A set of values per subject+predicate
+)
+
+// Schema is the contract for every predicate matching Predicate, which is either an
+// exact predicate name or a path.Match pattern ("health_*").
+type Schema struct {
+	Predicate         string            `yaml:"predicate" json:"predicate"`
+	Type              core.ValueType    `yaml:"type" json:"type,omitempty"`                               // Required object type ("" = any)
+	Enum              []string          `yaml:"enum" json:"enum,omitempty"`                               // Allowed values, matched case-insensitively
+	Aliases           map[string]string `yaml:"aliases" json:"aliases,omitempty"`                         // Alternative spellings mapped to an enum value
+	Min               *float64          `yaml:"min" json:"min,omitempty"`                                 // Lowest allowed numeric value
+	Max               *float64          `yaml:"max" json:"max,omitempty"`                                 // Highest allowed numeric value
+	Cardinality       Cardinality       `yaml:"cardinality" json:"cardinality,omitempty"`                 // "single" (default) or "multi"
+	DefaultTTLSeconds int64             `yaml:"default_ttl_seconds" json:"default_ttl_seconds,omitempty"` // TTL for claims that carry no expiry
+	Resolver          string            `yaml:"resolver" json:"resolver,omitempty"`                       // Reconciliation mode ("" = agent default)
+	CRDT              crdt.Type         `yaml:"crdt" json:"crdt,omitempty"`                               // Merge updates as this CRDT instead of picking a winner
+	Version           int64             `yaml:"version" json:"version"`                                   // Higher versions replace lower ones mesh-wide
+}
+
+// Validate checks that the schema itself is well formed.
+func (s *Schema) Validate() error {
+	if s.Predicate == "" {
+		return fmt.Errorf("schema needs a predicate")
+	}
+	if _, err := path.Match(s.Predicate, ""); err != nil {
+		return fmt.Errorf("invalid schema predicate %q: %w", s.Predicate, err)
+	}
+
+	switch s.Type {
+	case "", core.ValueString, core.ValueInt, core.ValueFloat, core.ValueBool,
+		core.ValueBytes, core.ValueTimestamp, core.ValueJSON, core.ValueRef:
+	default:
+		return fmt.Errorf("schema %q: unknown type %q", s.Predicate, s.Type)
+	}
+
+	if len(s.Enum) > 0 && s.Type != "" && s.Type != core.ValueString && s.Type != core.ValueRef {
+		return fmt.Errorf("schema %q: enum values need type string or ref", s.Predicate)
+	}
+	for alias, target := range s.Aliases {
+		if s.enumValue(target) == "" {
+			return fmt.Errorf("schema %q: alias %q maps to %q, which is not an enum value", s.Predicate, alias, target)
+		}
+	}
+
+	if s.Min != nil || s.Max != nil {
+		if s.Type != core.ValueInt && s.Type != core.ValueFloat {
+			return fmt.Errorf("schema %q: min and max need type int or float", s.Predicate)
+		}
+		if s.Min != nil && s.Max != nil && *s.Min > *s.Max {
+			return fmt.Errorf("schema %q: min %v is greater than max %v", s.Predicate, *s.Min, *s.Max)
+		}
+	}
+
+	switch s.Cardinality {
+	case "", CardinalitySingle, CardinalityMulti:
+	default:
+		return fmt.Errorf("schema %q: unknown cardinality %q", s.Predicate, s.Cardinality)
+	}
+
+	if s.DefaultTTLSeconds < 0 {
+		return fmt.Errorf("schema %q: default_ttl_seconds must not be negative", s.Predicate)
+	}
+	if _, err := reconciliation.ParseMode(s.Resolver); err != nil {
+		return fmt.Errorf("schema %q: %w", s.Predicate, err)
+	}
+
+	if _, err := crdt.ParseType(string(s.CRDT)); err != nil {
+		return fmt.Errorf("schema %q: %w", s.Predicate, err)
+	}
+	if s.CRDT != "" {
+		if s.Cardinality == CardinalityMulti || s.Resolver != "" {
+			return fmt.Errorf("schema %q: CRDT predicates cannot also set cardinality multi or a resolver", s.Predicate)
+		}
+		if s.CRDT.IsCounter() && s.Type != "" && s.Type != core.ValueInt {
+			return fmt.Errorf("schema %q: %s updates must have type int", s.Predicate, s.CRDT)
+		}
+		if s.CRDT == crdt.LWWMap && s.Type != "" && s.Type != core.ValueJSON {
+			return fmt.Errorf("schema %q: lww_map updates must have type json", s.Predicate)
+		}
+	}
+
+	return nil
+}
+
+// Normalize checks an object against the schema and returns it in its canonical
+// form: strings are converted to the declared type and enum values take their
+// declared spelling, so "DOWN", "down" and an alias "0" all become the same truth.
+func (s *Schema) Normalize(object interface{}) (interface{}, error) {
+	object, err := s.coerce(object)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(s.Enum) > 0 {
+		text := core.FormatObject(object)
+		if target, ok := s.alias(text); ok {
+			text = target
+		}
+		value := s.enumValue(text)
+		if value == "" {
+			return nil, fmt.Errorf("value %q is not one of %s", core.FormatObject(object), strings.Join(s.Enum, ", "))
+		}
+		if _, isRef := object.(core.Ref); isRef {
+			object = core.Ref(value)
+		} else {
+			object = value
+		}
+	}
+
+	if s.Min != nil || s.Max != nil {
+		number := reflect.ValueOf(object)
+		var n float64
+		if number.CanInt() {
+			n = float64(number.Int())
+		} else if number.CanUint() {
+			n = float64(number.Uint())
+		} else {
+			n = number.Float()
+		}
+		if s.Min != nil && n < *s.Min {
+			return nil, fmt.Errorf("value %v is below the minimum %v", object, *s.Min)
+		}
+		if s.Max != nil && n > *s.Max {
+			return nil, fmt.Errorf("value %v is above the maximum %v", object, *s.Max)
+		}
+	}
+
+	return object, nil
+}
+
+// coerce converts an object to the schema's type. Clients that only send the plain
+// string object are parsed; ints are widened to floats. Anything else must match.
+func (s *Schema) coerce(object interface{}) (interface{}, error) {
+	expected := s.valueType()
+	actual := core.TypeOf(object)
+	if expected == "" || actual == expected {
+		return object, nil
+	}
+
+	text, isString := object.(string)
+	switch {
+	case expected == core.ValueFloat && actual == core.ValueInt:
+		number := reflect.ValueOf(object)
+		if number.CanInt() {
+			return float64(number.Int()), nil
+		}
+		return float64(number.Uint()), nil
+	case !isString:
+		return nil, fmt.Errorf("expected a %s value, got %s", expected, actual)
+	}
+
+	var (
+		coerced interface{}
+		err     error
+	)
+	switch expected {
+	case core.ValueInt:
+		coerced, err = strconv.ParseInt(strings.TrimSpace(text), 10, 64)
+	case core.ValueFloat:
+		var f float64
+		f, err = strconv.ParseFloat(strings.TrimSpace(text), 64)
+		coerced = f
+		if err == nil {
+			err = core.ValidateObject(f)
+		}
+	case core.ValueBool:
+		coerced, err = strconv.ParseBool(strings.TrimSpace(text))
+	case core.ValueTimestamp:
+		coerced, err = time.Parse(time.RFC3339Nano, strings.TrimSpace(text))
+	case core.ValueJSON:
+		coerced = core.JSON(text)
+		err = core.ValidateObject(coerced)
+	case core.ValueRef:
+		coerced = core.Ref(text)
+	default:
+		err = fmt.Errorf("cannot convert a string")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("expected a %s value, got %q", expected, text)
+	}
+	return coerced, nil
+}
+
+// valueType returns the type objects must have, implied by the CRDT when not declared.
+func (s *Schema) valueType() core.ValueType {
+	switch {
+	case s.Type != "":
+		return s.Type
+	case s.CRDT.IsCounter():
+		return core.ValueInt
+	case s.CRDT == crdt.LWWMap:
+		return core.ValueJSON
+	}
+	return ""
+}
+
+// alias looks up an alternative spelling, ignoring case.
+func (s *Schema) alias(text string) (string, bool) {
+	for alias, target := range s.Aliases {
+		if strings.EqualFold(alias, text) {
+			return target, true
+		}
+	}
+	return "", false
+}
+
+// enumValue returns the declared spelling of an enum value, or "" if it is not allowed.
+func (s *Schema) enumValue(text string) string {
+	for _, value := range s.Enum {
+		if strings.EqualFold(value, text) {
+			return value
+		}
+	}
+	return ""
+}
+
+// fingerprint is a stable encoding used to order different schemas of the same version.
+func (s *Schema) fingerprint() string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
+
+// matches reports whether the schema applies to a predicate.
+func (s *Schema) matches(predicate string) bool {
+	if s.Predicate == predicate {
+		return true
+	}
+	matched, _ := path.Match(s.Predicate, predicate)
+	return matched
+}
+
+// Registry holds the schemas known to an agent.
+type Registry struct {
+	schemas map[string]*Schema // predicate or pattern -> schema
+	mutex   sync.RWMutex
+}
+
+// NewRegistry creates an empty schema registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// Put validates and stores a schema. It returns false when the registry already holds
+// the same or a newer definition for the predicate. Definitions with the same version
+// are ordered by content, so every agent settles on the same one.
+func (r *Registry) Put(schema *Schema) (bool, error) {
+	if err := schema.Validate(); err != nil {
+		return false, err
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if existing, exists := r.schemas[schema.Predicate]; exists {
+		if schema.Version < existing.Version {
+			return false, nil
+		}
+		if schema.Version == existing.Version && schema.fingerprint() <= existing.fingerprint() {
+			return false, nil
+		}
+	}
+
+	stored := *schema
+	r.schemas[schema.Predicate] = &stored
+	return true, nil
+}
+
+// Get returns the schema governing a predicate, or nil if there is none. An exact
+// definition wins over patterns; among patterns the longest (most specific) wins.
+func (r *Registry) Get(predicate string) *Schema {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if schema, exists := r.schemas[predicate]; exists {
+		return schema
+	}
+
+	var best *Schema
+	for _, schema := range r.schemas {
+		if !schema.matches(predicate) {
+			continue
+		}
+		if best == nil || len(schema.Predicate) > len(best.Predicate) ||
+			(len(schema.Predicate) == len(best.Predicate) && schema.Predicate < best.Predicate) {
+			best = schema
+		}
+	}
+	return best
+}
+
+// List returns all schemas ordered by predicate.
+func (r *Registry) List() []*Schema {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result := make([]*Schema, 0, len(r.schemas))
+	for _, schema := range r.schemas {
+		result = append(result, schema)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Predicate < result[j].Predicate
+	})
+	return result
+}
+
+// Validate checks a k-pak against the schema for its predicate and rewrites its
+// object into canonical form. K-paks for predicates without a schema pass unchanged.
+func (r *Registry) Validate(kpak *core.Kpak) error {
+	object, err := r.NormalizeObject(kpak.Predicate, kpak.Object)
+	if err != nil {
+		return err
+	}
+
+	if core.CanonicalObject(object) != kpak.ObjectKey() {
+		kpak.Object = object
+		kpak.RegenerateComputedFields()
+	}
+	return nil
+}
+
+// NormalizeObject checks an object against the schema for a predicate and returns it
+// in canonical form. Objects of predicates without a schema are returned unchanged.
+func (r *Registry) NormalizeObject(predicate string, object interface{}) (interface{}, error) {
+	schema := r.Get(predicate)
+	if schema == nil {
+		return object, nil
+	}
+
+	normalized, err := schema.Normalize(object)
+	if err != nil {
+		return nil, fmt.Errorf("predicate %q: %w", predicate, err)
+	}
+	return normalized, nil
+}
+
+// MultiValued reports whether a predicate is declared to hold a set of values.
+func (r *Registry) MultiValued(predicate string) bool {
+	if schema := r.Get(predicate); schema != nil {
+		return schema.Cardinality == CardinalityMulti
+	}
+	return false
+}
+
+// CRDT returns the CRDT type declared for a predicate, or "" for ordinary predicates.
+func (r *Registry) CRDT(predicate string) crdt.Type {
+	if schema := r.Get(predicate); schema != nil {
+		return schema.CRDT
+	}
+	return ""
+}
+
+// Version returns the version of the schema defined for exactly this predicate or pattern, or 0.
+func (r *Registry) Version(predicate string) int64 {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	if schema, exists := r.schemas[predicate]; exists {
+		return schema.Version
+	}
+	return 0
+}
+
+// DefaultTTL returns the default TTL declared for a predicate, or 0.
+func (r *Registry) DefaultTTL(predicate string) int64 {
+	if schema := r.Get(predicate); schema != nil {
+		return schema.DefaultTTLSeconds
+	}
+	return 0
+}
+
+// Resolver returns the reconciliation mode declared for a predicate, or "" to use the default.
+func (r *Registry) Resolver(predicate string) reconciliation.Mode {
+	if schema := r.Get(predicate); schema != nil {
+		return reconciliation.Mode(schema.Resolver)
+	}
+	return ""
+}
@@ -0,0 +1,209 @@
+package schema
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+)
+
+func float(f float64) *float64 {
+	return &f
+}
+
+func TestSchema_Validate(t *testing.T) {
+	valid := []Schema{
+		{Predicate: "status", Type: core.ValueString, Enum: []string{"up", "down"}, Aliases: map[string]string{"0": "down"}},
+		{Predicate: "cpu_*", Type: core.ValueFloat, Min: float(0), Max: float(100)},
+		{Predicate: "tags", Cardinality: CardinalityMulti, DefaultTTLSeconds: 60, Resolver: "fusion"},
+		{Predicate: "incidents", CRDT: crdt.GCounter},
+		{Predicate: "node_load", CRDT: crdt.LWWMap, Type: core.ValueJSON},
+	}
+	for _, s := range valid {
+		if err := s.Validate(); err != nil {
+			t.Fatalf("Expected schema %q to be valid, got %v", s.Predicate, err)
+		}
+	}
+
+	invalid := []Schema{
+		{},
+		{Predicate: "[bad"},
+		{Predicate: "status", Type: "colour"},
+		{Predicate: "status", Type: core.ValueInt, Enum: []string{"1"}},
+		{Predicate: "status", Enum: []string{"up"}, Aliases: map[string]string{"0": "down"}},
+		{Predicate: "cpu", Type: core.ValueString, Min: float(0)},
+		{Predicate: "cpu", Type: core.ValueFloat, Min: float(10), Max: float(1)},
+		{Predicate: "tags", Cardinality: "several"},
+		{Predicate: "tags", DefaultTTLSeconds: -1},
+		{Predicate: "tags", Resolver: "coin_flip"},
+		{Predicate: "tags", CRDT: "mv_register"},
+		{Predicate: "tags", CRDT: crdt.ORSet, Cardinality: CardinalityMulti},
+		{Predicate: "incidents", CRDT: crdt.GCounter, Resolver: "fusion"},
+		{Predicate: "incidents", CRDT: crdt.PNCounter, Type: core.ValueFloat},
+		{Predicate: "node_load", CRDT: crdt.LWWMap, Type: core.ValueString},
+	}
+	for _, s := range invalid {
+		if err := s.Validate(); err == nil {
+			t.Fatalf("Expected schema %+v to be invalid", s)
+		}
+	}
+}
+
+func TestSchema_NormalizeEnum(t *testing.T) {
+	s := &Schema{Predicate: "status", Enum: []string{"up", "down"}, Aliases: map[string]string{"0": "down"}}
+
+	for _, input := range []interface{}{"DOWN", "down", "Down", "0"} {
+		object, err := s.Normalize(input)
+		if err != nil {
+			t.Fatalf("Expected %v to be accepted, got %v", input, err)
+		}
+		if object != "down" {
+			t.Fatalf("Expected %v to normalize to 'down', got %v", input, object)
+		}
+	}
+
+	if _, err := s.Normalize("sideways"); err == nil {
+		t.Fatal("Expected a value outside the enum to be rejected")
+	}
+}
+
+func TestSchema_NormalizeTypesAndRanges(t *testing.T) {
+	s := &Schema{Predicate: "cpu", Type: core.ValueFloat, Min: float(0), Max: float(100)}
+
+	object, err := s.Normalize("42.5")
+	if err != nil || object != 42.5 {
+		t.Fatalf("Expected string '42.5' to become 42.5, got %v (%v)", object, err)
+	}
+	object, err = s.Normalize(int64(7))
+	if err != nil || object != 7.0 {
+		t.Fatalf("Expected int 7 to widen to 7.0, got %v (%v)", object, err)
+	}
+	if _, err := s.Normalize(150.0); err == nil {
+		t.Fatal("Expected a value above the maximum to be rejected")
+	}
+	if _, err := s.Normalize(-1.0); err == nil {
+		t.Fatal("Expected a value below the minimum to be rejected")
+	}
+	if _, err := s.Normalize("lots"); err == nil {
+		t.Fatal("Expected an unparsable string to be rejected")
+	}
+	if _, err := s.Normalize(true); err == nil {
+		t.Fatal("Expected a bool to be rejected")
+	}
+
+	ts := &Schema{Predicate: "seen", Type: core.ValueTimestamp}
+	object, err = ts.Normalize("2024-05-01T12:00:00Z")
+	if err != nil {
+		t.Fatalf("Expected a timestamp string to be accepted, got %v", err)
+	}
+	if !object.(time.Time).Equal(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)) {
+		t.Fatalf("Unexpected timestamp %v", object)
+	}
+}
+
+func TestRegistry_PutVersions(t *testing.T) {
+	r := NewRegistry()
+
+	stored, err := r.Put(&Schema{Predicate: "status", Type: core.ValueString, Version: 2})
+	if err != nil || !stored {
+		t.Fatalf("Expected first schema to be stored, got %v (%v)", stored, err)
+	}
+
+	stored, _ = r.Put(&Schema{Predicate: "status", Type: core.ValueInt, Version: 1})
+	if stored {
+		t.Fatal("Expected an older version to be ignored")
+	}
+	stored, _ = r.Put(&Schema{Predicate: "status", Type: core.ValueString, Version: 2})
+	if stored {
+		t.Fatal("Expected an identical schema to be ignored")
+	}
+	if _, err := r.Put(&Schema{Predicate: "status", Type: "colour", Version: 3}); err == nil {
+		t.Fatal("Expected an invalid schema to be refused")
+	}
+
+	// Conflicting definitions of the same version settle the same way in any order
+	a := &Schema{Predicate: "mode", Enum: []string{"a"}, Version: 1}
+	b := &Schema{Predicate: "mode", Enum: []string{"b"}, Version: 1}
+	r1, r2 := NewRegistry(), NewRegistry()
+	r1.Put(a)
+	r1.Put(b)
+	r2.Put(b)
+	r2.Put(a)
+	if r1.Get("mode").Enum[0] != r2.Get("mode").Enum[0] {
+		t.Fatal("Expected registries to converge on the same schema")
+	}
+
+	if r.Version("status") != 2 || r.Version("missing") != 0 {
+		t.Fatal("Unexpected schema versions")
+	}
+}
+
+func TestRegistry_GetPrefersMostSpecific(t *testing.T) {
+	r := NewRegistry()
+	r.Put(&Schema{Predicate: "*", Type: core.ValueString})
+	r.Put(&Schema{Predicate: "cpu_*", Type: core.ValueFloat, Resolver: "fusion", DefaultTTLSeconds: 30})
+	r.Put(&Schema{Predicate: "cpu_temp", Type: core.ValueInt})
+
+	if r.Get("cpu_temp").Type != core.ValueInt {
+		t.Fatal("Expected the exact schema to win")
+	}
+	if r.Get("cpu_usage").Type != core.ValueFloat {
+		t.Fatal("Expected the longer pattern to win")
+	}
+	if r.Get("name").Type != core.ValueString {
+		t.Fatal("Expected the wildcard schema to apply")
+	}
+	if r.Resolver("cpu_usage") != reconciliation.ModeFusion || r.DefaultTTL("cpu_usage") != 30 {
+		t.Fatal("Expected the pattern's resolver and TTL")
+	}
+	if len(r.List()) != 3 || r.List()[0].Predicate != "*" {
+		t.Fatal("Expected schemas listed by predicate")
+	}
+}
+
+func TestRegistry_Validate(t *testing.T) {
+	r := NewRegistry()
+	r.Put(&Schema{Predicate: "status", Enum: []string{"up", "down"}})
+
+	kpak := core.NewKpak("server1", "status", "DOWN", "scout", 0.9)
+	originalID := kpak.ID
+	if err := r.Validate(kpak); err != nil {
+		t.Fatalf("Expected k-pak to validate, got %v", err)
+	}
+	if kpak.Object != "down" {
+		t.Fatalf("Expected object to be normalized, got %v", kpak.Object)
+	}
+	if kpak.ID == originalID {
+		t.Fatal("Expected the ID to follow the normalized object")
+	}
+
+	if err := r.Validate(core.NewKpak("server1", "status", "sideways", "scout", 0.9)); err == nil {
+		t.Fatal("Expected an invalid k-pak to be rejected")
+	}
+	if err := r.Validate(core.NewKpak("server1", "owner", "anything", "scout", 0.9)); err != nil {
+		t.Fatal("Expected k-paks without a schema to pass")
+	}
+}
+
+func TestRegistry_CRDT(t *testing.T) {
+	registry := NewRegistry()
+	registry.Put(&Schema{Predicate: "incidents", CRDT: crdt.GCounter})
+
+	if registry.CRDT("incidents") != crdt.GCounter || registry.CRDT("status") != "" {
+		t.Fatal("Unexpected CRDT lookup")
+	}
+
+	// Counter updates are coerced to integers even without a declared type
+	kpak := core.NewKpak("svc-a", "incidents", "3", "pager", 0.9)
+	if err := registry.Validate(kpak); err != nil {
+		t.Fatalf("Expected increment to be valid, got %v", err)
+	}
+	if kpak.Object != int64(3) {
+		t.Fatalf("Expected increment coerced to int64, got %#v", kpak.Object)
+	}
+	if err := registry.Validate(core.NewKpak("svc-a", "incidents", "many", "pager", 0.9)); err == nil {
+		t.Fatal("Expected a non-numeric increment to be rejected")
+	}
+}
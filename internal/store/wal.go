@@ -2,20 +2,67 @@ package store
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/logging"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
+	"github.com/Pew-X/sutra/internal/tracing"
 )
 
+// logger is the storage layer's logger.
+var logger = logging.For("store")
+
 // WAL (Write-Ahead Log) provides persistent storage for k-paks.
 // It ensures the agent's memory survives restarts. very primitive
 type WAL struct {
 	filePath string
 	file     *os.File
 	mutex    sync.Mutex
+	observe  func(op string, d time.Duration) // Latency hook for "append" and "fsync" (nil = none)
+	lastSync time.Duration                    // Latency of the last fsync
+	lastErr  error                            // Error of the last write, nil once a write succeeds
+	lastSave time.Time                        // When a record last reached the disk
+}
+
+// EntryType identifies the kind of record stored in the log.
+type EntryType string
+
+const (
+	EntryKpak    EntryType = "kpak"    // A claim that was accepted or kept as a runner-up
+	EntryPromote EntryType = "promote" // A runner-up was promoted to truth
+	EntryRetract EntryType = "retract" // A source withdrew its claims for a subject+predicate (or one value of it)
+	EntrySchema  EntryType = "schema"  // A predicate schema was defined or replaced
+	EntryCRDT    EntryType = "crdt"    // The replicated state of a CRDT predicate changed
+	EntryPin     EntryType = "pin"     // An operator pinned a fact or lifted a pin
+	EntryReview  EntryType = "review"  // A claim was held for review, approved or rejected
+)
+
+// Entry is a single record in the log. Plain k-pak lines written by Append
+// are read back as EntryKpak entries, so logs from older agents stay readable.
+type Entry struct {
+	Type      EntryType           `json:"entry_type"`
+	Kpak      *core.Kpak          `json:"kpak,omitempty"`
+	Schema    *schema.Schema      `json:"schema,omitempty"`
+	CRDT      *crdt.State         `json:"crdt,omitempty"`
+	Pin       *reconciliation.Pin `json:"pin,omitempty"`
+	Review    *review.Item        `json:"review,omitempty"`
+	Subject   string              `json:"subject,omitempty"`
+	Predicate string              `json:"predicate,omitempty"`
+	Source    string              `json:"source,omitempty"`
+	ObjectKey string              `json:"object_key,omitempty"` // Retracted value, empty for every value
+	Timestamp int64               `json:"timestamp"`
 }
 
 // NewWAL creates a new Write-Ahead Log at the specified path.
@@ -32,35 +79,147 @@ func NewWAL(filePath string) (*WAL, error) {
 		return nil, fmt.Errorf("failed to open WAL file: %w", err)
 	}
 
+	// A log carried over from an earlier run was last saved when it was last written
+	lastSave := time.Now()
+	if info, err := file.Stat(); err == nil && info.Size() > 0 {
+		lastSave = info.ModTime()
+	}
+
 	return &WAL{
 		filePath: filePath,
 		file:     file,
+		lastSave: lastSave,
 	}, nil
 }
 
 // Append writes a k-pak to the log.
 func (w *WAL) Append(kpak *core.Kpak) error {
+	_, err := w.appendKpak(kpak)
+	return err
+}
+
+// AppendContext is Append traced as a span under ctx, with the fsync latency recorded on it.
+func (w *WAL) AppendContext(ctx context.Context, kpak *core.Kpak) error {
+	_, span := tracing.Start(ctx, "github.com/Pew-X/sutra/internal/store", "WAL.Append", tracing.KpakAttributes(kpak)...)
+	defer span.End()
+
+	fsync, err := w.appendKpak(kpak)
+	span.SetAttributes(attribute.Float64("wal.fsync_ms", float64(fsync)/float64(time.Millisecond)))
+	tracing.RecordError(span, err)
+	return err
+}
+
+// appendKpak writes a k-pak and returns how long its fsync took.
+func (w *WAL) appendKpak(kpak *core.Kpak) (time.Duration, error) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
 	// Serialize k-pak to JSON
 	data, err := kpak.ToJSON()
 	if err != nil {
-		return fmt.Errorf("failed to serialize k-pak: %w", err)
+		return 0, fmt.Errorf("failed to serialize k-pak: %w", err)
 	}
 
-	// Write to file with newline
-	_, err = fmt.Fprintf(w.file, "%s\n", data)
+	err = w.writeLine(data)
+	return w.lastSync, err
+}
+
+// AppendEntry writes a typed record to the log.
+func (w *WAL) AppendEntry(entry *Entry) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	data, err := json.Marshal(entry)
 	if err != nil {
+		return fmt.Errorf("failed to serialize WAL entry: %w", err)
+	}
+
+	return w.writeLine(data)
+}
+
+// SetObserver sets a hook that receives the latency of every append and fsync.
+func (w *WAL) SetObserver(observe func(op string, d time.Duration)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.observe = observe
+}
+
+// writeLine appends a record and syncs it to disk. The caller holds the mutex.
+func (w *WAL) writeLine(data []byte) error {
+	start := time.Now()
+
+	// Write to file with newline
+	if _, err := fmt.Fprintf(w.file, "%s\n", data); err != nil {
+		w.lastErr = err
 		return fmt.Errorf("failed to write to WAL: %w", err)
 	}
 
 	// Force sync to disk for durability may require more sophisticated handling in production
-	return w.file.Sync()
+	synced := time.Now()
+	err := w.file.Sync()
+	done := time.Now()
+	w.lastSync = done.Sub(synced)
+	w.lastErr = err
+	if err == nil {
+		w.lastSave = done
+	}
+	if w.observe != nil {
+		w.observe("fsync", w.lastSync)
+		w.observe("append", done.Sub(start))
+	}
+	return err
+}
+
+// Health reports the latency of the last fsync and whether the log can be written:
+// it must be open, its file must still exist, and the last write must have succeeded.
+func (w *WAL) Health() (lastSync time.Duration, err error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil {
+		return w.lastSync, fmt.Errorf("WAL is closed")
+	}
+	if _, err := os.Stat(w.filePath); err != nil {
+		return w.lastSync, fmt.Errorf("WAL file is gone: %w", err)
+	}
+	if w.lastErr != nil {
+		return w.lastSync, fmt.Errorf("last WAL write failed: %w", w.lastErr)
+	}
+	return w.lastSync, nil
+}
+
+// LastSave returns when a record last reached the disk, or when the log was opened
+// if nothing has been written to it yet.
+func (w *WAL) LastSave() time.Time {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	return w.lastSave
+}
+
+// Path returns the log's file path.
+func (w *WAL) Path() string {
+	return w.filePath
 }
 
 // Load reads all k-paks from the log file.
 func (w *WAL) Load() ([]*core.Kpak, error) {
+	entries, err := w.LoadEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	var kpaks []*core.Kpak
+	for _, entry := range entries {
+		if entry.Kpak != nil {
+			kpaks = append(kpaks, entry.Kpak)
+		}
+	}
+
+	return kpaks, nil
+}
+
+// LoadEntries reads all records from the log file in the order they were written.
+func (w *WAL) LoadEntries() ([]*Entry, error) {
 	// Open file for reading
 	file, err := os.Open(w.filePath)
 	if err != nil {
@@ -72,7 +231,7 @@ func (w *WAL) Load() ([]*core.Kpak, error) {
 	}
 	defer file.Close()
 
-	var kpaks []*core.Kpak
+	var entries []*Entry
 	scanner := bufio.NewScanner(file)
 	lineNum := 0
 
@@ -85,22 +244,39 @@ func (w *WAL) Load() ([]*core.Kpak, error) {
 			continue
 		}
 
-		// Parse k-pak from JSON
-		kpak, err := core.FromJSON([]byte(line))
+		// Parse entry from JSON
+		entry, err := parseEntry([]byte(line))
 		if err != nil {
 			// Log error but continue - don't let one bad line break everything
-			fmt.Printf("Warning: failed to parse k-pak at line %d: %v\n", lineNum, err)
+			logger.Warn("Skipped unreadable WAL entry", "path", w.filePath, "line", lineNum, logging.KeyError, err)
 			continue
 		}
 
-		kpaks = append(kpaks, kpak)
+		entries = append(entries, entry)
 	}
 
 	if err := scanner.Err(); err != nil {
 		return nil, fmt.Errorf("error reading WAL file: %w", err)
 	}
 
-	return kpaks, nil
+	return entries, nil
+}
+
+// parseEntry decodes a log line, treating lines without an entry type as plain k-paks.
+func parseEntry(line []byte) (*Entry, error) {
+	var entry Entry
+	if err := json.Unmarshal(line, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Type != "" {
+		return &entry, nil
+	}
+
+	kpak, err := core.FromJSON(line)
+	if err != nil {
+		return nil, err
+	}
+	return &Entry{Type: EntryKpak, Kpak: kpak, Timestamp: kpak.Timestamp}, nil
 }
 
 // Close closes the WAL file.
@@ -1,13 +1,24 @@
 package store
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
 
 	"github.com/Pew-X/sutra/internal/core"
+	"github.com/Pew-X/sutra/internal/crdt"
+	"github.com/Pew-X/sutra/internal/reconciliation"
+	"github.com/Pew-X/sutra/internal/review"
+	"github.com/Pew-X/sutra/internal/schema"
 )
 
 func TestNewWAL(t *testing.T) {
@@ -480,3 +491,336 @@ func TestWAL_PersistenceAcrossInstances(t *testing.T) {
 		t.Fatal("Missing expected subjects in persisted data")
 	}
 }
+
+func TestWAL_AppendEntry(t *testing.T) {
+	// Create temp directory
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	kpak := core.NewKpak("db-1", "status", "up", "MonitorA", 0.9)
+	promoted := core.NewKpak("db-1", "status", "down", "MonitorB", 0.8)
+
+	if err := wal.Append(kpak); err != nil {
+		t.Fatalf("Failed to append k-pak: %v", err)
+	}
+	if err := wal.AppendEntry(&Entry{Type: EntryRetract, Subject: "db-1", Predicate: "status", Source: "MonitorA"}); err != nil {
+		t.Fatalf("Failed to append retract entry: %v", err)
+	}
+	if err := wal.AppendEntry(&Entry{Type: EntryPromote, Kpak: promoted}); err != nil {
+		t.Fatalf("Failed to append promote entry: %v", err)
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(entries))
+	}
+	if entries[0].Type != EntryKpak || entries[0].Kpak.ID != kpak.ID {
+		t.Fatal("Plain k-pak line should load as a kpak entry")
+	}
+	if entries[1].Type != EntryRetract || entries[1].Source != "MonitorA" || entries[1].Kpak != nil {
+		t.Fatalf("Unexpected retract entry: %+v", entries[1])
+	}
+	if entries[2].Type != EntryPromote || entries[2].Kpak.ID != promoted.ID {
+		t.Fatalf("Unexpected promote entry: %+v", entries[2])
+	}
+
+	// Load only returns entries that carry a k-pak
+	kpaks, err := wal.Load()
+	if err != nil {
+		t.Fatalf("Failed to load k-paks: %v", err)
+	}
+	if len(kpaks) != 2 {
+		t.Fatalf("Expected 2 k-paks, got %d", len(kpaks))
+	}
+}
+
+func TestWAL_SchemaEntry(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	s := &schema.Schema{Predicate: "status", Enum: []string{"up", "down"}, Version: 3}
+	if err := wal.AppendEntry(&Entry{Type: EntrySchema, Schema: s}); err != nil {
+		t.Fatalf("Failed to append schema entry: %v", err)
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != EntrySchema || entries[0].Schema == nil {
+		t.Fatalf("Expected a schema entry, got %+v", entries)
+	}
+	if entries[0].Schema.Predicate != "status" || entries[0].Schema.Version != 3 || len(entries[0].Schema.Enum) != 2 {
+		t.Fatalf("Unexpected schema: %+v", entries[0].Schema)
+	}
+}
+
+func TestWAL_PinEntry(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	pin := &reconciliation.Pin{
+		Kpak:      core.NewKpak("server1", "health", "down", "alice", 1.0),
+		Operator:  "alice",
+		Reason:    "maintenance",
+		UpdatedAt: 42,
+	}
+	if err := wal.AppendEntry(&Entry{Type: EntryPin, Pin: pin}); err != nil {
+		t.Fatalf("Failed to append pin entry: %v", err)
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != EntryPin || entries[0].Pin == nil {
+		t.Fatalf("Expected a pin entry, got %+v", entries)
+	}
+	if got := entries[0].Pin; got.Operator != "alice" || got.UpdatedAt != 42 || got.Kpak.Object != "down" {
+		t.Fatalf("Unexpected pin: %+v", got)
+	}
+}
+
+func TestWAL_ReviewEntry(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	kpak := core.NewKpak("server1", "status", "down", "ai-scout", 0.3)
+	item := &review.Item{ID: kpak.ID, Kpak: kpak, Reasons: []string{"low confidence"}, Status: review.StatusPending, HeldAt: time.Now()}
+	if err := wal.AppendEntry(&Entry{Type: EntryReview, Review: item}); err != nil {
+		t.Fatalf("Failed to append review entry: %v", err)
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != EntryReview || entries[0].Review == nil {
+		t.Fatalf("Expected a review entry, got %+v", entries)
+	}
+	if got := entries[0].Review; got.ID != kpak.ID || got.Status != review.StatusPending || got.Kpak.Object != "down" {
+		t.Fatalf("Unexpected review item: %+v", got)
+	}
+}
+
+func TestWAL_CRDTEntry(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	state := crdt.NewState(crdt.GCounter, "svc-a", "incidents")
+	state.Apply(core.NewKpak("svc-a", "incidents", int64(4), "pager", 0.9), "agent-a")
+	if err := wal.AppendEntry(&Entry{Type: EntryCRDT, CRDT: state}); err != nil {
+		t.Fatalf("Failed to append CRDT entry: %v", err)
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil {
+		t.Fatalf("Failed to load entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Type != EntryCRDT || entries[0].CRDT == nil {
+		t.Fatalf("Expected a CRDT entry, got %+v", entries)
+	}
+	if entries[0].CRDT.Type != crdt.GCounter || entries[0].CRDT.Value() != int64(4) {
+		t.Fatalf("Unexpected CRDT state: %+v", entries[0].CRDT)
+	}
+}
+
+func TestWAL_Observer(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	observed := make(map[string]int)
+	wal.SetObserver(func(op string, d time.Duration) {
+		if d < 0 {
+			t.Fatalf("Negative %s latency %v", op, d)
+		}
+		observed[op]++
+	})
+
+	if err := wal.Append(core.NewKpak("server1", "cpu", "10", "scout", 0.9)); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if err := wal.AppendEntry(&Entry{Type: EntryRetract, Subject: "server1", Predicate: "cpu", Source: "scout"}); err != nil {
+		t.Fatalf("AppendEntry failed: %v", err)
+	}
+	if observed["append"] != 2 || observed["fsync"] != 2 {
+		t.Fatalf("Expected two appends and two fsyncs, got %v", observed)
+	}
+}
+
+func TestWAL_Health(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "test.log")
+	wal, err := NewWAL(path)
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	if wal.Path() != path {
+		t.Fatalf("Expected path %s, got %s", path, wal.Path())
+	}
+
+	if err := wal.Append(core.NewKpak("server1", "cpu", "10", "scout", 0.9)); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	if _, err := wal.Health(); err != nil {
+		t.Fatalf("Expected a healthy WAL, got %v", err)
+	}
+
+	os.Remove(path)
+	if _, err := wal.Health(); err == nil {
+		t.Fatal("Expected a WAL whose file was removed to be unhealthy")
+	}
+
+	wal.Close()
+	if _, err := wal.Health(); err == nil {
+		t.Fatal("Expected a closed WAL to be unhealthy")
+	}
+}
+
+func TestWAL_LastSave(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	path := filepath.Join(tempDir, "test.log")
+	wal, err := NewWAL(path)
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	opened := wal.LastSave()
+	if time.Since(opened) > time.Minute {
+		t.Fatalf("Expected a new WAL to count from when it was opened, got %v", opened)
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	if err := wal.Append(core.NewKpak("server1", "cpu", "10", "scout", 0.9)); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+	saved := wal.LastSave()
+	if !saved.After(opened) {
+		t.Fatalf("Expected an append to move the last save on, got %v after %v", saved, opened)
+	}
+	wal.Close()
+
+	// A reopened log was last saved when its file was last written
+	old := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatalf("Failed to age the WAL: %v", err)
+	}
+	reopened, err := NewWAL(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen WAL: %v", err)
+	}
+	defer reopened.Close()
+	if since := time.Since(reopened.LastSave()); since < 59*time.Minute {
+		t.Fatalf("Expected the file's age to carry over, got %v", since)
+	}
+}
+
+func TestWAL_AppendContext(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "wal_test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	wal, err := NewWAL(filepath.Join(tempDir, "test.log"))
+	if err != nil {
+		t.Fatalf("Failed to create WAL: %v", err)
+	}
+	defer wal.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	kpak := core.NewKpak("server1", "cpu", "10", "scout", 0.9)
+	if err := wal.AppendContext(context.Background(), kpak); err != nil {
+		t.Fatalf("AppendContext failed: %v", err)
+	}
+	if err := wal.Append(kpak); err != nil {
+		t.Fatalf("Append failed: %v", err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 1 || spans[0].Name() != "WAL.Append" {
+		t.Fatalf("Expected one WAL.Append span, got %v", spans)
+	}
+	attributes := make(map[string]bool)
+	for _, kv := range spans[0].Attributes() {
+		attributes[string(kv.Key)] = true
+	}
+	if !attributes["wal.fsync_ms"] || !attributes["kpak.id"] {
+		t.Fatalf("Expected fsync latency and k-pak attributes, got %v", spans[0].Attributes())
+	}
+
+	entries, err := wal.LoadEntries()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Expected both appends in the log, got %d, %v", len(entries), err)
+	}
+}
@@ -0,0 +1,113 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// DefaultServiceName is the service spans are reported under.
+const DefaultServiceName = "sutra-agent"
+
+// Config selects where spans are exported.
+type Config struct {
+	Endpoint    string  `yaml:"endpoint"`     // OTLP/gRPC collector address, e.g. "localhost:4317" (empty = tracing off)
+	Insecure    bool    `yaml:"insecure"`     // Connect to the collector without TLS
+	SampleRatio float64 `yaml:"sample_ratio"` // Share of new traces recorded; traces from peers follow their sender (0 = all)
+	ServiceName string  `yaml:"service_name"` // (default "sutra-agent")
+}
+
+// Validate checks that the sample ratio is in range.
+func (c Config) Validate() error {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("tracing: sample_ratio must be between 0 and 1")
+	}
+	return nil
+}
+
+// Setup installs a global tracer provider that batches spans to the collector.
+// The returned function flushes outstanding spans and stops exporting.
+func Setup(config Config, node string) (func(context.Context) error, error) {
+	exporterOptions := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(config.Endpoint)}
+	if config.Insecure {
+		exporterOptions = append(exporterOptions, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(context.Background(), exporterOptions...)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: failed to create OTLP exporter: %w", err)
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultServiceName
+	}
+	ratio := config.SampleRatio
+	if ratio == 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", serviceName),
+			attribute.String("service.instance.id", node),
+		)),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Start starts a span in the given instrumentation scope, under any span in ctx.
+// Without Setup spans are not recorded.
+func Start(ctx context.Context, scope, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(scope).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// Inject returns the W3C trace context of the span in ctx, for sending to peers
+// (nil = no span).
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx carrying the remote span described by a peer's trace context.
+func Extract(ctx context.Context, traceContext map[string]string) context.Context {
+	if len(traceContext) == 0 {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier(traceContext))
+}
+
+// KpakAttributes describe a k-pak on a span.
+func KpakAttributes(kpak *core.Kpak) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("kpak.id", kpak.ID),
+		attribute.String("kpak.subject", kpak.Subject),
+		attribute.String("kpak.predicate", kpak.Predicate),
+		attribute.String("kpak.source", kpak.Source),
+	}
+}
+
+// RecordError marks a span failed.
+func RecordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
@@ -0,0 +1,105 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/grpc"
+
+	"github.com/Pew-X/sutra/internal/core"
+)
+
+// collectorStub is an OTLP/gRPC trace collector that keeps what it receives.
+type collectorStub struct {
+	collectortrace.UnimplementedTraceServiceServer
+	spans []*tracepb.ResourceSpans
+	mutex sync.Mutex
+}
+
+func (c *collectorStub) Export(ctx context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.spans = append(c.spans, req.ResourceSpans...)
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func TestSetup_ExportsToCollector(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	collector := &collectorStub{}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	defer otel.SetTracerProvider(noop.NewTracerProvider())
+
+	shutdown, err := Setup(Config{Endpoint: listener.Addr().String(), Insecure: true}, "node-1")
+	if err != nil {
+		t.Fatalf("Setup failed: %v", err)
+	}
+	_, span := Start(context.Background(), "test", "Agent.Ingest", KpakAttributes(core.NewKpak("server1", "cpu", "10", "scout", 0.9))...)
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown failed: %v", err)
+	}
+
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	if len(collector.spans) != 1 {
+		t.Fatalf("Expected one batch of spans, got %d", len(collector.spans))
+	}
+	resource := collector.spans[0]
+	attributes := make(map[string]string)
+	for _, kv := range resource.Resource.Attributes {
+		attributes[kv.Key] = kv.Value.GetStringValue()
+	}
+	if attributes["service.name"] != DefaultServiceName || attributes["service.instance.id"] != "node-1" {
+		t.Fatalf("Unexpected resource attributes: %v", attributes)
+	}
+	spans := resource.ScopeSpans[0].Spans
+	if len(spans) != 1 || spans[0].Name != "Agent.Ingest" {
+		t.Fatalf("Unexpected spans: %v", spans)
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	if Inject(context.Background()) != nil {
+		t.Fatal("Expected no trace context without a span")
+	}
+
+	provider := sdktrace.NewTracerProvider()
+	ctx, span := provider.Tracer("test").Start(context.Background(), "parent")
+	defer span.End()
+
+	carrier := Inject(ctx)
+	if carrier["traceparent"] == "" {
+		t.Fatalf("Expected a traceparent, got %v", carrier)
+	}
+	remote := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+	if !remote.IsRemote() || remote.TraceID() != span.SpanContext().TraceID() || remote.SpanID() != span.SpanContext().SpanID() {
+		t.Fatalf("Expected the parent's span context, got %+v", remote)
+	}
+	if Extract(ctx, nil) != ctx {
+		t.Fatal("Expected ctx back without a trace context")
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	if err := (Config{SampleRatio: 0.5}).Validate(); err != nil {
+		t.Fatalf("Expected a valid ratio, got %v", err)
+	}
+	if err := (Config{SampleRatio: 1.5}).Validate(); err == nil {
+		t.Fatal("Expected a ratio above 1 to be rejected")
+	}
+}